@@ -0,0 +1,87 @@
+// Command canary-cleanup removes the data a PR canary wrote under canary
+// isolation: it deletes the canary's <table>-pr-<N> table, or every record
+// under the CANARY#<N># key prefix in the shared table.
+//
+// Usage:
+//
+//	canary-cleanup -pr 123 [-table store-items] [-isolation prefix]
+//
+// The table, endpoint and isolation policy default to the service's
+// DYNAMODB_TABLE_NAME, DYNAMODB_ENDPOINT and CANARY_ISOLATION settings.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/data"
+)
+
+// Config holds the store settings shared with the service
+type Config struct {
+	DynamoTableName string            `envconfig:"DYNAMODB_TABLE_NAME" default:""`
+	DynamoEndpoint  string            `envconfig:"DYNAMODB_ENDPOINT" default:""`
+	CanaryIsolation map[string]string `envconfig:"CANARY_ISOLATION" default:""`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		logging.WithError(err).Fatal("Failed to process config")
+	}
+
+	pr := flag.String("pr", "", "PR number of the canary to clean up (required)")
+	table := flag.String("table", cfg.DynamoTableName, "production table name (env DYNAMODB_TABLE_NAME)")
+	isolation := flag.String("isolation", "", "canary isolation policy: table or prefix (default from CANARY_ISOLATION)")
+	timeout := flag.Duration("timeout", 10*time.Minute, "timeout for the cleanup")
+	flag.Parse()
+
+	if *pr == "" || *table == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *isolation == "" {
+		*isolation = cfg.CanaryIsolation[*table]
+	}
+	canaryIsolation, err := data.ParseCanaryIsolation(*isolation)
+	if err != nil {
+		logging.WithError(err).Fatal("Invalid canary isolation")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	awsConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		logging.WithError(err).Fatal("Failed to load AWS config")
+	}
+	dynamoClient := dynamodb.NewFromConfig(awsConfig, func(o *dynamodb.Options) {
+		if cfg.DynamoEndpoint != "" {
+			o.BaseEndpoint = &cfg.DynamoEndpoint
+		}
+	})
+
+	store := data.NewDynamoStore(dynamoClient, *table, canaryIsolation)
+	deleted, err := store.DropCanaryData(ctx, *pr)
+	if err != nil {
+		logging.WithError(err).WithFields(logrus.Fields{
+			"table": *table,
+			"pr":    *pr,
+		}).Fatal("Failed to clean up canary data")
+	}
+
+	logging.WithFields(logrus.Fields{
+		"table":     *table,
+		"pr":        *pr,
+		"isolation": canaryIsolation,
+		"deleted":   deleted,
+	}).Info("Canary data cleaned up")
+}
@@ -0,0 +1,633 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+func init() {
+	register(command{name: "create-item", summary: "Create an item", run: createItem})
+	register(command{name: "get-item", summary: "Show an item", run: getItem})
+	register(command{name: "update-item", summary: "Change fields of an item", run: updateItem})
+	register(command{name: "delete-item", summary: "Discontinue an item", run: deleteItem})
+	register(command{name: "list-items", summary: "List items, optionally filtered", run: listItems})
+	register(command{name: "update-inventory", summary: "Add or remove stock for an item", run: updateInventory})
+	register(command{name: "list-low-stock", summary: "List items at or below their reorder point", run: listLowStock})
+	register(command{name: "get-config", summary: "Show tenant settings and quotas", run: getConfig})
+	register(command{name: "update-config", summary: "Change tenant settings", run: updateConfig})
+	register(command{name: "set-quotas", summary: "Change tenant quotas (operators only)", run: setQuotas})
+	register(command{name: "get-usage", summary: "Show tenant usage against quotas", run: getUsage})
+	register(command{name: "recount-items", summary: "Recompute the tenant's item count (operators only)", run: recountItems})
+	register(command{name: "list-audit-events", summary: "List the audit trail of catalog changes", run: listAuditEvents})
+}
+
+// errUsage reports bad command flags; flag.ContinueOnError has already printed why
+var errUsage = errors.New("invalid arguments")
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("storectl "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		return errUsage
+	}
+	return nil
+}
+
+// tenant returns the -tenant global flag, which every command needs
+func (c *cli) tenant() (int64, error) {
+	if c.opts.tenantID <= 0 {
+		return 0, errors.New("-tenant (or STORECTL_TENANT) is required")
+	}
+	return c.opts.tenantID, nil
+}
+
+// itemID reads the item ID from -id or the first positional argument
+func itemID(fs *flag.FlagSet, id string) (string, error) {
+	if id == "" && fs.NArg() > 0 {
+		id = fs.Arg(0)
+	}
+	if id == "" {
+		return "", errors.New("item ID is required (-id or first argument)")
+	}
+	return id, nil
+}
+
+// isSet reports whether a flag was given on the command line
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func createItem(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("create-item")
+	name := fs.String("name", "", "item name (required)")
+	description := fs.String("description", "", "item description")
+	price := fs.Float64("price", 0, "item price")
+	category := fs.String("category", "", "category: electronics, clothing, books, home, sports")
+	sku := fs.String("sku", "", "stock keeping unit")
+	inventory := fs.Int("inventory", 0, "initial inventory count")
+	threshold := fs.Int("low-stock-threshold", 0, "reorder point (0 = tenant default)")
+	tags := fs.String("tags", "", "comma-separated tags")
+	createdBy := fs.String("created-by", os.Getenv("USER"), "user recorded as the creator")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+	cat, err := parseCategory(*category)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.CreateItem(ctx, &pb.CreateItemRequest{
+		TenantId:          tenantID,
+		Name:              *name,
+		Description:       *description,
+		Price:             *price,
+		Category:          cat,
+		Sku:               *sku,
+		InventoryCount:    int32(*inventory),
+		LowStockThreshold: int32(*threshold),
+		Tags:              splitList(*tags),
+		CreatedBy:         *createdBy,
+	})
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+func getItem(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("get-item")
+	id := fs.String("id", "", "item ID")
+	consistent := fs.Bool("consistent", false, "skip the server's item cache and read the latest write")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+	itemID, err := itemID(fs, *id)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetItem(ctx, &pb.GetItemRequest{TenantId: tenantID, Id: itemID, ConsistentRead: *consistent})
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+// updateItem changes only the fields given as flags. UpdateItem replaces the
+// whole item, so the current version is read first, bypassing the item
+// cache, and used for the rest.
+func updateItem(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("update-item")
+	id := fs.String("id", "", "item ID")
+	name := fs.String("name", "", "item name")
+	description := fs.String("description", "", "item description")
+	price := fs.Float64("price", 0, "item price")
+	category := fs.String("category", "", "category: electronics, clothing, books, home, sports")
+	itemStatus := fs.String("status", "", "status: active, inactive, out_of_stock, discontinued")
+	sku := fs.String("sku", "", "stock keeping unit")
+	inventory := fs.Int("inventory", 0, "inventory count")
+	threshold := fs.Int("low-stock-threshold", 0, "reorder point (0 = tenant default)")
+	tags := fs.String("tags", "", "comma-separated tags, replacing the current ones")
+	updatedBy := fs.String("updated-by", os.Getenv("USER"), "user recorded as the updater")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+	itemID, err := itemID(fs, *id)
+	if err != nil {
+		return err
+	}
+
+	current, err := c.client.GetItem(ctx, &pb.GetItemRequest{TenantId: tenantID, Id: itemID, ConsistentRead: true})
+	if err != nil {
+		return err
+	}
+	item := current.GetItem()
+
+	req := &pb.UpdateItemRequest{
+		TenantId:          tenantID,
+		Id:                itemID,
+		Name:              item.GetName(),
+		Description:       item.GetDescription(),
+		Price:             item.GetPrice(),
+		Category:          item.GetCategory(),
+		Status:            item.GetStatus(),
+		Sku:               item.GetSku(),
+		InventoryCount:    item.GetInventoryCount(),
+		LowStockThreshold: item.GetLowStockThreshold(),
+		Tags:              item.GetTags(),
+		UpdatedBy:         *updatedBy,
+	}
+	if isSet(fs, "name") {
+		req.Name = *name
+	}
+	if isSet(fs, "description") {
+		req.Description = *description
+	}
+	if isSet(fs, "price") {
+		req.Price = *price
+	}
+	if isSet(fs, "category") {
+		if req.Category, err = parseCategory(*category); err != nil {
+			return err
+		}
+	}
+	if isSet(fs, "status") {
+		if req.Status, err = parseStatus(*itemStatus); err != nil {
+			return err
+		}
+	}
+	if isSet(fs, "sku") {
+		req.Sku = *sku
+	}
+	if isSet(fs, "inventory") {
+		req.InventoryCount = int32(*inventory)
+	}
+	if isSet(fs, "low-stock-threshold") {
+		req.LowStockThreshold = int32(*threshold)
+	}
+	if isSet(fs, "tags") {
+		req.Tags = splitList(*tags)
+	}
+
+	resp, err := c.client.UpdateItem(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+func deleteItem(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("delete-item")
+	id := fs.String("id", "", "item ID")
+	deletedBy := fs.String("deleted-by", os.Getenv("USER"), "user recorded as the deleter")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+	itemID, err := itemID(fs, *id)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.DeleteItem(ctx, &pb.DeleteItemRequest{TenantId: tenantID, Id: itemID, DeletedBy: *deletedBy})
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+func listItems(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("list-items")
+	category := fs.String("category", "", "filter by category")
+	itemStatus := fs.String("status", "", "filter by status")
+	search := fs.String("search", "", "search in name and description")
+	pageSize := fs.Int("page-size", 0, "items per page (server default 100)")
+	pageToken := fs.String("page-token", "", "continue from a previous page")
+	all := fs.Bool("all", false, "follow next page tokens until the last page")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+	cat, err := parseCategory(*category)
+	if err != nil {
+		return err
+	}
+	st, err := parseStatus(*itemStatus)
+	if err != nil {
+		return err
+	}
+
+	result := &pb.ListItemsResponse{}
+	err = paginate(*pageToken, *all, func(token string) (string, error) {
+		resp, err := c.client.ListItems(ctx, &pb.ListItemsRequest{
+			TenantId:    tenantID,
+			Category:    cat,
+			Status:      st,
+			SearchQuery: *search,
+			PageSize:    int32(*pageSize),
+			PageToken:   token,
+		})
+		if err != nil {
+			return "", err
+		}
+		result.Items = append(result.Items, resp.GetItems()...)
+		result.TotalCount = resp.GetTotalCount()
+		result.NextPageToken = resp.GetNextPageToken()
+		return resp.GetNextPageToken(), nil
+	})
+	if err != nil {
+		return err
+	}
+	return c.out.print(result)
+}
+
+func updateInventory(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("update-inventory")
+	id := fs.String("id", "", "item ID")
+	change := fs.Int("change", 0, "quantity to add (positive) or remove (negative)")
+	reason := fs.String("reason", "", "reason for the change")
+	updatedBy := fs.String("updated-by", os.Getenv("USER"), "user recorded as the updater")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+	itemID, err := itemID(fs, *id)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.UpdateInventory(ctx, &pb.UpdateInventoryRequest{
+		TenantId:       tenantID,
+		ItemId:         itemID,
+		QuantityChange: int32(*change),
+		Reason:         *reason,
+		UpdatedBy:      *updatedBy,
+	})
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+func listLowStock(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("list-low-stock")
+	pageSize := fs.Int("page-size", 0, "items per page (server default 100)")
+	pageToken := fs.String("page-token", "", "continue from a previous page")
+	all := fs.Bool("all", false, "follow next page tokens until the last page")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+
+	result := &pb.ListLowStockItemsResponse{}
+	err = paginate(*pageToken, *all, func(token string) (string, error) {
+		resp, err := c.client.ListLowStockItems(ctx, &pb.ListLowStockItemsRequest{
+			TenantId:  tenantID,
+			PageSize:  int32(*pageSize),
+			PageToken: token,
+		})
+		if err != nil {
+			return "", err
+		}
+		result.Items = append(result.Items, resp.GetItems()...)
+		result.NextPageToken = resp.GetNextPageToken()
+		return resp.GetNextPageToken(), nil
+	})
+	if err != nil {
+		return err
+	}
+	return c.out.print(result)
+}
+
+func getConfig(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("get-config")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetTenantConfig(ctx, &pb.GetTenantConfigRequest{TenantId: tenantID})
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+// updateConfig changes the tenant settings given as flags
+func updateConfig(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("update-config")
+	threshold := fs.Int("default-low-stock-threshold", 0, "reorder point for items without their own")
+	updatedBy := fs.String("updated-by", os.Getenv("USER"), "user recorded as the updater")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+
+	current, err := c.client.GetTenantConfig(ctx, &pb.GetTenantConfigRequest{TenantId: tenantID})
+	if err != nil {
+		return err
+	}
+
+	req := &pb.UpdateTenantConfigRequest{
+		TenantId:                 tenantID,
+		DefaultLowStockThreshold: current.GetConfig().GetDefaultLowStockThreshold(),
+		UpdatedBy:                *updatedBy,
+	}
+	if isSet(fs, "default-low-stock-threshold") {
+		req.DefaultLowStockThreshold = int32(*threshold)
+	}
+
+	resp, err := c.client.UpdateTenantConfig(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+// setQuotas changes only the quotas given as flags, keeping the rest
+func setQuotas(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("set-quotas")
+	maxItems := fs.Int("max-items", 0, "item quota (0 = unlimited)")
+	maxWrites := fs.Int("max-writes-per-day", 0, "daily write quota (0 = unlimited)")
+	maxTags := fs.Int("max-tags-per-item", 0, "tags per item quota (0 = unlimited)")
+	maxDescription := fs.Int("max-description-length", 0, "description length quota (0 = unlimited)")
+	updatedBy := fs.String("updated-by", os.Getenv("USER"), "user recorded as the updater")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+
+	current, err := c.client.GetTenantConfig(ctx, &pb.GetTenantConfigRequest{TenantId: tenantID})
+	if err != nil {
+		return err
+	}
+	quotas := current.GetConfig().GetQuotas()
+
+	req := &pb.SetTenantQuotasRequest{
+		TenantId:  tenantID,
+		UpdatedBy: *updatedBy,
+		Quotas: &pb.TenantQuotas{
+			MaxItems:             quotas.GetMaxItems(),
+			MaxWritesPerDay:      quotas.GetMaxWritesPerDay(),
+			MaxTagsPerItem:       quotas.GetMaxTagsPerItem(),
+			MaxDescriptionLength: quotas.GetMaxDescriptionLength(),
+		},
+	}
+	if isSet(fs, "max-items") {
+		req.Quotas.MaxItems = int32(*maxItems)
+	}
+	if isSet(fs, "max-writes-per-day") {
+		req.Quotas.MaxWritesPerDay = int32(*maxWrites)
+	}
+	if isSet(fs, "max-tags-per-item") {
+		req.Quotas.MaxTagsPerItem = int32(*maxTags)
+	}
+	if isSet(fs, "max-description-length") {
+		req.Quotas.MaxDescriptionLength = int32(*maxDescription)
+	}
+
+	resp, err := c.client.SetTenantQuotas(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+func getUsage(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("get-usage")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetTenantUsage(ctx, &pb.GetTenantUsageRequest{TenantId: tenantID})
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+func recountItems(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("recount-items")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.RecountItems(ctx, &pb.RecountItemsRequest{TenantId: tenantID})
+	if err != nil {
+		return err
+	}
+	return c.out.print(resp)
+}
+
+func listAuditEvents(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("list-audit-events")
+	item := fs.String("item", "", "filter by item ID")
+	actor := fs.String("actor", "", "filter by actor")
+	method := fs.String("method", "", "filter by method, e.g. UpdateItem")
+	since := fs.String("since", "", "only events at or after this time (RFC 3339 or a duration such as 24h)")
+	until := fs.String("until", "", "only events before this time (RFC 3339 or a duration such as 1h)")
+	pageSize := fs.Int("page-size", 0, "events per page (server default 100)")
+	pageToken := fs.String("page-token", "", "continue from a previous page")
+	all := fs.Bool("all", false, "follow next page tokens until the last page")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tenantID, err := c.tenant()
+	if err != nil {
+		return err
+	}
+	startTime, err := parseTime(*since)
+	if err != nil {
+		return fmt.Errorf("invalid -since: %w", err)
+	}
+	endTime, err := parseTime(*until)
+	if err != nil {
+		return fmt.Errorf("invalid -until: %w", err)
+	}
+
+	result := &pb.ListAuditEventsResponse{}
+	err = paginate(*pageToken, *all, func(token string) (string, error) {
+		resp, err := c.client.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{
+			TenantId:  tenantID,
+			ItemId:    *item,
+			Actor:     *actor,
+			Method:    *method,
+			StartTime: startTime,
+			EndTime:   endTime,
+			PageSize:  int32(*pageSize),
+			PageToken: token,
+		})
+		if err != nil {
+			return "", err
+		}
+		result.Events = append(result.Events, resp.GetEvents()...)
+		result.NextPageToken = resp.GetNextPageToken()
+		return resp.GetNextPageToken(), nil
+	})
+	if err != nil {
+		return err
+	}
+	return c.out.print(result)
+}
+
+// paginate fetches one page starting at pageToken, or every remaining page when all is set
+func paginate(pageToken string, all bool, fetch func(token string) (string, error)) error {
+	token := pageToken
+	for {
+		next, err := fetch(token)
+		if err != nil {
+			return err
+		}
+		if !all || next == "" {
+			return nil
+		}
+		token = next
+	}
+}
+
+// parseCategory accepts "books", "BOOKS" or "ITEM_CATEGORY_BOOKS"; empty means unspecified
+func parseCategory(s string) (pb.ItemCategory, error) {
+	if s == "" {
+		return pb.ItemCategory_ITEM_CATEGORY_UNSPECIFIED, nil
+	}
+	value, ok := pb.ItemCategory_value["ITEM_CATEGORY_"+strings.TrimPrefix(strings.ToUpper(s), "ITEM_CATEGORY_")]
+	if !ok {
+		return 0, fmt.Errorf("unknown category %q", s)
+	}
+	return pb.ItemCategory(value), nil
+}
+
+// parseStatus accepts "active", "ACTIVE" or "ITEM_STATUS_ACTIVE"; empty means unspecified
+func parseStatus(s string) (pb.ItemStatus, error) {
+	if s == "" {
+		return pb.ItemStatus_ITEM_STATUS_UNSPECIFIED, nil
+	}
+	normalized := strings.ReplaceAll(strings.ToUpper(s), "-", "_")
+	value, ok := pb.ItemStatus_value["ITEM_STATUS_"+strings.TrimPrefix(normalized, "ITEM_STATUS_")]
+	if !ok {
+		return 0, fmt.Errorf("unknown status %q", s)
+	}
+	return pb.ItemStatus(value), nil
+}
+
+// parseTime accepts an RFC 3339 time or a duration counted back from now
+func parseTime(s string) (*timestamppb.Timestamp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return timestamppb.New(time.Now().Add(-d)), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// envInt64 reads an integer environment variable, ignoring unset or invalid values
+func envInt64(key string) int64 {
+	v, _ := strconv.ParseInt(os.Getenv(key), 10, 64)
+	return v
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,195 @@
+// Command storectl is a command-line client for StoreService.
+//
+// Usage:
+//
+//	storectl [global flags] <command> [command flags]
+//
+// Run "storectl help" for the list of commands.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// globalOptions are the flags shared by every command
+type globalOptions struct {
+	addr     string
+	tenantID int64
+	canary   string
+	token    string
+	output   string
+	timeout  time.Duration
+	useTLS   bool
+}
+
+// command is one storectl subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, c *cli, args []string) error
+}
+
+// cli holds what a command needs to call the service and print results
+type cli struct {
+	opts   globalOptions
+	client pb.StoreServiceClient
+	out    *printer
+}
+
+var commands = map[string]command{}
+
+func register(cmd command) {
+	commands[cmd.name] = cmd
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if st, ok := status.FromError(err); ok {
+			fmt.Fprintf(os.Stderr, "storectl: %s: %s\n", st.Code(), st.Message())
+			printDetails(st)
+		} else {
+			fmt.Fprintf(os.Stderr, "storectl: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// printDetails prints the field violations and error reason carried by a status
+func printDetails(st *status.Status) {
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				fmt.Fprintf(os.Stderr, "  %s: %s\n", violation.GetField(), violation.GetDescription())
+			}
+		case *errdetails.ErrorInfo:
+			fmt.Fprintf(os.Stderr, "  reason: %s\n", d.GetReason())
+		}
+	}
+}
+
+func run(args []string) error {
+	var opts globalOptions
+	fs := flag.NewFlagSet("storectl", flag.ContinueOnError)
+	fs.StringVar(&opts.addr, "addr", envOr("STORECTL_ADDR", "localhost:8080"), "StoreService gRPC address (env STORECTL_ADDR)")
+	fs.Int64Var(&opts.tenantID, "tenant", envInt64("STORECTL_TENANT"), "tenant ID (env STORECTL_TENANT)")
+	fs.StringVar(&opts.canary, "canary", os.Getenv("STORECTL_CANARY"), "PR number sent as the X-Canary header (env STORECTL_CANARY)")
+	fs.StringVar(&opts.token, "token", os.Getenv("STORECTL_TOKEN"), "bearer token for authentication (env STORECTL_TOKEN)")
+	fs.StringVar(&opts.output, "o", "table", "output format: table or json")
+	fs.DurationVar(&opts.timeout, "timeout", 30*time.Second, "timeout for the whole command")
+	fs.BoolVar(&opts.useTLS, "tls", false, "connect with TLS using the system root certificates")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if fs.NArg() == 0 || fs.Arg(0) == "help" {
+		usage(fs)
+		return nil
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		usage(fs)
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+
+	out, err := newPrinter(opts.output, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if opts.canary != "" && !canaryctx.IsValidCanary(opts.canary) {
+		return fmt.Errorf("invalid canary %q: must be a PR number", opts.canary)
+	}
+
+	conn, err := dial(opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+	if opts.canary != "" {
+		ctx = canaryctx.WithCanary(ctx, opts.canary)
+	}
+
+	return cmd.run(ctx, &cli{
+		opts:   opts,
+		client: pb.NewStoreServiceClient(conn),
+		out:    out,
+	}, fs.Args()[1:])
+}
+
+func dial(opts globalOptions) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if opts.useTLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(canaryctx.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(canaryctx.StreamClientInterceptor()),
+	}
+	if opts.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken{
+			token:      opts.token,
+			requireTLS: opts.useTLS,
+		}))
+	}
+
+	conn, err := grpc.NewClient(opts.addr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", opts.addr, err)
+	}
+	return conn, nil
+}
+
+// bearerToken sends the token as an authorization header on every call
+type bearerToken struct {
+	token      string
+	requireTLS bool
+}
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: storectl [global flags] <command> [command flags]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-20s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(w, "\nGlobal flags:\n")
+	fs.PrintDefaults()
+	fmt.Fprintf(w, "\nRun \"storectl <command> -h\" for command flags.\n")
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// printer renders responses as aligned tables or as JSON
+type printer struct {
+	json bool
+	w    io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case "table":
+		return &printer{w: w}, nil
+	case "json":
+		return &printer{json: true, w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q: use table or json", format)
+	}
+}
+
+// print writes a response. Table output covers every StoreService response;
+// a non-empty next page token is reported on stderr so stdout stays parseable.
+func (p *printer) print(msg proto.Message) error {
+	if p.json {
+		b, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+		_, err = fmt.Fprintln(p.w, string(b))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	switch m := msg.(type) {
+	case *pb.CreateItemResponse:
+		itemDetail(tw, m.GetItem())
+	case *pb.GetItemResponse:
+		itemDetail(tw, m.GetItem())
+	case *pb.UpdateItemResponse:
+		itemDetail(tw, m.GetItem())
+	case *pb.UpdateInventoryResponse:
+		itemDetail(tw, m.GetItem())
+		fmt.Fprintf(tw, "PREVIOUS COUNT\t%d\n", m.GetPreviousCount())
+	case *pb.DeleteItemResponse:
+		fmt.Fprintf(tw, "DELETED\t%t\n", m.GetSuccess())
+	case *pb.ListItemsResponse:
+		itemTable(tw, m.GetItems())
+		fmt.Fprintf(tw, "\n%d of %d items\n", len(m.GetItems()), m.GetTotalCount())
+		nextPage(m.GetNextPageToken())
+	case *pb.ListLowStockItemsResponse:
+		itemTable(tw, m.GetItems())
+		nextPage(m.GetNextPageToken())
+	case *pb.GetTenantConfigResponse:
+		configDetail(tw, m.GetConfig())
+	case *pb.UpdateTenantConfigResponse:
+		configDetail(tw, m.GetConfig())
+	case *pb.SetTenantQuotasResponse:
+		configDetail(tw, m.GetConfig())
+	case *pb.GetTenantUsageResponse:
+		usageTable(tw, m.GetUsage())
+	case *pb.RecountItemsResponse:
+		fmt.Fprintf(tw, "PREVIOUS ITEM COUNT\t%d\n", m.GetPreviousItemCount())
+		fmt.Fprintf(tw, "ITEM COUNT\t%d\n", m.GetItemCount())
+	case *pb.ListAuditEventsResponse:
+		auditTable(tw, m.GetEvents())
+		nextPage(m.GetNextPageToken())
+	default:
+		return fmt.Errorf("no table format for %T, use -o json", msg)
+	}
+	return tw.Flush()
+}
+
+func itemDetail(w io.Writer, item *pb.Item) {
+	rows := [][2]string{
+		{"ID", item.GetId()},
+		{"TENANT", strconv.FormatInt(item.GetTenantId(), 10)},
+		{"NAME", item.GetName()},
+		{"DESCRIPTION", item.GetDescription()},
+		{"PRICE", formatPrice(item.GetPrice())},
+		{"CATEGORY", enumName(item.GetCategory().String(), "ITEM_CATEGORY_")},
+		{"STATUS", enumName(item.GetStatus().String(), "ITEM_STATUS_")},
+		{"SKU", item.GetSku()},
+		{"INVENTORY", strconv.Itoa(int(item.GetInventoryCount()))},
+		{"LOW STOCK THRESHOLD", strconv.Itoa(int(item.GetLowStockThreshold()))},
+		{"TAGS", strings.Join(item.GetTags(), ",")},
+		{"CREATED", formatTime(item.GetCreatedAt()) + " by " + item.GetCreatedBy()},
+		{"UPDATED", formatTime(item.GetUpdatedAt()) + " by " + item.GetUpdatedBy()},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%s\n", row[0], row[1])
+	}
+}
+
+func itemTable(w io.Writer, items []*pb.Item) {
+	fmt.Fprintln(w, "ID\tNAME\tSKU\tCATEGORY\tSTATUS\tPRICE\tINVENTORY\tUPDATED")
+	for _, item := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			item.GetId(),
+			item.GetName(),
+			item.GetSku(),
+			enumName(item.GetCategory().String(), "ITEM_CATEGORY_"),
+			enumName(item.GetStatus().String(), "ITEM_STATUS_"),
+			formatPrice(item.GetPrice()),
+			item.GetInventoryCount(),
+			formatTime(item.GetUpdatedAt()),
+		)
+	}
+}
+
+func configDetail(w io.Writer, config *pb.TenantConfig) {
+	quotas := config.GetQuotas()
+	fmt.Fprintf(w, "TENANT\t%d\n", config.GetTenantId())
+	fmt.Fprintf(w, "DEFAULT LOW STOCK THRESHOLD\t%d\n", config.GetDefaultLowStockThreshold())
+	fmt.Fprintf(w, "MAX ITEMS\t%s\n", formatLimit(int64(quotas.GetMaxItems())))
+	fmt.Fprintf(w, "MAX WRITES PER DAY\t%s\n", formatLimit(int64(quotas.GetMaxWritesPerDay())))
+	fmt.Fprintf(w, "MAX TAGS PER ITEM\t%s\n", formatLimit(int64(quotas.GetMaxTagsPerItem())))
+	fmt.Fprintf(w, "MAX DESCRIPTION LENGTH\t%s\n", formatLimit(int64(quotas.GetMaxDescriptionLength())))
+	fmt.Fprintf(w, "UPDATED\t%s by %s\n", formatTime(config.GetUpdatedAt()), config.GetUpdatedBy())
+}
+
+func usageTable(w io.Writer, usage *pb.TenantUsage) {
+	quotas := usage.GetQuotas()
+	fmt.Fprintln(w, "QUOTA\tUSED\tLIMIT")
+	fmt.Fprintf(w, "items\t%d\t%s\n", usage.GetItemCount(), formatLimit(int64(quotas.GetMaxItems())))
+	fmt.Fprintf(w, "writes today\t%d\t%s\n", usage.GetWritesToday(), formatLimit(int64(quotas.GetMaxWritesPerDay())))
+}
+
+func auditTable(w io.Writer, events []*pb.AuditEvent) {
+	fmt.Fprintln(w, "TIME\tMETHOD\tITEM\tACTOR\tCHANGES")
+	for _, event := range events {
+		var changes []string
+		for _, change := range event.GetChanges() {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", change.GetField(), change.GetBefore(), change.GetAfter()))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			formatTime(event.GetTimestamp()),
+			event.GetMethod(),
+			event.GetItemId(),
+			event.GetActor(),
+			strings.Join(changes, "; "),
+		)
+	}
+}
+
+func nextPage(token string) {
+	if token != "" {
+		fmt.Fprintf(os.Stderr, "next page: -page-token %s (or -all)\n", token)
+	}
+}
+
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
+
+func formatLimit(limit int64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
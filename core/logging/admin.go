@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LoggingStatus is the JSON body served by the admin handlers
+type LoggingStatus struct {
+	Level      string      `json:"level"`
+	DebugRules []DebugRule `json:"debug_rules"`
+}
+
+// Status reports the log level and active debug rules
+func Status() LoggingStatus {
+	rules := DebugRules()
+	if rules == nil {
+		rules = []DebugRule{}
+	}
+	return LoggingStatus{
+		Level:      GetLevel().String(),
+		DebugRules: rules,
+	}
+}
+
+// LevelHandler answers /admin/logging: GET reports the status, PUT with
+// {"level": "debug"} changes the log level
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var body struct {
+				Level string `json:"level"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, "invalid JSON body", http.StatusBadRequest)
+				return
+			}
+			level, err := logrus.ParseLevel(body.Level)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			ChangeLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, Status())
+	})
+}
+
+// DebugHandler answers /admin/logging/debug: POST with {"tenant_id": 42} or
+// {"header_name": "x-request-id", "header_value": "abc"} and an optional
+// "duration" such as "10m" adds a debug rule, DELETE removes every rule
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var body struct {
+				DebugRule
+				Duration string `json:"duration"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, "invalid JSON body", http.StatusBadRequest)
+				return
+			}
+			var duration time.Duration
+			if body.Duration != "" {
+				var err error
+				if duration, err = time.ParseDuration(body.Duration); err != nil {
+					http.Error(w, "invalid duration", http.StatusBadRequest)
+					return
+				}
+			}
+			if _, err := EnableDebug(body.DebugRule, duration); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+			ClearDebugRules()
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, Status())
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
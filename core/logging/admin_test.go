@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// serve sends a request to handler and decodes the status it answers with
+func serve(t *testing.T, handler http.Handler, method, body string) (int, LoggingStatus) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, "/admin/logging", strings.NewReader(body)))
+	var status LoggingStatus
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+			t.Fatalf("body is not a logging status: %s", rec.Body)
+		}
+	}
+	return rec.Code, status
+}
+
+func TestLevelHandler(t *testing.T) {
+	captureLogs(t)
+	SetLevel(logrus.InfoLevel)
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		wantCode  int
+		wantLevel logrus.Level
+	}{
+		{name: "get", method: http.MethodGet, wantCode: http.StatusOK, wantLevel: logrus.InfoLevel},
+		{name: "change level", method: http.MethodPut, body: `{"level": "warn"}`, wantCode: http.StatusOK, wantLevel: logrus.WarnLevel},
+		{name: "unknown level", method: http.MethodPut, body: `{"level": "loud"}`, wantCode: http.StatusBadRequest, wantLevel: logrus.WarnLevel},
+		{name: "invalid body", method: http.MethodPut, body: `level=debug`, wantCode: http.StatusBadRequest, wantLevel: logrus.WarnLevel},
+		{name: "unsupported method", method: http.MethodDelete, wantCode: http.StatusMethodNotAllowed, wantLevel: logrus.WarnLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, status := serve(t, LevelHandler(), tt.method, tt.body)
+			if code != tt.wantCode {
+				t.Fatalf("status code = %d, want %d", code, tt.wantCode)
+			}
+			if code == http.StatusOK && status.Level != tt.wantLevel.String() {
+				t.Errorf("reported level = %s, want %s", status.Level, tt.wantLevel)
+			}
+			if GetLevel() != tt.wantLevel {
+				t.Errorf("level = %s, want %s", GetLevel(), tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestDebugHandler(t *testing.T) {
+	captureLogs(t)
+	t.Cleanup(func() { ClearDebugRules() })
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		wantCode  int
+		wantRules int
+	}{
+		{name: "add tenant rule", method: http.MethodPost, body: `{"tenant_id": 42, "duration": "10m"}`, wantCode: http.StatusOK, wantRules: 1},
+		{name: "add header rule", method: http.MethodPost, body: `{"header_name": "x-debug"}`, wantCode: http.StatusOK, wantRules: 2},
+		{name: "invalid duration", method: http.MethodPost, body: `{"tenant_id": 42, "duration": "soon"}`, wantCode: http.StatusBadRequest, wantRules: 2},
+		{name: "invalid rule", method: http.MethodPost, body: `{}`, wantCode: http.StatusBadRequest, wantRules: 2},
+		{name: "list", method: http.MethodGet, wantCode: http.StatusOK, wantRules: 2},
+		{name: "clear", method: http.MethodDelete, wantCode: http.StatusOK, wantRules: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, status := serve(t, DebugHandler(), tt.method, tt.body)
+			if code != tt.wantCode {
+				t.Fatalf("status code = %d, want %d", code, tt.wantCode)
+			}
+			if code == http.StatusOK && len(status.DebugRules) != tt.wantRules {
+				t.Errorf("reported rules = %+v, want %d", status.DebugRules, tt.wantRules)
+			}
+			if rules := DebugRules(); len(rules) != tt.wantRules {
+				t.Errorf("DebugRules() = %+v, want %d", rules, tt.wantRules)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultDebugDuration is how long a debug rule lasts when no duration is given
+const DefaultDebugDuration = 15 * time.Minute
+
+// DebugRule enables debug logging for requests from one tenant, or carrying
+// one request header, until it expires
+type DebugRule struct {
+	TenantID    int64     `json:"tenant_id,omitempty"`
+	HeaderName  string    `json:"header_name,omitempty"`
+	HeaderValue string    `json:"header_value,omitempty"` // Empty matches any value
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (r DebugRule) matches(tenantID int64, md metadata.MD) bool {
+	if r.TenantID != 0 {
+		return r.TenantID == tenantID
+	}
+	for _, value := range md.Get(r.HeaderName) {
+		if r.HeaderValue == "" || value == r.HeaderValue {
+			return true
+		}
+	}
+	return false
+}
+
+// debugLogger logs requests matched by a debug rule. It shares output,
+// formatting and hooks with logger but always logs at debug level, so the
+// rest of the traffic stays at the configured level.
+var debugLogger = newLogger(logrus.DebugLevel)
+
+var debugRules = struct {
+	sync.Mutex
+	rules       []DebugRule
+	maxDuration time.Duration
+}{maxDuration: time.Hour}
+
+// SetMaxDebugDuration limits how long a single debug rule may last
+func SetMaxDebugDuration(max time.Duration) {
+	debugRules.Lock()
+	defer debugRules.Unlock()
+	debugRules.maxDuration = max
+}
+
+// EnableDebug adds a rule matching either a tenant or a request header for
+// the given duration, or DefaultDebugDuration if zero
+func EnableDebug(rule DebugRule, duration time.Duration) (DebugRule, error) {
+	rule.HeaderName = strings.ToLower(strings.TrimSpace(rule.HeaderName))
+	switch {
+	case rule.TenantID < 0:
+		return DebugRule{}, errors.New("tenant_id must be positive")
+	case rule.TenantID == 0 && rule.HeaderName == "":
+		return DebugRule{}, errors.New("a tenant_id or header_name is required")
+	case rule.TenantID != 0 && rule.HeaderName != "":
+		return DebugRule{}, errors.New("set either tenant_id or header_name, not both")
+	}
+	if duration == 0 {
+		duration = DefaultDebugDuration
+	}
+
+	debugRules.Lock()
+	defer debugRules.Unlock()
+	if duration < 0 || duration > debugRules.maxDuration {
+		return DebugRule{}, fmt.Errorf("duration must be between 0 and %s", debugRules.maxDuration)
+	}
+	rule.ExpiresAt = time.Now().Add(duration).UTC()
+	debugRules.rules = append(pruneDebugRules(debugRules.rules), rule)
+
+	logger.WithFields(logrus.Fields{
+		"debug_tenant_id": rule.TenantID,
+		"debug_header":    rule.HeaderName,
+		"expires_at":      rule.ExpiresAt,
+	}).Info("Debug logging enabled for matching requests")
+	return rule, nil
+}
+
+// DebugRules returns the rules that have not yet expired
+func DebugRules() []DebugRule {
+	debugRules.Lock()
+	defer debugRules.Unlock()
+	debugRules.rules = pruneDebugRules(debugRules.rules)
+	return append([]DebugRule(nil), debugRules.rules...)
+}
+
+// ClearDebugRules removes every rule and reports how many were active
+func ClearDebugRules() int {
+	debugRules.Lock()
+	defer debugRules.Unlock()
+	removed := len(pruneDebugRules(debugRules.rules))
+	debugRules.rules = nil
+
+	logger.WithField("removed", removed).Info("Debug logging rules cleared")
+	return removed
+}
+
+func pruneDebugRules(rules []DebugRule) []DebugRule {
+	now := time.Now()
+	active := rules[:0]
+	for _, rule := range rules {
+		if now.Before(rule.ExpiresAt) {
+			active = append(active, rule)
+		}
+	}
+	return active
+}
+
+// debugMatch reports whether an active rule matches the request
+func debugMatch(ctx context.Context, tenantID int64) bool {
+	debugRules.Lock()
+	defer debugRules.Unlock()
+	if len(debugRules.rules) == 0 {
+		return false
+	}
+	debugRules.rules = pruneDebugRules(debugRules.rules)
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, rule := range debugRules.rules {
+		if rule.matches(tenantID, md) {
+			return true
+		}
+	}
+	return false
+}
+
+type debugKey struct{}
+
+// withDebug marks the request so FromContext logs it at debug level
+func withDebug(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugKey{}, true)
+}
+
+func isDebug(ctx context.Context) bool {
+	debug, _ := ctx.Value(debugKey{}).(bool)
+	return debug
+}
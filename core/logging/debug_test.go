@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+func TestEnableDebug(t *testing.T) {
+	t.Cleanup(func() { ClearDebugRules() })
+
+	tests := []struct {
+		name     string
+		rule     DebugRule
+		duration time.Duration
+		wantErr  bool
+	}{
+		{name: "tenant", rule: DebugRule{TenantID: 42}},
+		{name: "header", rule: DebugRule{HeaderName: " X-Debug ", HeaderValue: "alice"}, duration: time.Minute},
+		{name: "neither tenant nor header", rule: DebugRule{}, wantErr: true},
+		{name: "both tenant and header", rule: DebugRule{TenantID: 42, HeaderName: "x-debug"}, wantErr: true},
+		{name: "negative tenant", rule: DebugRule{TenantID: -1}, wantErr: true},
+		{name: "longer than the maximum", rule: DebugRule{TenantID: 42}, duration: 2 * time.Hour, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			rule, err := EnableDebug(tt.rule, tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnableDebug() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			want := tt.duration
+			if want == 0 {
+				want = DefaultDebugDuration
+			}
+			if expires := rule.ExpiresAt.Sub(start); expires < want || expires > want+time.Second {
+				t.Errorf("rule expires in %s, want %s", expires, want)
+			}
+			if tt.rule.HeaderName != "" && rule.HeaderName != "x-debug" {
+				t.Errorf("rule header = %q, want it trimmed and lowercased", rule.HeaderName)
+			}
+		})
+	}
+
+	if rules := DebugRules(); len(rules) != 2 {
+		t.Errorf("DebugRules() = %+v, want the 2 valid rules", rules)
+	}
+	if removed := ClearDebugRules(); removed != 2 || len(DebugRules()) != 0 {
+		t.Errorf("ClearDebugRules() = %d, want 2 and no rules left", removed)
+	}
+}
+
+func TestDebugRuleLogsMatchingRequests(t *testing.T) {
+	t.Cleanup(func() { ClearDebugRules() })
+	if _, err := EnableDebug(DebugRule{TenantID: 42}, time.Minute); err != nil {
+		t.Fatalf("EnableDebug() error = %v", err)
+	}
+	if _, err := EnableDebug(DebugRule{HeaderName: "x-debug", HeaderValue: "alice"}, time.Minute); err != nil {
+		t.Fatalf("EnableDebug() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		tenantID  int64
+		md        metadata.MD
+		wantDebug bool
+	}{
+		{name: "matching tenant", tenantID: 42, wantDebug: true},
+		{name: "matching header", tenantID: 7, md: metadata.Pairs("x-debug", "alice"), wantDebug: true},
+		{name: "header with another value", tenantID: 7, md: metadata.Pairs("x-debug", "bob")},
+		{name: "other tenant", tenantID: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t)
+			SetLevel(logrus.InfoLevel)
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			handler := func(ctx context.Context, req any) (any, error) {
+				FromContext(ctx).Debug("Cache lookup")
+				return nil, nil
+			}
+
+			UnaryServerInterceptor()(ctx, &pb.GetItemRequest{TenantId: tt.tenantID, Id: "widget"}, &grpc.UnaryServerInfo{}, handler)
+			// Other logging stays at the configured level
+			WithField("tenant_id", tt.tenantID).Debug("Unrelated")
+
+			entries := logs()
+			if debug := len(entries) == 1; debug != tt.wantDebug {
+				t.Errorf("logged %v, want the debug entry only if a rule matches", entries)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// tenantIDName is the request field holding the tenant a call addresses
+const tenantIDName protoreflect.Name = "tenant_id"
+
+// UnaryServerInterceptor seeds the request context with the tenant from the
+// request message, so FromContext entries carry it, and marks requests
+// matched by a debug rule
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(seed(ctx, req), req)
+	}
+}
+
+// StreamServerInterceptor seeds the stream context with the tenant from the
+// first received message that names one, and marks streams matched by a
+// debug rule
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &loggingServerStream{ServerStream: ss, ctx: seed(ss.Context(), nil)})
+	}
+}
+
+// loggingServerStream carries a context seeded from the stream's messages
+type loggingServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	seeded bool
+}
+
+func (s *loggingServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *loggingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if !s.seeded {
+		if _, ok := requestTenant(m); ok {
+			s.ctx = seed(s.ctx, m)
+			s.seeded = true
+		}
+	}
+	return nil
+}
+
+// seed adds the request's log fields to ctx and marks it for debug logging
+// when a debug rule matches its tenant or headers
+func seed(ctx context.Context, req interface{}) context.Context {
+	tenantID, ok := requestTenant(req)
+	if ok {
+		ctx = NewContext(ctx, logrus.Fields{TenantIDField: tenantID})
+	}
+	if !isDebug(ctx) && debugMatch(ctx, tenantID) {
+		ctx = withDebug(ctx)
+	}
+	return ctx
+}
+
+// requestTenant reads the tenant_id field of a request message
+func requestTenant(req interface{}) (int64, bool) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return 0, false
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(tenantIDName)
+	if fd == nil || fd.Kind() != protoreflect.Int64Kind || !m.Has(fd) {
+		return 0, false
+	}
+	return m.Get(fd).Int(), true
+}
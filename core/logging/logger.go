@@ -1,25 +1,104 @@
 package logging
 
 import (
+	"context"
 	"os"
+	"sync"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var logger = logrus.New()
+var logger = newLogger(logrus.InfoLevel)
 
-func init() {
-	logger.SetFormatter(&logrus.JSONFormatter{})
-	logger.SetLevel(logrus.InfoLevel)
-	logger.SetOutput(os.Stdout)
+func newLogger(level logrus.Level) *logrus.Logger {
+	l := logrus.New()
+	l.SetFormatter(&logrus.JSONFormatter{})
+	l.SetLevel(level)
+	l.SetOutput(os.Stdout)
+	l.AddHook(&standardFieldsHook{})
+	l.AddHook(&contextHook{})
+	l.AddHook(&redactHook{})
+	return l
+}
+
+// Fields added to request-scoped entries by FromContext
+const (
+	TraceIDField  = "trace_id"
+	SpanIDField   = "span_id"
+	TenantIDField = "tenant_id"
+)
+
+// ContextFieldsFunc extracts log fields from a request context
+type ContextFieldsFunc func(ctx context.Context) logrus.Fields
+
+var (
+	contextFieldsMu    sync.RWMutex
+	contextFieldsFuncs []ContextFieldsFunc
+)
+
+// AddContextFields registers a function whose fields are added to every
+// entry logged through WithContext
+func AddContextFields(fn ContextFieldsFunc) {
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+	contextFieldsFuncs = append(contextFieldsFuncs, fn)
+}
+
+// contextHook adds the registered context fields to entries that carry a context
+type contextHook struct{}
+
+func (h *contextHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *contextHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	contextFieldsMu.RLock()
+	defer contextFieldsMu.RUnlock()
+	for _, fn := range contextFieldsFuncs {
+		for key, value := range fn(entry.Context) {
+			if _, exists := entry.Data[key]; !exists {
+				entry.Data[key] = value
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	standardFieldsMu sync.RWMutex
+	standardFields   logrus.Fields
+)
+
+// standardFieldsHook adds the standard fields to every entry
+type standardFieldsHook struct{}
+
+func (h *standardFieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *standardFieldsHook) Fire(entry *logrus.Entry) error {
+	standardFieldsMu.RLock()
+	defer standardFieldsMu.RUnlock()
+	for key, value := range standardFields {
+		if _, exists := entry.Data[key]; !exists {
+			entry.Data[key] = value
+		}
+	}
+	return nil
 }
 
 // SetStandardFields sets standard fields that should be included in all log entries
 func SetStandardFields(serviceName, version string) {
-	logger = logger.WithFields(logrus.Fields{
+	standardFieldsMu.Lock()
+	defer standardFieldsMu.Unlock()
+	standardFields = logrus.Fields{
 		"service": serviceName,
 		"version": version,
-	}).Logger
+	}
 }
 
 // SetLevel sets the logging level
@@ -27,6 +106,75 @@ func SetLevel(level logrus.Level) {
 	logger.SetLevel(level)
 }
 
+// ChangeLevel sets the logging level at runtime and logs the change
+func ChangeLevel(level logrus.Level) logrus.Level {
+	previous := logger.GetLevel()
+	logger.SetLevel(level)
+	logger.WithFields(logrus.Fields{
+		"new_level":      level.String(),
+		"previous_level": previous.String(),
+	}).Warn("Log level changed")
+	return previous
+}
+
+// GetLevel returns the logging level
+func GetLevel() logrus.Level {
+	return logger.GetLevel()
+}
+
+// WithContext returns a logger carrying the request context, so registered
+// context fields (such as the canary) are added to its entries when logged.
+// Request handlers should prefer FromContext.
+func WithContext(ctx context.Context) *logrus.Entry {
+	return logger.WithContext(ctx)
+}
+
+type fieldsKey struct{}
+
+// NewContext returns a context whose FromContext entries carry the given
+// fields in addition to any already seeded
+func NewContext(ctx context.Context, fields logrus.Fields) context.Context {
+	seeded, _ := ctx.Value(fieldsKey{}).(logrus.Fields)
+	merged := make(logrus.Fields, len(seeded)+len(fields))
+	for key, value := range seeded {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// FromContext returns a request-scoped logger populated with the current
+// trace and span IDs, the fields seeded by the logging interceptor (such as
+// the tenant) and the registered context fields (such as the canary).
+// Requests matched by a debug rule log at debug level.
+func FromContext(ctx context.Context) *logrus.Entry {
+	fields := logrus.Fields{}
+	if seeded, ok := ctx.Value(fieldsKey{}).(logrus.Fields); ok {
+		for key, value := range seeded {
+			fields[key] = value
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields[TraceIDField] = sc.TraceID().String()
+		fields[SpanIDField] = sc.SpanID().String()
+	}
+
+	contextFieldsMu.RLock()
+	for _, fn := range contextFieldsFuncs {
+		for key, value := range fn(ctx) {
+			fields[key] = value
+		}
+	}
+	contextFieldsMu.RUnlock()
+
+	if isDebug(ctx) && !logger.IsLevelEnabled(logrus.DebugLevel) {
+		return debugLogger.WithContext(ctx).WithFields(fields)
+	}
+	return logger.WithContext(ctx).WithFields(fields)
+}
+
 // WithField returns a logger with the specified field
 func WithField(key string, value interface{}) *logrus.Entry {
 	return logger.WithField(key, value)
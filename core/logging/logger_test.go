@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// captureLogs sends both loggers' output to a buffer until the test ends and
+// returns a function decoding the entries written so far
+func captureLogs(t *testing.T) func() []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	level := logger.GetLevel()
+	logger.SetOutput(&buf)
+	debugLogger.SetOutput(&buf)
+	t.Cleanup(func() {
+		logger.SetOutput(os.Stdout)
+		debugLogger.SetOutput(os.Stdout)
+		logger.SetLevel(level)
+	})
+
+	return func() []map[string]any {
+		var entries []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatalf("log line is not JSON: %q", line)
+			}
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+}
+
+type requestIDKey struct{}
+
+func TestFromContext(t *testing.T) {
+	logs := captureLogs(t)
+	SetStandardFields("store-service", "v1.2.3")
+	t.Cleanup(func() {
+		standardFieldsMu.Lock()
+		standardFields = nil
+		standardFieldsMu.Unlock()
+	})
+	AddContextFields(func(ctx context.Context) logrus.Fields {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			return logrus.Fields{"request_id": id}
+		}
+		return nil
+	})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	ctx = context.WithValue(ctx, requestIDKey{}, "req-1")
+	ctx = NewContext(ctx, logrus.Fields{"operation": "get_item"})
+
+	FromContext(ctx).WithField("item_id", "widget").Info("Item fetched")
+
+	entries := logs()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+	for key, want := range map[string]any{
+		"service":    "store-service",
+		"version":    "v1.2.3",
+		TraceIDField: traceID.String(),
+		SpanIDField:  spanID.String(),
+		"request_id": "req-1",
+		"operation":  "get_item",
+		"item_id":    "widget",
+		"msg":        "Item fetched",
+	} {
+		if got := entries[0][key]; got != want {
+			t.Errorf("entry %s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorSeedsTenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        any
+		wantTenant any
+	}{
+		{name: "request naming a tenant", req: &pb.GetItemRequest{TenantId: 42, Id: "widget"}, wantTenant: float64(42)},
+		{name: "request without a tenant", req: &pb.GetLoggingRequest{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t)
+			handler := func(ctx context.Context, req any) (any, error) {
+				FromContext(ctx).Info("Handled")
+				return nil, nil
+			}
+
+			if _, err := UnaryServerInterceptor()(context.Background(), tt.req, &grpc.UnaryServerInfo{FullMethod: "/store.v1.StoreService/GetItem"}, handler); err != nil {
+				t.Fatalf("interceptor error = %v", err)
+			}
+
+			entries := logs()
+			if len(entries) != 1 {
+				t.Fatalf("logged %d entries, want 1", len(entries))
+			}
+			if got := entries[0][TenantIDField]; got != tt.wantTenant {
+				t.Errorf("entry tenant_id = %v, want %v", got, tt.wantTenant)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RedactedValue replaces redacted field values and matched substrings
+const RedactedValue = "[REDACTED]"
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+\d{7,15}\b|\(?\b\d{3}\)?[\s.-]\d{3}[\s.-]\d{4}\b`)
+)
+
+var redaction = struct {
+	sync.RWMutex
+	fields   map[string]bool
+	patterns bool
+}{}
+
+// SetRedaction masks the values of the given field keys (case-insensitive)
+// in every entry and, if patterns is set, email addresses and phone numbers
+// in the message and in string and error field values
+func SetRedaction(fields []string, patterns bool) {
+	redaction.Lock()
+	defer redaction.Unlock()
+	redaction.fields = make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			redaction.fields[field] = true
+		}
+	}
+	redaction.patterns = patterns
+}
+
+// redactHook masks sensitive values. It runs after the other hooks so fields
+// they add are redacted too.
+type redactHook struct{}
+
+func (h *redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *redactHook) Fire(entry *logrus.Entry) error {
+	redaction.RLock()
+	defer redaction.RUnlock()
+
+	for key, value := range entry.Data {
+		if redaction.fields[strings.ToLower(key)] {
+			entry.Data[key] = RedactedValue
+			continue
+		}
+		if !redaction.patterns {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			entry.Data[key] = redactPatterns(v)
+		case error:
+			if s := v.Error(); redactPatterns(s) != s {
+				entry.Data[key] = redactPatterns(s)
+			}
+		}
+	}
+	if redaction.patterns {
+		entry.Message = redactPatterns(entry.Message)
+	}
+	return nil
+}
+
+func redactPatterns(s string) string {
+	s = emailPattern.ReplaceAllString(s, RedactedValue)
+	return phonePattern.ReplaceAllString(s, RedactedValue)
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRedactHook(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      []string
+		patterns    bool
+		data        logrus.Fields
+		message     string
+		wantData    logrus.Fields
+		wantMessage string
+	}{
+		{
+			name:     "listed fields whatever their case",
+			fields:   []string{"Name", " created_by "},
+			data:     logrus.Fields{"name": "Widget", "CREATED_BY": "alice", "item_id": "widget"},
+			wantData: logrus.Fields{"name": RedactedValue, "CREATED_BY": RedactedValue, "item_id": "widget"},
+		},
+		{
+			name:        "patterns off leaves contact details",
+			data:        logrus.Fields{"actor": "alice@example.com"},
+			message:     "Call +14155550123",
+			wantData:    logrus.Fields{"actor": "alice@example.com"},
+			wantMessage: "Call +14155550123",
+		},
+		{
+			name:     "emails and phone numbers in values and the message",
+			patterns: true,
+			data: logrus.Fields{
+				"actor":   "alice@example.com",
+				"note":    "call (415) 555-0123 or +14155550123",
+				"error":   errors.New("no user bob@example.org"),
+				"count":   3,
+				"item_id": "widget",
+			},
+			message: "Updated by alice@example.com",
+			wantData: logrus.Fields{
+				"actor":   RedactedValue,
+				"note":    "call " + RedactedValue + " or " + RedactedValue,
+				"error":   "no user " + RedactedValue,
+				"count":   3,
+				"item_id": "widget",
+			},
+			wantMessage: "Updated by " + RedactedValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRedaction(tt.fields, tt.patterns)
+			t.Cleanup(func() { SetRedaction(nil, false) })
+			entry := &logrus.Entry{Data: tt.data, Message: tt.message}
+
+			if err := (&redactHook{}).Fire(entry); err != nil {
+				t.Fatalf("Fire() error = %v", err)
+			}
+
+			for key, want := range tt.wantData {
+				if got := entry.Data[key]; got != want {
+					t.Errorf("%s = %v, want %v", key, got, want)
+				}
+			}
+			if entry.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", entry.Message, tt.wantMessage)
+			}
+		})
+	}
+}
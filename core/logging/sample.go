@@ -0,0 +1,122 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// samplingSummaryMessage is logged for each message key that had entries
+// dropped; it is never sampled itself
+const samplingSummaryMessage = "Log entries dropped by sampling"
+
+// sampleKey identifies repeated log lines by level and message
+type sampleKey struct {
+	level   logrus.Level
+	message string
+}
+
+type sampleCount struct {
+	second  int64 // Unix second of the current window
+	logged  int   // Entries written in the current window
+	dropped int   // Entries dropped since the last summary
+}
+
+// samplingFormatter caps how many Info, Debug and Trace entries with the same
+// message are written per second. Dropped entries are formatted to nothing,
+// since logrus hooks cannot discard an entry. Warnings and errors are never
+// sampled.
+type samplingFormatter struct {
+	next      logrus.Formatter
+	perSecond int
+
+	mu     sync.Mutex
+	counts map[sampleKey]*sampleCount
+}
+
+func (f *samplingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if entry.Level <= logrus.WarnLevel || entry.Message == samplingSummaryMessage {
+		return f.next.Format(entry)
+	}
+
+	key := sampleKey{level: entry.Level, message: entry.Message}
+	second := entry.Time.Unix()
+
+	f.mu.Lock()
+	count, ok := f.counts[key]
+	if !ok {
+		count = &sampleCount{second: second}
+		f.counts[key] = count
+	}
+	if count.second != second {
+		count.second = second
+		count.logged = 0
+	}
+	drop := count.logged >= f.perSecond
+	if drop {
+		count.dropped++
+	} else {
+		count.logged++
+	}
+	f.mu.Unlock()
+
+	if drop {
+		return nil, nil
+	}
+	return f.next.Format(entry)
+}
+
+// summarize returns and resets the dropped counts, and forgets keys that
+// were not logged during the last interval
+func (f *samplingFormatter) summarize(idleBefore int64) map[sampleKey]int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dropped := make(map[sampleKey]int)
+	for key, count := range f.counts {
+		if count.dropped > 0 {
+			dropped[key] = count.dropped
+			count.dropped = 0
+		} else if count.second < idleBefore {
+			delete(f.counts, key)
+		}
+	}
+	return dropped
+}
+
+var samplingOnce sync.Once
+
+// SetSampling limits repeated Info, Debug and Trace entries to perSecond per
+// message and logs how many were dropped every summaryInterval. Entries
+// from requests matched by a debug rule are not sampled. It may only be
+// called once; perSecond <= 0 leaves sampling off.
+func SetSampling(perSecond int, summaryInterval time.Duration) {
+	if perSecond <= 0 || summaryInterval <= 0 {
+		return
+	}
+	samplingOnce.Do(func() {
+		sampler := &samplingFormatter{
+			next:      logger.Formatter,
+			perSecond: perSecond,
+			counts:    make(map[sampleKey]*sampleCount),
+		}
+		logger.SetFormatter(sampler)
+
+		go func() {
+			ticker := time.NewTicker(summaryInterval)
+			defer ticker.Stop()
+			for now := range ticker.C {
+				idleBefore := now.Add(-summaryInterval).Unix()
+				for key, dropped := range sampler.summarize(idleBefore) {
+					logger.WithFields(logrus.Fields{
+						"sampled_message": key.message,
+						"sampled_level":   key.level.String(),
+						"dropped":         dropped,
+						"per_second":      perSecond,
+					}).Info(samplingSummaryMessage)
+				}
+			}
+		}()
+	})
+}
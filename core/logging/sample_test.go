@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// messageFormatter formats an entry as its message
+type messageFormatter struct{}
+
+func (messageFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	return []byte(entry.Message), nil
+}
+
+func TestSamplingFormatter(t *testing.T) {
+	sampler := &samplingFormatter{
+		next:      messageFormatter{},
+		perSecond: 2,
+		counts:    make(map[sampleKey]*sampleCount),
+	}
+	start := time.Unix(1700000000, 0)
+
+	// written reports how many of count entries logged at at were written
+	written := func(level logrus.Level, message string, at time.Time, count int) int {
+		n := 0
+		for range count {
+			out, err := sampler.Format(&logrus.Entry{Level: level, Message: message, Time: at})
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if len(out) > 0 {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := written(logrus.InfoLevel, "Item fetched", start, 5); n != 2 {
+		t.Errorf("info entries written = %d, want 2 per second", n)
+	}
+	if n := written(logrus.InfoLevel, "Item created", start, 2); n != 2 {
+		t.Errorf("entries with another message written = %d, want 2", n)
+	}
+	if n := written(logrus.WarnLevel, "Item fetched", start, 5); n != 5 {
+		t.Errorf("warnings written = %d, want every one", n)
+	}
+	if n := written(logrus.InfoLevel, samplingSummaryMessage, start, 5); n != 5 {
+		t.Errorf("summaries written = %d, want every one", n)
+	}
+	if n := written(logrus.InfoLevel, "Item fetched", start.Add(time.Second), 3); n != 2 {
+		t.Errorf("info entries written in the next second = %d, want 2", n)
+	}
+
+	dropped := sampler.summarize(start.Add(time.Second).Unix())
+	if got := dropped[sampleKey{level: logrus.InfoLevel, message: "Item fetched"}]; got != 4 {
+		t.Errorf("dropped Item fetched = %d, want 4", got)
+	}
+	if len(dropped) != 1 {
+		t.Errorf("summarize() = %v, want only the message with drops", dropped)
+	}
+	// Keys idle since before the window are forgotten once summarised
+	if _, ok := sampler.counts[sampleKey{level: logrus.InfoLevel, message: "Item created"}]; ok {
+		t.Error("idle message still counted after summarize()")
+	}
+}
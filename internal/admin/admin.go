@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/tracing"
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// AdminServiceServer implements the AdminService gRPC interface
+type AdminServiceServer struct {
+	pb.UnimplementedAdminServiceServer
+}
+
+// NewAdminServiceServer creates a new admin server instance
+func NewAdminServiceServer() *AdminServiceServer {
+	return &AdminServiceServer{}
+}
+
+// GetLogging reports the log level and active debug logging rules
+func (s *AdminServiceServer) GetLogging(ctx context.Context, req *pb.GetLoggingRequest) (*pb.GetLoggingResponse, error) {
+	_, span := tracing.StartSpan(ctx, "admin.get_logging")
+	defer span.End()
+
+	return loggingResponse(), nil
+}
+
+// SetLogLevel changes the log level until the next restart
+func (s *AdminServiceServer) SetLogLevel(ctx context.Context, req *pb.SetLogLevelRequest) (*pb.SetLogLevelResponse, error) {
+	_, span := tracing.StartSpan(ctx, "admin.set_log_level")
+	defer span.End()
+
+	level, err := logrus.ParseLevel(req.Level)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid level %q", req.Level)
+	}
+	previous := logging.ChangeLevel(level)
+
+	return &pb.SetLogLevelResponse{
+		Level:         level.String(),
+		PreviousLevel: previous.String(),
+	}, nil
+}
+
+// EnableDebugLogging logs at debug level for matching requests for a limited time
+func (s *AdminServiceServer) EnableDebugLogging(ctx context.Context, req *pb.EnableDebugLoggingRequest) (*pb.EnableDebugLoggingResponse, error) {
+	_, span := tracing.StartSpan(ctx, "admin.enable_debug_logging")
+	defer span.End()
+
+	rule, err := logging.EnableDebug(logging.DebugRule{
+		TenantID:    req.TargetTenantId,
+		HeaderName:  req.HeaderName,
+		HeaderValue: req.HeaderValue,
+	}, req.Duration.AsDuration())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.EnableDebugLoggingResponse{
+		Rule: ruleToProto(rule),
+	}, nil
+}
+
+// ClearDebugLogging removes every debug logging rule
+func (s *AdminServiceServer) ClearDebugLogging(ctx context.Context, req *pb.ClearDebugLoggingRequest) (*pb.ClearDebugLoggingResponse, error) {
+	_, span := tracing.StartSpan(ctx, "admin.clear_debug_logging")
+	defer span.End()
+
+	removed := logging.ClearDebugRules()
+
+	return &pb.ClearDebugLoggingResponse{
+		Removed: int32(removed),
+	}, nil
+}
+
+func loggingResponse() *pb.GetLoggingResponse {
+	current := logging.Status()
+	resp := &pb.GetLoggingResponse{
+		Level: current.Level,
+	}
+	for _, rule := range current.DebugRules {
+		resp.DebugRules = append(resp.DebugRules, ruleToProto(rule))
+	}
+	return resp
+}
+
+func ruleToProto(rule logging.DebugRule) *pb.DebugLoggingRule {
+	return &pb.DebugLoggingRule{
+		TargetTenantId: rule.TenantID,
+		HeaderName:     rule.HeaderName,
+		HeaderValue:    rule.HeaderValue,
+		ExpiresAt:      timestamppb.New(rule.ExpiresAt),
+	}
+}
@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/rinsecrm/store-service/internal/auth"
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+	"github.com/rinsecrm/store-service/internal/data"
+	"github.com/rinsecrm/store-service/internal/gateway"
+)
+
+// forwardedForHeader is the metadata key the REST gateway records the HTTP
+// client's address in
+const forwardedForHeader = "x-forwarded-for"
+
+// UnaryServerInterceptor attaches the caller's identity, canary flag, trace ID
+// and client address to the context so the store can record them in audit
+// events. It must run after the canary and authentication interceptors.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(data.WithAuditInfo(ctx, infoFromContext(ctx)), req)
+	}
+}
+
+// StreamServerInterceptor attaches audit caller details to streaming calls
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &auditServerStream{
+			ServerStream: ss,
+			ctx:          data.WithAuditInfo(ss.Context(), infoFromContext(ss.Context())),
+		})
+	}
+}
+
+type auditServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *auditServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func infoFromContext(ctx context.Context) data.AuditInfo {
+	var info data.AuditInfo
+
+	if claims, ok := auth.FromContext(ctx); ok {
+		info.Actor = claims.Subject
+	}
+	if canary, ok := canaryctx.FromContext(ctx); ok {
+		info.Canary = canary
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		info.TraceID = spanContext.TraceID().String()
+	}
+	info.ClientAddress = clientAddress(ctx)
+
+	return info
+}
+
+// clientAddress returns the address of the client that made the call. REST
+// calls reach the server over the gateway's in-process listener; the gateway
+// appends the address its HTTP client connected from to x-forwarded-for, so
+// the last entry there is used instead. Earlier entries come from the client,
+// and the header is ignored on calls from anywhere but the gateway.
+func clientAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	address := p.Addr.String()
+	if _, fromGateway := p.Addr.(gateway.Addr); !fromGateway {
+		return address
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	forwarded := md.Get(forwardedForHeader)
+	if len(forwarded) == 0 {
+		return address
+	}
+	hops := strings.Split(forwarded[len(forwarded)-1], ",")
+	if last := strings.TrimSpace(hops[len(hops)-1]); last != "" {
+		return last
+	}
+	return address
+}
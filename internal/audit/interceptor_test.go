@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/rinsecrm/store-service/internal/gateway"
+)
+
+func TestClientAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		peer      net.Addr
+		forwarded []string
+		want      string
+	}{
+		{
+			name: "network client",
+			peer: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 4321},
+			want: "10.0.0.5:4321",
+		},
+		{
+			name:      "forwarded header from a network client is ignored",
+			peer:      &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 4321},
+			forwarded: []string{"203.0.113.9"},
+			want:      "10.0.0.5:4321",
+		},
+		{
+			name:      "forwarded header over loopback is ignored",
+			peer:      &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 4321},
+			forwarded: []string{"203.0.113.9"},
+			want:      "127.0.0.1:4321",
+		},
+		{
+			name:      "gateway call uses the address the gateway appended",
+			peer:      gateway.Addr{},
+			forwarded: []string{"198.51.100.1, 203.0.113.9"},
+			want:      "203.0.113.9",
+		},
+		{
+			name: "gateway call without a forwarded address",
+			peer: gateway.Addr{},
+			want: "gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tt.peer})
+			if tt.forwarded != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{forwardedForHeader: tt.forwarded})
+			}
+			if got := clientAddress(ctx); got != tt.want {
+				t.Errorf("clientAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := clientAddress(context.Background()); got != "" {
+		t.Errorf("clientAddress() without a peer = %q, want empty", got)
+	}
+}
@@ -0,0 +1,391 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+const (
+	AuthorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+type contextKey string
+
+const claimsKey contextKey = "auth_claims"
+
+// Request fields the interceptor checks or fills from the verified token
+const (
+	tenantIDField       protoreflect.Name = "tenant_id"
+	targetTenantIDField protoreflect.Name = "target_tenant_id"
+	createdByField      protoreflect.Name = "created_by"
+	updatedByField      protoreflect.Name = "updated_by"
+	deletedByField      protoreflect.Name = "deleted_by"
+)
+
+// Config holds authentication configuration
+type Config struct {
+	JWKSFile       string   // Path to a JWKS document with signing keys
+	PublicKeyFiles []string // Paths to PEM public keys or certificates
+	Issuer         string   // Expected "iss" claim (optional)
+	Audience       string   // Expected "aud" claim (optional)
+	TenantClaim    string   // Claim holding the caller's tenant ID
+	RolesClaim     string   // Claim holding the caller's roles
+	ExemptMethods  []string // Full method names or prefixes that skip authentication
+
+	// OperatorRole marks tokens of the service's operators, which are not
+	// bound to a tenant. OperatorMethods (full method names or prefixes)
+	// may only be called with such a token.
+	OperatorRole    string
+	OperatorMethods []string
+}
+
+// Claims holds the verified identity of the caller
+type Claims struct {
+	Subject  string
+	TenantID int64 // 0 for operators without a tenant claim
+	Roles    []string
+	Operator bool // Holds the operator role and may act on any tenant
+}
+
+// FromContext extracts the verified caller claims from context
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(Claims)
+	return claims, ok
+}
+
+// WithClaims adds verified caller claims to context
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// Authenticator verifies bearer JWTs on incoming gRPC calls
+type Authenticator struct {
+	keys            *keySet
+	parser          *jwt.Parser
+	tenantClaim     string
+	rolesClaim      string
+	exemptMethods   []string
+	operatorRole    string
+	operatorMethods []string
+}
+
+// NewAuthenticator creates an authenticator from the configured key sources
+func NewAuthenticator(config Config) (*Authenticator, error) {
+	keys, err := loadKeySet(config.JWKSFile, config.PublicKeyFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load verification keys: %w", err)
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithJSONNumber(),
+	}
+	if config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		opts = append(opts, jwt.WithAudience(config.Audience))
+	}
+
+	tenantClaim := config.TenantClaim
+	if tenantClaim == "" {
+		tenantClaim = "tenant_id"
+	}
+	rolesClaim := config.RolesClaim
+	if rolesClaim == "" {
+		rolesClaim = "roles"
+	}
+	operatorRole := config.OperatorRole
+	if operatorRole == "" {
+		operatorRole = RoleOperator
+	}
+
+	return &Authenticator{
+		keys:            keys,
+		parser:          jwt.NewParser(opts...),
+		tenantClaim:     tenantClaim,
+		rolesClaim:      rolesClaim,
+		exemptMethods:   config.ExemptMethods,
+		operatorRole:    operatorRole,
+		operatorMethods: config.OperatorMethods,
+	}, nil
+}
+
+// UnaryServerInterceptor authenticates unary calls and enforces the tenant claim
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if matchesMethod(a.exemptMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		claims, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.checkOperator(ctx, claims, info.FullMethod); err != nil {
+			return nil, err
+		}
+		if err := applyClaims(ctx, claims, req, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(WithClaims(ctx, claims), req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming calls and enforces the tenant
+// claim on every received message
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if matchesMethod(a.exemptMethods, info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		claims, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if err := a.checkOperator(ss.Context(), claims, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{
+			ServerStream: ss,
+			ctx:          WithClaims(ss.Context(), claims),
+			claims:       claims,
+			method:       info.FullMethod,
+		})
+	}
+}
+
+// authServerStream carries the verified claims and checks each inbound message
+type authServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	claims Claims
+	method string
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return applyClaims(s.ctx, s.claims, m, s.method)
+}
+
+// matchesMethod reports whether the full method name matches one of the
+// method names or prefixes
+func matchesMethod(methods []string, fullMethod string) bool {
+	for _, method := range methods {
+		if method != "" && strings.HasPrefix(fullMethod, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOperator rejects calls to operator-only methods made without the
+// operator role, whatever the authorization policy grants
+func (a *Authenticator) checkOperator(ctx context.Context, claims Claims, fullMethod string) error {
+	if claims.Operator || !matchesMethod(a.operatorMethods, fullMethod) {
+		return nil
+	}
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"method":    fullMethod,
+		"subject":   claims.Subject,
+		"tenant_id": claims.TenantID,
+	}).Warn("Rejected operator-only call")
+	return status.Errorf(codes.PermissionDenied, "%s requires the %s role", fullMethod, a.operatorRole)
+}
+
+// authenticate verifies the bearer token in the incoming metadata
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AuthorizationHeader)
+	if len(values) == 0 {
+		logging.FromContext(ctx).WithField("method", fullMethod).Warn("Rejected call without bearer token")
+		return Claims{}, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	raw := strings.TrimSpace(values[0])
+	if len(raw) <= len(bearerPrefix) || !strings.EqualFold(raw[:len(bearerPrefix)], bearerPrefix) {
+		logging.FromContext(ctx).WithField("method", fullMethod).Warn("Rejected call with malformed authorization header")
+		return Claims{}, status.Error(codes.Unauthenticated, "authorization header must be a bearer token")
+	}
+
+	mapClaims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(strings.TrimSpace(raw[len(bearerPrefix):]), mapClaims, a.keyFunc)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("method", fullMethod).Warn("Rejected call with invalid token")
+		return Claims{}, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	subject, err := mapClaims.GetSubject()
+	if err != nil || subject == "" {
+		return Claims{}, status.Error(codes.Unauthenticated, "token has no subject")
+	}
+
+	roles := parseRolesClaim(mapClaims[a.rolesClaim])
+	operator := slices.Contains(roles, a.operatorRole)
+
+	// Operator tokens need no tenant claim, but one that is present must be valid
+	var tenantID int64
+	if value, ok := mapClaims[a.tenantClaim]; ok || !operator {
+		tenantID, err = parseTenantClaim(value)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"method":  fullMethod,
+				"subject": subject,
+			}).Warn("Rejected call with invalid tenant claim")
+			return Claims{}, status.Errorf(codes.Unauthenticated, "token has no valid %s claim", a.tenantClaim)
+		}
+	}
+
+	return Claims{
+		Subject:  subject,
+		TenantID: tenantID,
+		Roles:    roles,
+		Operator: operator,
+	}, nil
+}
+
+// keyFunc selects the verification key by "kid", falling back to every
+// configured key when the token does not name one
+func (a *Authenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		if key, ok := a.keys.byKid[kid]; ok {
+			return key, nil
+		}
+		if len(a.keys.byKid) == len(a.keys.all) {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+	}
+
+	set := jwt.VerificationKeySet{}
+	for _, key := range a.keys.all {
+		set.Keys = append(set.Keys, key)
+	}
+	return set, nil
+}
+
+func parseTenantClaim(value interface{}) (int64, error) {
+	var tenantID int64
+	var err error
+
+	switch v := value.(type) {
+	case json.Number:
+		tenantID, err = v.Int64()
+	case string:
+		tenantID, err = strconv.ParseInt(v, 10, 64)
+	case nil:
+		return 0, errors.New("tenant claim missing")
+	default:
+		return 0, fmt.Errorf("unsupported tenant claim type %T", value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if tenantID <= 0 {
+		return 0, errors.New("tenant claim must be positive")
+	}
+	return tenantID, nil
+}
+
+// parseRolesClaim accepts either a JSON array of strings or a space-delimited
+// string, as used by the OAuth2 "scope" claim
+func parseRolesClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		roles := make([]string, 0, len(v))
+		for _, role := range v {
+			if s, ok := role.(string); ok && s != "" {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	case string:
+		return strings.Fields(v)
+	default:
+		return nil
+	}
+}
+
+// applyClaims rejects requests addressed to another tenant, unless the
+// caller is an operator, and overwrites the client-supplied actor fields
+// with the token subject
+func applyClaims(ctx context.Context, claims Claims, req interface{}, fullMethod string) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	// A target_tenant_id of 0 means every tenant, so it is rejected as well
+	for _, name := range []protoreflect.Name{tenantIDField, targetTenantIDField} {
+		fd := fields.ByName(name)
+		if claims.Operator || fd == nil || fd.Kind() != protoreflect.Int64Kind {
+			continue
+		}
+		if requested := m.Get(fd).Int(); requested != claims.TenantID {
+			logging.FromContext(ctx).WithFields(logrus.Fields{
+				"method":            fullMethod,
+				"subject":           claims.Subject,
+				"field":             name,
+				"token_tenant_id":   claims.TenantID,
+				"request_tenant_id": requested,
+			}).Warn("Rejected cross-tenant call")
+			return status.Errorf(codes.PermissionDenied, "%s does not match token", name)
+		}
+	}
+
+	for _, name := range []protoreflect.Name{createdByField, updatedByField, deletedByField} {
+		if fd := fields.ByName(name); fd != nil && fd.Kind() == protoreflect.StringKind {
+			m.Set(fd, protoreflect.ValueOfString(claims.Subject))
+		}
+	}
+	return nil
+}
+
+// RequireOperator serves next only to requests with a valid bearer token that
+// holds the operator role, for HTTP endpoints that change the whole process
+func (a *Authenticator) RequireOperator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs(AuthorizationHeader, r.Header.Get("Authorization")))
+		claims, err := a.authenticate(ctx, r.URL.Path)
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusUnauthorized)
+			return
+		}
+		if !claims.Operator {
+			logging.WithFields(logrus.Fields{
+				"path":    r.URL.Path,
+				"subject": claims.Subject,
+			}).Warn("Rejected admin request without operator role")
+			http.Error(w, "requires the "+a.operatorRole+" role", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+	})
+}
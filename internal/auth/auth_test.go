@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+const getItemMethod = "/store.v1.StoreService/GetItem"
+
+// newTestAuthenticator returns an authenticator trusting a new key, and the
+// private key to sign its tokens with
+func newTestAuthenticator(t *testing.T, config Config) (*Authenticator, *ecdsa.PrivateKey) {
+	t.Helper()
+	key := newKey(t)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write public key: %v", err)
+	}
+
+	config.PublicKeyFiles = []string{path}
+	authenticator, err := NewAuthenticator(config)
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	return authenticator, key
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+// signToken signs claims, adding a subject and expiry unless they are set
+func signToken(t *testing.T, key *ecdsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	if _, ok := claims["sub"]; !ok {
+		claims["sub"] = "alice"
+	}
+	if _, ok := claims["exp"]; !ok {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// withAuthorization returns an incoming call context carrying header
+func withAuthorization(header string) context.Context {
+	if header == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationHeader, header))
+}
+
+// callUnary runs req through the unary interceptor and returns the claims
+// the handler saw, if it was called
+func callUnary(a *Authenticator, ctx context.Context, method string, req any) (*Claims, error) {
+	var seen *Claims
+	handler := func(ctx context.Context, req any) (any, error) {
+		claims, _ := FromContext(ctx)
+		seen = &claims
+		return req, nil
+	}
+	_, err := a.UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return seen, err
+}
+
+func TestAuthenticate(t *testing.T) {
+	authenticator, key := newTestAuthenticator(t, Config{
+		ExemptMethods: []string{"/grpc.health.v1.Health/"},
+	})
+	otherKey := newKey(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		header     string
+		wantCode   codes.Code
+		wantTenant int64
+	}{
+		{
+			name:       "valid token",
+			header:     "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 1}),
+			wantTenant: 1,
+		},
+		{
+			name:       "tenant claim as a string",
+			header:     "bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": "1"}),
+			wantTenant: 1,
+		},
+		{
+			name:     "missing token",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "not a bearer token",
+			header:   "Basic YWxpY2U6c2VjcmV0",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "signed by an unknown key",
+			header:   "Bearer " + signToken(t, otherKey, jwt.MapClaims{"tenant_id": 1}),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "expired token",
+			header:   "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 1, "exp": time.Now().Add(-time.Minute).Unix()}),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "token without a subject",
+			header:   "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 1, "sub": ""}),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "token without a tenant",
+			header:   "Bearer " + signToken(t, key, jwt.MapClaims{}),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "tenant claim that is not positive",
+			header:   "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 0}),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "another tenant's request",
+			header:   "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 2}),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:   "exempt method needs no token",
+			method: "/grpc.health.v1.Health/Check",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = getItemMethod
+			}
+
+			claims, err := callUnary(authenticator, withAuthorization(tt.header), method, &pb.GetItemRequest{TenantId: 1, Id: "widget"})
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				if claims != nil {
+					t.Error("handler called for a rejected call")
+				}
+				return
+			}
+			if claims == nil {
+				t.Fatal("handler not called")
+			}
+			if claims.TenantID != tt.wantTenant {
+				t.Errorf("claims tenant = %d, want %d", claims.TenantID, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestApplyClaimsActors(t *testing.T) {
+	authenticator, key := newTestAuthenticator(t, Config{})
+	ctx := withAuthorization("Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 1, "sub": "alice"}))
+
+	tests := []struct {
+		name   string
+		method string
+		req    proto.Message
+		actor  func(proto.Message) string
+	}{
+		{
+			name:   "created_by",
+			method: "/store.v1.StoreService/CreateItem",
+			req:    &pb.CreateItemRequest{TenantId: 1, Name: "Widget", CreatedBy: "mallory"},
+			actor:  func(m proto.Message) string { return m.(*pb.CreateItemRequest).CreatedBy },
+		},
+		{
+			name:   "updated_by",
+			method: "/store.v1.StoreService/UpdateInventory",
+			req:    &pb.UpdateInventoryRequest{TenantId: 1, ItemId: "widget", UpdatedBy: "mallory"},
+			actor:  func(m proto.Message) string { return m.(*pb.UpdateInventoryRequest).UpdatedBy },
+		},
+		{
+			name:   "deleted_by",
+			method: "/store.v1.StoreService/DeleteItem",
+			req:    &pb.DeleteItemRequest{TenantId: 1, Id: "widget", DeletedBy: "mallory"},
+			actor:  func(m proto.Message) string { return m.(*pb.DeleteItemRequest).DeletedBy },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := callUnary(authenticator, ctx, tt.method, tt.req); err != nil {
+				t.Fatalf("interceptor error = %v", err)
+			}
+			if actor := tt.actor(tt.req); actor != "alice" {
+				t.Errorf("%s = %q, want the token subject alice", tt.name, actor)
+			}
+		})
+	}
+}
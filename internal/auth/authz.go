@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/metrics"
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// Built-in roles
+const (
+	RoleViewer         = "viewer"
+	RoleEditor         = "editor"
+	RoleInventoryClerk = "inventory-clerk"
+	RoleAdmin          = "admin"
+	// RoleOperator is held by the service's operators rather than by tenants
+	RoleOperator = "operator"
+)
+
+// Policy maps role names to the gRPC full method names they may call. A
+// permission ending in "*" grants every method with that prefix.
+type Policy struct {
+	Roles map[string][]string `json:"roles"`
+}
+
+// DefaultPolicy returns the built-in role policy for StoreService
+func DefaultPolicy() Policy {
+	read := []string{
+		pb.StoreService_GetItem_FullMethodName,
+		pb.StoreService_ListItems_FullMethodName,
+		pb.StoreService_ListLowStockItems_FullMethodName,
+		pb.StoreService_GetTenantConfig_FullMethodName,
+		pb.StoreService_GetTenantUsage_FullMethodName,
+	}
+
+	// Tenant admins may call every StoreService method except the
+	// operator-only ones that change a tenant's plan or usage
+	var admin []string
+	for _, method := range pb.StoreService_ServiceDesc.Methods {
+		fullMethod := "/" + pb.StoreService_ServiceDesc.ServiceName + "/" + method.MethodName
+		switch fullMethod {
+		case pb.StoreService_SetTenantQuotas_FullMethodName, pb.StoreService_RecountItems_FullMethodName:
+		default:
+			admin = append(admin, fullMethod)
+		}
+	}
+
+	return Policy{
+		Roles: map[string][]string{
+			RoleViewer: read,
+			RoleInventoryClerk: append(append([]string{}, read...),
+				pb.StoreService_UpdateInventory_FullMethodName,
+			),
+			RoleEditor: append(append([]string{}, read...),
+				pb.StoreService_CreateItem_FullMethodName,
+				pb.StoreService_UpdateItem_FullMethodName,
+				pb.StoreService_UpdateInventory_FullMethodName,
+			),
+			RoleAdmin: admin,
+			RoleOperator: {
+				"/" + pb.StoreService_ServiceDesc.ServiceName + "/*",
+				"/" + pb.AdminService_ServiceDesc.ServiceName + "/*",
+			},
+		},
+	}
+}
+
+// LoadPolicy reads a JSON role policy from disk
+func LoadPolicy(path string) (Policy, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to read authorization policy: %w", err)
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return Policy{}, fmt.Errorf("failed to parse authorization policy: %w", err)
+	}
+	if len(policy.Roles) == 0 {
+		return Policy{}, fmt.Errorf("authorization policy %s defines no roles", path)
+	}
+	return policy, nil
+}
+
+// Allows reports whether any of the given roles grants the full method name
+func (p Policy) Allows(roles []string, fullMethod string) bool {
+	for _, role := range roles {
+		for _, permission := range p.Roles[role] {
+			if permission == fullMethod {
+				return true
+			}
+			if prefix, ok := strings.CutSuffix(permission, "*"); ok && strings.HasPrefix(fullMethod, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authorizer enforces a role policy on authenticated gRPC calls. It must run
+// after the Authenticator interceptor.
+type Authorizer struct {
+	policy        Policy
+	exemptMethods []string
+}
+
+// NewAuthorizer creates an authorizer for the given policy
+func NewAuthorizer(policy Policy, exemptMethods []string) *Authorizer {
+	return &Authorizer{
+		policy:        policy,
+		exemptMethods: exemptMethods,
+	}
+}
+
+// UnaryServerInterceptor rejects unary calls the caller's roles do not permit
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streaming calls the caller's roles do not permit
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *Authorizer) authorize(ctx context.Context, fullMethod string) error {
+	if matchesMethod(a.exemptMethods, fullMethod) {
+		return nil
+	}
+
+	claims, ok := FromContext(ctx)
+	if ok && a.policy.Allows(claims.Roles, fullMethod) {
+		return nil
+	}
+
+	service, method := metrics.SplitFullMethod(fullMethod)
+	metrics.RecordAuthorizationDenied(service, method, metrics.CanaryLabel(ctx))
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"method":    fullMethod,
+		"subject":   claims.Subject,
+		"tenant_id": claims.TenantID,
+		"roles":     claims.Roles,
+	}).Warn("Rejected call missing permission")
+
+	return status.Errorf(codes.PermissionDenied, "missing permission %s", fullMethod)
+}
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := DefaultPolicy()
+
+	tests := []struct {
+		role   string
+		method string
+		want   bool
+	}{
+		{role: RoleViewer, method: pb.StoreService_GetItem_FullMethodName, want: true},
+		{role: RoleViewer, method: pb.StoreService_UpdateInventory_FullMethodName, want: false},
+		{role: RoleInventoryClerk, method: pb.StoreService_UpdateInventory_FullMethodName, want: true},
+		{role: RoleInventoryClerk, method: pb.StoreService_UpdateItem_FullMethodName, want: false},
+		{role: RoleEditor, method: pb.StoreService_CreateItem_FullMethodName, want: true},
+		{role: RoleEditor, method: pb.StoreService_DeleteItem_FullMethodName, want: false},
+		{role: RoleAdmin, method: pb.StoreService_DeleteItem_FullMethodName, want: true},
+		{role: RoleAdmin, method: pb.StoreService_SetTenantQuotas_FullMethodName, want: false},
+		{role: RoleAdmin, method: pb.StoreService_RecountItems_FullMethodName, want: false},
+		{role: RoleAdmin, method: pb.AdminService_SetLogLevel_FullMethodName, want: false},
+		{role: RoleOperator, method: pb.StoreService_SetTenantQuotas_FullMethodName, want: true},
+		{role: RoleOperator, method: pb.AdminService_SetLogLevel_FullMethodName, want: true},
+		{role: "unknown", method: pb.StoreService_GetItem_FullMethodName, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Allows([]string{tt.role}, tt.method); got != tt.want {
+			t.Errorf("Allows(%s, %s) = %t, want %t", tt.role, tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "roles with exact and prefix permissions", content: `{"roles": {"auditor": ["/store.v1.StoreService/ListAuditEvents", "/store.v1.StoreService/Get*"]}}`},
+		{name: "no roles", content: `{"roles": {}}`, wantErr: true},
+		{name: "not JSON", content: `roles: []`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write policy: %v", err)
+			}
+
+			policy, err := LoadPolicy(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPolicy() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for method, want := range map[string]bool{
+				pb.StoreService_ListAuditEvents_FullMethodName: true,
+				pb.StoreService_GetTenantUsage_FullMethodName:  true,
+				pb.StoreService_ListItems_FullMethodName:       false,
+			} {
+				if got := policy.Allows([]string{"auditor"}, method); got != want {
+					t.Errorf("Allows(auditor, %s) = %t, want %t", method, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorizer(t *testing.T) {
+	authorizer := NewAuthorizer(DefaultPolicy(), []string{"/grpc.health.v1.Health/"})
+
+	tests := []struct {
+		name     string
+		claims   *Claims
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "role grants the method", claims: &Claims{Subject: "alice", TenantID: 1, Roles: []string{RoleViewer}}, method: pb.StoreService_GetItem_FullMethodName},
+		{name: "any role may grant it", claims: &Claims{Subject: "alice", TenantID: 1, Roles: []string{"unknown", RoleEditor}}, method: pb.StoreService_CreateItem_FullMethodName},
+		{name: "role lacks the method", claims: &Claims{Subject: "alice", TenantID: 1, Roles: []string{RoleViewer}}, method: pb.StoreService_DeleteItem_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "unauthenticated call", method: pb.StoreService_GetItem_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "exempt method", method: "/grpc.health.v1.Health/Check"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = WithClaims(ctx, *tt.claims)
+			}
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				return req, nil
+			}
+
+			_, err := authorizer.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if called != (err == nil) {
+				t.Errorf("handler called = %t for error %v", called, err)
+			}
+		})
+	}
+}
+
+func TestOperatorMethods(t *testing.T) {
+	authenticator, key := newTestAuthenticator(t, Config{
+		OperatorMethods: []string{"/store.v1.AdminService/", pb.StoreService_SetTenantQuotas_FullMethodName},
+	})
+	tenantToken := "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 1, "roles": []string{RoleAdmin}})
+	operatorToken := "Bearer " + signToken(t, key, jwt.MapClaims{"roles": "operator"})
+
+	tests := []struct {
+		name         string
+		header       string
+		method       string
+		req          any
+		wantCode     codes.Code
+		wantOperator bool
+	}{
+		{
+			name:     "tenant admin calls an operator-only method",
+			header:   tenantToken,
+			method:   pb.StoreService_SetTenantQuotas_FullMethodName,
+			req:      &pb.SetTenantQuotasRequest{TenantId: 1},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "tenant admin calls the admin service",
+			header:   tenantToken,
+			method:   pb.AdminService_SetLogLevel_FullMethodName,
+			req:      &pb.SetLogLevelRequest{Level: "debug"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:         "operator needs no tenant claim",
+			header:       operatorToken,
+			method:       pb.StoreService_SetTenantQuotas_FullMethodName,
+			req:          &pb.SetTenantQuotasRequest{TenantId: 1},
+			wantOperator: true,
+		},
+		{
+			name:         "operator may address any tenant",
+			header:       operatorToken,
+			method:       pb.StoreService_GetItem_FullMethodName,
+			req:          &pb.GetItemRequest{TenantId: 7, Id: "widget"},
+			wantOperator: true,
+		},
+		{
+			name:         "operator with a tenant claim may address every tenant",
+			header:       "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 1, "roles": "operator"}),
+			method:       pb.AdminService_EnableDebugLogging_FullMethodName,
+			req:          &pb.EnableDebugLoggingRequest{TargetTenantId: 0},
+			wantOperator: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := callUnary(authenticator, withAuthorization(tt.header), tt.method, tt.req)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if err == nil && claims.Operator != tt.wantOperator {
+				t.Errorf("claims operator = %t, want %t", claims.Operator, tt.wantOperator)
+			}
+		})
+	}
+}
+
+func TestRequireOperator(t *testing.T) {
+	authenticator, key := newTestAuthenticator(t, Config{})
+	handler := authenticator.RequireOperator(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "operator", header: "Bearer " + signToken(t, key, jwt.MapClaims{"roles": []string{RoleOperator}}), want: http.StatusNoContent},
+		{name: "tenant admin", header: "Bearer " + signToken(t, key, jwt.MapClaims{"tenant_id": 1, "roles": []string{RoleAdmin}}), want: http.StatusForbidden},
+		{name: "no token", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/logging", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
 const (
 	CanaryHeader = "X-Canary"
+
+	// LogField is the log field, span attribute and baggage key carrying the canary
+	LogField = "canary"
 )
 
 type contextKey string
@@ -28,6 +32,15 @@ func WithCanary(ctx context.Context, canary string) context.Context {
 	return context.WithValue(ctx, canaryKey, canary)
 }
 
+// LogFields returns the canary log field for a request context, for use with
+// logging.AddContextFields
+func LogFields(ctx context.Context) logrus.Fields {
+	if canary, ok := FromContext(ctx); ok {
+		return logrus.Fields{LogField: canary}
+	}
+	return nil
+}
+
 // IsValidCanary checks if the canary value is a valid PR number (digits only)
 func IsValidCanary(canary string) bool {
 	if canary == "" {
@@ -40,25 +53,61 @@ func IsValidCanary(canary string) bool {
 // UnaryServerInterceptor extracts X-Canary from incoming gRPC metadata
 func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			if values := md.Get(CanaryHeader); len(values) > 0 {
-				canary := strings.TrimSpace(values[0])
-				if IsValidCanary(canary) {
-					ctx = WithCanary(ctx, canary)
-				}
+		return handler(fromIncomingMetadata(ctx), req)
+	}
+}
+
+// StreamServerInterceptor extracts X-Canary from incoming gRPC metadata for streaming calls
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &canaryServerStream{
+			ServerStream: ss,
+			ctx:          fromIncomingMetadata(ss.Context()),
+		})
+	}
+}
+
+type canaryServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *canaryServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// fromIncomingMetadata adds a valid X-Canary value from incoming metadata to context
+func fromIncomingMetadata(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(CanaryHeader); len(values) > 0 {
+			canary := strings.TrimSpace(values[0])
+			if IsValidCanary(canary) {
+				return WithCanary(ctx, canary)
 			}
 		}
-		return handler(ctx, req)
 	}
+	return ctx
 }
 
 // UnaryClientInterceptor adds X-Canary to outgoing gRPC metadata
 func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		if canary, ok := FromContext(ctx); ok {
-			md := metadata.Pairs(CanaryHeader, canary)
-			ctx = metadata.NewOutgoingContext(ctx, md)
-		}
-		return invoker(ctx, method, req, reply, cc, opts...)
+		return invoker(toOutgoingMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor adds X-Canary to outgoing gRPC metadata for streaming calls
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(toOutgoingMetadata(ctx), desc, cc, method, opts...)
+	}
+}
+
+// toOutgoingMetadata appends the context's canary to outgoing metadata,
+// keeping any metadata already set by the caller
+func toOutgoingMetadata(ctx context.Context) context.Context {
+	if canary, ok := FromContext(ctx); ok {
+		return metadata.AppendToOutgoingContext(ctx, CanaryHeader, canary)
 	}
+	return ctx
 }
@@ -0,0 +1,272 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+// Audited store methods
+const (
+	AuditMethodCreateItem      = "CreateItem"
+	AuditMethodUpdateItem      = "UpdateItem"
+	AuditMethodDeleteItem      = "DeleteItem"
+	AuditMethodUpdateInventory = "UpdateInventory"
+)
+
+// auditTimeFormat sorts lexically in time order, so it can prefix sort keys
+const auditTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// AnonymousActor is recorded for mutations with neither an authenticated
+// caller nor a client-supplied user
+const AnonymousActor = "anonymous"
+
+type auditContextKey string
+
+const auditInfoKey auditContextKey = "audit_info"
+
+// AuditInfo describes the caller of a mutation. It is attached to the request
+// context by the audit interceptor and copied into every audit event.
+type AuditInfo struct {
+	Actor         string
+	Canary        string
+	TraceID       string
+	ClientAddress string
+}
+
+// WithAuditInfo adds caller details for audit events to context
+func WithAuditInfo(ctx context.Context, info AuditInfo) context.Context {
+	return context.WithValue(ctx, auditInfoKey, info)
+}
+
+// AuditInfoFromContext extracts caller details for audit events from context
+func AuditInfoFromContext(ctx context.Context) (AuditInfo, bool) {
+	info, ok := ctx.Value(auditInfoKey).(AuditInfo)
+	return info, ok
+}
+
+// FieldChange records one field's value before and after a mutation
+type FieldChange struct {
+	Field  string `dynamodbav:"Field"`
+	Before string `dynamodbav:"Before"`
+	After  string `dynamodbav:"After"`
+}
+
+// AuditEvent is an append-only record of a catalog mutation
+type AuditEvent struct {
+	PK            string        `dynamodbav:"PK"` // Partition key: AUDIT#TENANT#{tenant_id}
+	SK            string        `dynamodbav:"SK"` // Sort key: EVENT#{timestamp}#{event_id}
+	EventID       string        `dynamodbav:"EventID"`
+	TenantID      int64         `dynamodbav:"TenantID"`
+	ItemID        string        `dynamodbav:"ItemID"`
+	Method        string        `dynamodbav:"Method"`
+	Actor         string        `dynamodbav:"Actor"`
+	Changes       []FieldChange `dynamodbav:"Changes"`
+	Canary        string        `dynamodbav:"Canary,omitempty"`
+	TraceID       string        `dynamodbav:"TraceID,omitempty"`
+	ClientAddress string        `dynamodbav:"ClientAddress,omitempty"`
+	Timestamp     time.Time     `dynamodbav:"Timestamp"`
+}
+
+// AuditFilter selects audit events in ListAuditEvents. Zero values match everything.
+type AuditFilter struct {
+	ItemID    string
+	Actor     string
+	Method    string
+	StartTime time.Time // Inclusive
+	EndTime   time.Time // Exclusive
+}
+
+// auditPut builds the transaction entry that appends an audit event for a
+// mutation. actor is used when the context carries no authenticated caller.
+func (s *DynamoStore) auditPut(ctx context.Context, tenantID int64, itemID, method, actor string, before, after *Item, now time.Time) (*types.Put, error) {
+	info, _ := AuditInfoFromContext(ctx)
+	if info.Actor != "" {
+		actor = info.Actor
+	}
+	if actor == "" {
+		actor = AnonymousActor
+	}
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID := uuid.New().String()
+	event := AuditEvent{
+		PK:            scope.auditPK(tenantID),
+		SK:            fmt.Sprintf("EVENT#%s#%s", now.UTC().Format(auditTimeFormat), eventID),
+		EventID:       eventID,
+		TenantID:      tenantID,
+		ItemID:        itemID,
+		Method:        method,
+		Actor:         actor,
+		Changes:       diffItems(before, after),
+		Canary:        info.Canary,
+		TraceID:       info.TraceID,
+		ClientAddress: info.ClientAddress,
+		Timestamp:     now,
+	}
+
+	av, err := attributevalue.MarshalMap(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	return &types.Put{
+		TableName:           aws.String(scope.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	}, nil
+}
+
+// diffItems lists the user-visible fields that differ between two versions of
+// an item. A nil before or after stands for a missing item.
+func diffItems(before, after *Item) []FieldChange {
+	var b, a Item
+	if before != nil {
+		b = *before
+	}
+	if after != nil {
+		a = *after
+	}
+
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{"name", b.Name, a.Name},
+		{"description", b.Description, a.Description},
+		{"price", fmt.Sprintf("%.2f", b.Price), fmt.Sprintf("%.2f", a.Price)},
+		{"category", fmt.Sprintf("%d", b.Category), fmt.Sprintf("%d", a.Category)},
+		{"status", fmt.Sprintf("%d", b.Status), fmt.Sprintf("%d", a.Status)},
+		{"sku", b.SKU, a.SKU},
+		{"inventory_count", fmt.Sprintf("%d", b.InventoryCount), fmt.Sprintf("%d", a.InventoryCount)},
+		{"low_stock_threshold", fmt.Sprintf("%d", b.LowStockThreshold), fmt.Sprintf("%d", a.LowStockThreshold)},
+		{"tags", strings.Join(b.Tags, ","), strings.Join(a.Tags, ",")},
+	}
+
+	var changes []FieldChange
+	for _, f := range fields {
+		if before == nil {
+			f.before = ""
+		}
+		if after == nil {
+			f.after = ""
+		}
+		if f.before != f.after {
+			changes = append(changes, FieldChange{Field: f.name, Before: f.before, After: f.after})
+		}
+	}
+	return changes
+}
+
+// ListAuditEvents lists a tenant's audit events, newest first
+func (s *DynamoStore) ListAuditEvents(ctx context.Context, tenantID int64, filter AuditFilter, pageSize int32, pageToken string) ([]AuditEvent, string, error) {
+	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keyCondition := "PK = :pk AND begins_with(SK, :sk_prefix)"
+	values := map[string]types.AttributeValue{
+		":pk": &types.AttributeValueMemberS{Value: scope.auditPK(tenantID)},
+	}
+	switch {
+	case !filter.StartTime.IsZero() && !filter.EndTime.IsZero():
+		keyCondition = "PK = :pk AND SK BETWEEN :sk_from AND :sk_to"
+		values[":sk_from"] = &types.AttributeValueMemberS{Value: "EVENT#" + filter.StartTime.UTC().Format(auditTimeFormat)}
+		values[":sk_to"] = &types.AttributeValueMemberS{Value: "EVENT#" + filter.EndTime.UTC().Format(auditTimeFormat)}
+	case !filter.StartTime.IsZero():
+		keyCondition = "PK = :pk AND SK >= :sk_from"
+		values[":sk_from"] = &types.AttributeValueMemberS{Value: "EVENT#" + filter.StartTime.UTC().Format(auditTimeFormat)}
+	case !filter.EndTime.IsZero():
+		keyCondition = "PK = :pk AND SK < :sk_to"
+		values[":sk_to"] = &types.AttributeValueMemberS{Value: "EVENT#" + filter.EndTime.UTC().Format(auditTimeFormat)}
+	default:
+		values[":sk_prefix"] = &types.AttributeValueMemberS{Value: "EVENT#"}
+	}
+
+	var filters []string
+	names := map[string]string{}
+	if filter.ItemID != "" {
+		filters = append(filters, "#itemID = :itemID")
+		names["#itemID"] = "ItemID"
+		values[":itemID"] = &types.AttributeValueMemberS{Value: filter.ItemID}
+	}
+	if filter.Actor != "" {
+		filters = append(filters, "#actor = :actor")
+		names["#actor"] = "Actor"
+		values[":actor"] = &types.AttributeValueMemberS{Value: filter.Actor}
+	}
+	if filter.Method != "" {
+		filters = append(filters, "#method = :method")
+		names["#method"] = "Method"
+		values[":method"] = &types.AttributeValueMemberS{Value: filter.Method}
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(scope.table),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: values,
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(pageSize),
+	}
+	if len(filters) > 0 {
+		input.FilterExpression = aws.String(strings.Join(filters, " AND "))
+		input.ExpressionAttributeNames = names
+	}
+
+	if pageToken != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: scope.auditPK(tenantID)},
+			"SK": &types.AttributeValueMemberS{Value: pageToken},
+		}
+	}
+
+	result, err := s.client.Query(ctx, input)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+		}).Error("Failed to list audit events")
+		return nil, "", fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	var events []AuditEvent
+	for _, item := range result.Items {
+		var event AuditEvent
+		if err := attributevalue.UnmarshalMap(item, &event); err != nil {
+			logging.FromContext(ctx).WithError(err).Error("Failed to unmarshal audit event in list")
+			continue
+		}
+		events = append(events, event)
+	}
+
+	nextPageToken := ""
+	if result.LastEvaluatedKey != nil {
+		if sk, ok := result.LastEvaluatedKey["SK"]; ok {
+			if skValue, ok := sk.(*types.AttributeValueMemberS); ok {
+				nextPageToken = skValue.Value
+			}
+		}
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":    tenantID,
+		"events_count": len(events),
+		"duration":     time.Since(start),
+	}).Debug("Audit events listed successfully")
+
+	return events, nextPageToken, nil
+}
@@ -0,0 +1,40 @@
+package data
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDeleteItemAuditActor(t *testing.T) {
+	tests := []struct {
+		name      string
+		deletedBy string
+		caller    string // authenticated subject in the context
+		want      string
+	}{
+		{name: "caller named in the request", deletedBy: "alice", want: "alice"},
+		{name: "authenticated caller wins", deletedBy: "alice", caller: "svc-catalog", want: "svc-catalog"},
+		{name: "unknown caller", want: AnonymousActor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			fake.put(t, testItem(ItemStatusActive, 5))
+
+			ctx := WithAuditInfo(context.Background(), AuditInfo{Actor: tt.caller})
+			if err := store.DeleteItem(ctx, testTenantID, "widget", tt.deletedBy); err != nil {
+				t.Fatalf("DeleteItem() error = %v", err)
+			}
+
+			transactions := fake.calls("TransactWriteItems")
+			if len(transactions) != 1 {
+				t.Fatalf("TransactWriteItems calls = %d, want 1", len(transactions))
+			}
+			event := transactions[0]["TransactItems"].([]any)[2].(map[string]any)["Put"].(map[string]any)["Item"].(map[string]any)
+			if actor := event["Actor"].(map[string]any)["S"]; actor != tt.want {
+				t.Errorf("audit actor = %v, want %q", actor, tt.want)
+			}
+		})
+	}
+}
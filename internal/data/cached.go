@@ -0,0 +1,303 @@
+package data
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+)
+
+// CacheConfig sizes the item cache of a CachedStore
+type CacheConfig struct {
+	// Size is the most items kept; the least recently used is evicted first
+	Size int
+	// TTL bounds how stale a cached item can be, including after writes made
+	// by other instances, which this cache cannot see
+	TTL time.Duration
+	// CanaryIsolation must match the store's, so canary requests share
+	// entries with production exactly when they share its data
+	CanaryIsolation CanaryIsolation
+}
+
+// CacheStats are cumulative counters of a CachedStore's item cache
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Bypasses      uint64
+	Coalesced     uint64 // misses answered by another caller's load
+	Evictions     uint64
+	Invalidations uint64
+	Entries       int
+}
+
+// CachedStore wraps a StoreInterface with a read-through cache of GetItem.
+// Concurrent misses for an item share one load, and every write through the
+// decorator invalidates the item it touched. Other calls pass straight through.
+type CachedStore struct {
+	next            StoreInterface
+	canaryIsolation CanaryIsolation
+	items           *lruCache
+	loads           singleflight.Group
+
+	hits, misses, bypasses, coalesced, invalidations atomic.Uint64
+}
+
+// NewCachedStore creates a caching decorator around next
+func NewCachedStore(next StoreInterface, config CacheConfig) *CachedStore {
+	return &CachedStore{
+		next:            next,
+		canaryIsolation: config.CanaryIsolation,
+		items:           newLRUCache(config.Size, config.TTL),
+	}
+}
+
+type consistentReadContextKey string
+
+const consistentReadKey consistentReadContextKey = "consistent_read"
+
+// WithConsistentRead marks reads made with ctx as needing the latest data:
+// they skip the cache and use strongly consistent DynamoDB reads
+func WithConsistentRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, consistentReadKey, true)
+}
+
+func consistentRead(ctx context.Context) bool {
+	consistent, _ := ctx.Value(consistentReadKey).(bool)
+	return consistent
+}
+
+// Stats returns the cache's counters so far
+func (s *CachedStore) Stats() CacheStats {
+	return CacheStats{
+		Hits:          s.hits.Load(),
+		Misses:        s.misses.Load(),
+		Bypasses:      s.bypasses.Load(),
+		Coalesced:     s.coalesced.Load(),
+		Evictions:     s.items.evictions.Load(),
+		Invalidations: s.invalidations.Load(),
+		Entries:       s.items.len(),
+	}
+}
+
+// key identifies an item within the data its request can see
+func (s *CachedStore) key(ctx context.Context, tenantID int64, itemID string) string {
+	if canary, ok := canaryctx.FromContext(ctx); ok && s.canaryIsolation != CanaryIsolationShared {
+		return fmt.Sprintf("CANARY#%s#%d/%s", canary, tenantID, itemID)
+	}
+	return fmt.Sprintf("%d/%s", tenantID, itemID)
+}
+
+// invalidate drops an item after a write, whether or not it succeeded, and
+// keeps loads already in flight from caching what they read before the write
+func (s *CachedStore) invalidate(ctx context.Context, tenantID int64, itemID string) {
+	key := s.key(ctx, tenantID, itemID)
+	s.items.remove(key)
+	s.loads.Forget(key)
+	s.invalidations.Add(1)
+}
+
+func (s *CachedStore) GetItem(ctx context.Context, tenantID int64, itemID string) (Item, error) {
+	if consistentRead(ctx) {
+		s.bypasses.Add(1)
+		return s.next.GetItem(ctx, tenantID, itemID)
+	}
+
+	key := s.key(ctx, tenantID, itemID)
+	if item, ok := s.items.get(key); ok {
+		s.hits.Add(1)
+		return cloneItem(item), nil
+	}
+	s.misses.Add(1)
+
+	// The load outlives a caller that gives up, since others may be waiting
+	// on it; each caller still returns as soon as its own ctx is done
+	loadCtx := context.WithoutCancel(ctx)
+	leader := false
+	results := s.loads.DoChan(key, func() (interface{}, error) {
+		leader = true
+		generation := s.items.generation()
+		item, err := s.next.GetItem(loadCtx, tenantID, itemID)
+		if err == nil {
+			s.items.addIfUnchanged(key, item, generation)
+		}
+		return item, err
+	})
+
+	select {
+	case result := <-results:
+		if result.Shared && !leader {
+			s.coalesced.Add(1)
+		}
+		if result.Err != nil {
+			return Item{}, result.Err
+		}
+		return cloneItem(result.Val.(Item)), nil
+	case <-ctx.Done():
+		return Item{}, ctx.Err()
+	}
+}
+
+func (s *CachedStore) CreateItem(ctx context.Context, tenantID int64, name, description string, price float64, category ItemCategory, sku string, inventoryCount, lowStockThreshold int32, tags []string, createdBy string) (Item, error) {
+	return s.next.CreateItem(ctx, tenantID, name, description, price, category, sku, inventoryCount, lowStockThreshold, tags, createdBy)
+}
+
+func (s *CachedStore) UpdateItem(ctx context.Context, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount, lowStockThreshold int32, tags []string, updatedBy string) (Item, error) {
+	defer s.invalidate(ctx, tenantID, itemID)
+	return s.next.UpdateItem(ctx, tenantID, itemID, name, description, price, category, status, sku, inventoryCount, lowStockThreshold, tags, updatedBy)
+}
+
+func (s *CachedStore) DeleteItem(ctx context.Context, tenantID int64, itemID, deletedBy string) error {
+	defer s.invalidate(ctx, tenantID, itemID)
+	return s.next.DeleteItem(ctx, tenantID, itemID, deletedBy)
+}
+
+func (s *CachedStore) UpdateInventory(ctx context.Context, tenantID int64, itemID string, quantityChange int32, reason, updatedBy string) (Item, int32, error) {
+	defer s.invalidate(ctx, tenantID, itemID)
+	return s.next.UpdateInventory(ctx, tenantID, itemID, quantityChange, reason, updatedBy)
+}
+
+func (s *CachedStore) ListItems(ctx context.Context, tenantID int64, category ItemCategory, status ItemStatus, searchQuery string, pageSize int32, pageToken string) ([]Item, string, int32, error) {
+	return s.next.ListItems(ctx, tenantID, category, status, searchQuery, pageSize, pageToken)
+}
+
+func (s *CachedStore) ListLowStockItems(ctx context.Context, tenantID int64, pageSize int32, pageToken string) ([]Item, string, error) {
+	return s.next.ListLowStockItems(ctx, tenantID, pageSize, pageToken)
+}
+
+func (s *CachedStore) GetTenantConfig(ctx context.Context, tenantID int64) (TenantConfig, error) {
+	return s.next.GetTenantConfig(ctx, tenantID)
+}
+
+func (s *CachedStore) UpdateTenantConfig(ctx context.Context, tenantID int64, defaultLowStockThreshold int32, updatedBy string) (TenantConfig, error) {
+	return s.next.UpdateTenantConfig(ctx, tenantID, defaultLowStockThreshold, updatedBy)
+}
+
+func (s *CachedStore) SetTenantQuotas(ctx context.Context, tenantID int64, quotas TenantQuotas, updatedBy string) (TenantConfig, error) {
+	return s.next.SetTenantQuotas(ctx, tenantID, quotas, updatedBy)
+}
+
+func (s *CachedStore) GetTenantUsage(ctx context.Context, tenantID int64) (TenantUsage, error) {
+	return s.next.GetTenantUsage(ctx, tenantID)
+}
+
+func (s *CachedStore) RecountItems(ctx context.Context, tenantID int64) (int64, int64, error) {
+	return s.next.RecountItems(ctx, tenantID)
+}
+
+func (s *CachedStore) ListAuditEvents(ctx context.Context, tenantID int64, filter AuditFilter, pageSize int32, pageToken string) ([]AuditEvent, string, error) {
+	return s.next.ListAuditEvents(ctx, tenantID, filter, pageSize, pageToken)
+}
+
+func (s *CachedStore) InventoryStats(ctx context.Context) ([]TenantInventory, error) {
+	return s.next.InventoryStats(ctx)
+}
+
+func (s *CachedStore) AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error) {
+	return s.next.AcquireLease(ctx, name, holder, duration)
+}
+
+// cloneItem copies the tags so callers cannot modify a cached item
+func cloneItem(item Item) Item {
+	item.Tags = slices.Clone(item.Tags)
+	return item
+}
+
+// lruCache is a size-bounded cache of items that expire after a TTL
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // most recently used first
+	entries map[string]*list.Element
+	// gen advances on every removal, so a load that started before one
+	// cannot cache what it read
+	gen uint64
+
+	evictions atomic.Uint64
+}
+
+type lruEntry struct {
+	key     string
+	item    Item
+	expires time.Time
+}
+
+func newLRUCache(size int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) get(key string) (Item, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return Item{}, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return Item{}, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.item, true
+}
+
+func (c *lruCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// addIfUnchanged caches item unless anything was removed since generation
+func (c *lruCache) addIfUnchanged(key string, item Item, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 || c.gen != generation {
+		return
+	}
+	entry := &lruEntry{key: key, item: cloneItem(item), expires: time.Now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+		c.evictions.Add(1)
+	}
+}
+
+func (c *lruCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+func (c *lruCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	item := func(id string) Item {
+		return Item{ItemID: id, Tags: []string{"tag"}}
+	}
+
+	tests := []struct {
+		name          string
+		size          int
+		ttl           time.Duration
+		run           func(c *lruCache)
+		wantCached    []string
+		wantMissing   []string
+		wantEvictions uint64
+	}{
+		{
+			name: "load without removal is cached",
+			size: 2,
+			ttl:  time.Minute,
+			run: func(c *lruCache) {
+				c.addIfUnchanged("a", item("a"), c.generation())
+			},
+			wantCached: []string{"a"},
+		},
+		{
+			name: "load started before a removal is not cached",
+			size: 2,
+			ttl:  time.Minute,
+			run: func(c *lruCache) {
+				generation := c.generation()
+				c.remove("a") // a write lands while the load is in flight
+				c.addIfUnchanged("a", item("a"), generation)
+			},
+			wantMissing: []string{"a"},
+		},
+		{
+			name: "removal of another key also discards the load",
+			size: 2,
+			ttl:  time.Minute,
+			run: func(c *lruCache) {
+				generation := c.generation()
+				c.remove("b")
+				c.addIfUnchanged("a", item("a"), generation)
+			},
+			wantMissing: []string{"a"},
+		},
+		{
+			name: "load started after a removal is cached",
+			size: 2,
+			ttl:  time.Minute,
+			run: func(c *lruCache) {
+				c.remove("a")
+				c.addIfUnchanged("a", item("a"), c.generation())
+			},
+			wantCached: []string{"a"},
+		},
+		{
+			name: "least recently used item is evicted",
+			size: 2,
+			ttl:  time.Minute,
+			run: func(c *lruCache) {
+				c.addIfUnchanged("a", item("a"), c.generation())
+				c.addIfUnchanged("b", item("b"), c.generation())
+				c.get("a")
+				c.addIfUnchanged("c", item("c"), c.generation())
+			},
+			wantCached:    []string{"a", "c"},
+			wantMissing:   []string{"b"},
+			wantEvictions: 1,
+		},
+		{
+			name: "expired item is not returned",
+			size: 2,
+			ttl:  -time.Second,
+			run: func(c *lruCache) {
+				c.addIfUnchanged("a", item("a"), c.generation())
+			},
+			wantMissing: []string{"a"},
+		},
+		{
+			name: "zero size caches nothing",
+			size: 0,
+			ttl:  time.Minute,
+			run: func(c *lruCache) {
+				c.addIfUnchanged("a", item("a"), c.generation())
+			},
+			wantMissing: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLRUCache(tt.size, tt.ttl)
+			tt.run(c)
+
+			for _, key := range tt.wantCached {
+				got, ok := c.get(key)
+				if !ok || got.ItemID != key {
+					t.Errorf("get(%q) = %+v, %t, want cached item", key, got, ok)
+				}
+			}
+			for _, key := range tt.wantMissing {
+				if got, ok := c.get(key); ok {
+					t.Errorf("get(%q) = %+v, want miss", key, got)
+				}
+			}
+			if evictions := c.evictions.Load(); evictions != tt.wantEvictions {
+				t.Errorf("evictions = %d, want %d", evictions, tt.wantEvictions)
+			}
+		})
+	}
+}
+
+func TestLRUCacheCopiesItems(t *testing.T) {
+	c := newLRUCache(1, time.Minute)
+	loaded := Item{ItemID: "a", Tags: []string{"before"}}
+	c.addIfUnchanged("a", loaded, c.generation())
+	loaded.Tags[0] = "after"
+
+	got, ok := c.get("a")
+	if !ok || got.Tags[0] != "before" {
+		t.Fatalf("get(a) = %+v, %t, want the tags as they were added", got, ok)
+	}
+}
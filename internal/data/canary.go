@@ -0,0 +1,348 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+)
+
+// CanaryIsolation selects where requests carrying an X-Canary PR number keep
+// their data
+type CanaryIsolation string
+
+const (
+	// CanaryIsolationShared lets canary requests read and write production data
+	CanaryIsolationShared CanaryIsolation = "shared"
+	// CanaryIsolationTable sends canary requests to a separate <table>-pr-<N>
+	// table, created with the production table's keys and indexes on the
+	// canary's first request
+	CanaryIsolationTable CanaryIsolation = "table"
+	// CanaryIsolationPrefix keeps canary data in the same table under
+	// partition keys prefixed with CANARY#<N>#
+	CanaryIsolationPrefix CanaryIsolation = "prefix"
+)
+
+// ParseCanaryIsolation parses a canary isolation policy name; empty means shared
+func ParseCanaryIsolation(s string) (CanaryIsolation, error) {
+	switch CanaryIsolation(s) {
+	case "", CanaryIsolationShared:
+		return CanaryIsolationShared, nil
+	case CanaryIsolationTable, CanaryIsolationPrefix:
+		return CanaryIsolation(s), nil
+	default:
+		return "", fmt.Errorf("unknown canary isolation %q: use shared, table or prefix", s)
+	}
+}
+
+// CanaryTableName returns the table holding a canary's data under table isolation
+func CanaryTableName(tableName, canary string) string {
+	return fmt.Sprintf("%s-pr-%s", tableName, canary)
+}
+
+// canaryKeyPrefix prefixes partition keys of a canary's data under prefix isolation
+func canaryKeyPrefix(canary string) string {
+	return fmt.Sprintf("CANARY#%s#", canary)
+}
+
+// storageScope is the table and partition key prefix a request's data lives under
+type storageScope struct {
+	table  string
+	prefix string
+}
+
+// scope resolves where the request's data lives from its canary PR number
+// and the store's isolation policy
+func (s *DynamoStore) scope(ctx context.Context) (storageScope, error) {
+	canary, ok := canaryctx.FromContext(ctx)
+	if !ok {
+		return s.productionScope(), nil
+	}
+	switch s.canaryIsolation {
+	case CanaryIsolationTable:
+		table := CanaryTableName(s.tableName, canary)
+		if err := s.ensureCanaryTable(ctx, table); err != nil {
+			return storageScope{}, err
+		}
+		return storageScope{table: table}, nil
+	case CanaryIsolationPrefix:
+		return storageScope{table: s.tableName, prefix: canaryKeyPrefix(canary)}, nil
+	default:
+		return s.productionScope(), nil
+	}
+}
+
+// productionScope is where production data lives. Tenant settings and quotas
+// always live here: they belong to the tenant and its operators rather than to
+// a canary build, so canary requests obey the same limits as production ones.
+func (s *DynamoStore) productionScope() storageScope {
+	return storageScope{table: s.tableName}
+}
+
+// canaryTableTimeout bounds creating a canary table and waiting for it
+const canaryTableTimeout = 2 * time.Minute
+
+// ensureCanaryTable creates a canary's table on its first request and waits
+// until it is active. Concurrent first requests share one creation, which
+// outlives a caller that gives up waiting.
+func (s *DynamoStore) ensureCanaryTable(ctx context.Context, table string) error {
+	if _, ok := s.canaryTables.Load(table); ok {
+		return nil
+	}
+	ch := s.canaryTableCreates.DoChan(table, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), canaryTableTimeout)
+		defer cancel()
+		if err := s.createCanaryTable(ctx, table); err != nil {
+			return nil, err
+		}
+		s.canaryTables.Store(table, struct{}{})
+		return nil, nil
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-ch:
+		return result.Err
+	}
+}
+
+// createCanaryTable creates table with the production table's key schema,
+// indexes and TTL setting unless it already exists, then waits until it is
+// active
+func (s *DynamoStore) createCanaryTable(ctx context.Context, table string) error {
+	start := time.Now()
+
+	created := false
+	_, err := s.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	var notFound *types.ResourceNotFoundException
+	switch {
+	case err == nil:
+		// Created by an earlier run or another replica
+	case errors.As(err, &notFound):
+		if err := s.copyProductionTable(ctx, table); err != nil {
+			logging.WithError(err).WithField("table", table).Error("Failed to create canary table")
+			return fmt.Errorf("failed to create canary table %s: %w", table, err)
+		}
+		created = true
+	default:
+		return fmt.Errorf("failed to describe canary table %s: %w", table, err)
+	}
+
+	waiter := dynamodb.NewTableExistsWaiter(s.client)
+	if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)}, canaryTableTimeout); err != nil {
+		return fmt.Errorf("canary table %s did not become active: %w", table, err)
+	}
+	if created {
+		if err := s.copyProductionTTL(ctx, table); err != nil {
+			logging.WithError(err).WithField("table", table).Error("Failed to enable TTL on canary table")
+			return fmt.Errorf("failed to enable TTL on canary table %s: %w", table, err)
+		}
+	}
+
+	logging.WithFields(logrus.Fields{
+		"table":    table,
+		"created":  created,
+		"duration": time.Since(start),
+	}).Info("Canary table ready")
+
+	return nil
+}
+
+// copyProductionTable creates table with the production table's keys and
+// indexes, billed on demand
+func (s *DynamoStore) copyProductionTable(ctx context.Context, table string) error {
+	production, err := s.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(s.tableName)})
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", s.tableName, err)
+	}
+
+	input := &dynamodb.CreateTableInput{
+		TableName:            aws.String(table),
+		KeySchema:            production.Table.KeySchema,
+		AttributeDefinitions: production.Table.AttributeDefinitions,
+		BillingMode:          types.BillingModePayPerRequest,
+	}
+	for _, index := range production.Table.GlobalSecondaryIndexes {
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, types.GlobalSecondaryIndex{
+			IndexName:  index.IndexName,
+			KeySchema:  index.KeySchema,
+			Projection: index.Projection,
+		})
+	}
+	for _, index := range production.Table.LocalSecondaryIndexes {
+		input.LocalSecondaryIndexes = append(input.LocalSecondaryIndexes, types.LocalSecondaryIndex{
+			IndexName:  index.IndexName,
+			KeySchema:  index.KeySchema,
+			Projection: index.Projection,
+		})
+	}
+
+	_, err = s.client.CreateTable(ctx, input)
+	var inUse *types.ResourceInUseException
+	if errors.As(err, &inUse) {
+		// Another replica is creating it
+		return nil
+	}
+	return err
+}
+
+// copyProductionTTL enables TTL on table when the production table has it,
+// since expired idempotency records and daily usage counters rely on it
+func (s *DynamoStore) copyProductionTTL(ctx context.Context, table string) error {
+	ttl, err := s.client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(s.tableName)})
+	if err != nil {
+		return fmt.Errorf("failed to describe TTL of table %s: %w", s.tableName, err)
+	}
+	description := ttl.TimeToLiveDescription
+	if description == nil || description.TimeToLiveStatus != types.TimeToLiveStatusEnabled {
+		return nil
+	}
+
+	_, err = s.client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(table),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: description.AttributeName,
+			Enabled:       aws.Bool(true),
+		},
+	})
+	return err
+}
+
+func (sc storageScope) tenantPK(tenantID int64) string {
+	return fmt.Sprintf("%sTENANT#%d", sc.prefix, tenantID)
+}
+
+func (sc storageScope) auditPK(tenantID int64) string {
+	return fmt.Sprintf("%sAUDIT#TENANT#%d", sc.prefix, tenantID)
+}
+
+// DropCanaryData removes everything a canary PR wrote. Under table isolation
+// the canary's table is deleted; under prefix isolation every record with the
+// canary's key prefix is deleted from the shared table. It returns the number
+// of records deleted (0 for a dropped table).
+func (s *DynamoStore) DropCanaryData(ctx context.Context, canary string) (int, error) {
+	if !canaryctx.IsValidCanary(canary) {
+		return 0, fmt.Errorf("invalid canary %q: must be a PR number", canary)
+	}
+
+	switch s.canaryIsolation {
+	case CanaryIsolationTable:
+		return 0, s.dropCanaryTable(ctx, canary)
+	case CanaryIsolationPrefix:
+		return s.deleteCanaryRecords(ctx, canary)
+	default:
+		return 0, fmt.Errorf("canary isolation is %q for table %s, so canary data is not separable", s.canaryIsolation, s.tableName)
+	}
+}
+
+func (s *DynamoStore) dropCanaryTable(ctx context.Context, canary string) error {
+	start := time.Now()
+	tableName := CanaryTableName(s.tableName, canary)
+
+	_, err := s.client.DeleteTable(ctx, &dynamodb.DeleteTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			logging.WithField("table", tableName).Info("Canary table already removed")
+			return nil
+		}
+		logging.WithError(err).WithField("table", tableName).Error("Failed to delete canary table")
+		return fmt.Errorf("failed to delete canary table %s: %w", tableName, err)
+	}
+
+	logging.WithFields(logrus.Fields{
+		"table":    tableName,
+		"canary":   canary,
+		"duration": time.Since(start),
+	}).Info("Canary table deleted successfully")
+
+	return nil
+}
+
+// deleteCanaryRecords scans the shared table for the canary's key prefix and
+// deletes the matches in batches
+func (s *DynamoStore) deleteCanaryRecords(ctx context.Context, canary string) (int, error) {
+	start := time.Now()
+	prefix := canaryKeyPrefix(canary)
+
+	input := &dynamodb.ScanInput{
+		TableName:            aws.String(s.tableName),
+		FilterExpression:     aws.String("begins_with(PK, :prefix)"),
+		ProjectionExpression: aws.String("PK, SK"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":prefix": &types.AttributeValueMemberS{Value: prefix},
+		},
+	}
+
+	deleted := 0
+	paginator := dynamodb.NewScanPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logging.WithError(err).WithField("canary", canary).Error("Failed to scan canary records")
+			return deleted, fmt.Errorf("failed to scan canary records: %w", err)
+		}
+
+		// BatchWriteItem accepts at most 25 requests
+		for i := 0; i < len(page.Items); i += 25 {
+			end := min(i+25, len(page.Items))
+			var requests []types.WriteRequest
+			for _, key := range page.Items[i:end] {
+				requests = append(requests, types.WriteRequest{
+					DeleteRequest: &types.DeleteRequest{Key: key},
+				})
+			}
+			if err := s.batchDelete(ctx, requests); err != nil {
+				logging.WithError(err).WithField("canary", canary).Error("Failed to delete canary records")
+				return deleted, fmt.Errorf("failed to delete canary records: %w", err)
+			}
+			deleted += len(requests)
+		}
+	}
+
+	logging.WithFields(logrus.Fields{
+		"table":    s.tableName,
+		"canary":   canary,
+		"deleted":  deleted,
+		"duration": time.Since(start),
+	}).Info("Canary records deleted successfully")
+
+	return deleted, nil
+}
+
+// batchDelete writes a batch of deletes, retrying unprocessed items with backoff
+func (s *DynamoStore) batchDelete(ctx context.Context, requests []types.WriteRequest) error {
+	backoff := 50 * time.Millisecond
+	for attempt := 0; len(requests) > 0; attempt++ {
+		if attempt > 0 {
+			if attempt > 8 {
+				return fmt.Errorf("%d deletes still unprocessed after retries", len(requests))
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		result, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{s.tableName: requests},
+		})
+		if err != nil {
+			return err
+		}
+		requests = result.UnprocessedItems[s.tableName]
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+)
+
+func TestCanaryScope(t *testing.T) {
+	tests := []struct {
+		isolation CanaryIsolation
+		wantTable string
+		wantPK    string
+	}{
+		{isolation: CanaryIsolationShared, wantTable: "store", wantPK: "TENANT#1"},
+		{isolation: CanaryIsolationPrefix, wantTable: "store", wantPK: "CANARY#7#TENANT#1"},
+		{isolation: CanaryIsolationTable, wantTable: "store-pr-7", wantPK: "TENANT#1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.isolation), func(t *testing.T) {
+			store, fake := newIsolatedFakeDynamoStore(t, tt.isolation)
+			ctx := canaryctx.WithCanary(context.Background(), "7")
+
+			if _, err := store.GetItem(ctx, testTenantID, "widget"); !errors.Is(err, ErrItemNotFound) {
+				t.Fatalf("GetItem() error = %v, want ErrItemNotFound", err)
+			}
+			if _, err := store.GetTenantConfig(ctx, testTenantID); err != nil {
+				t.Fatalf("GetTenantConfig() error = %v", err)
+			}
+
+			gets := fake.calls("GetItem")
+			if len(gets) != 2 {
+				t.Fatalf("GetItem calls = %d, want 2", len(gets))
+			}
+			item, config := gets[0], gets[1]
+			if table, pk := item["TableName"], item["Key"].(map[string]any)["PK"].(map[string]any)["S"]; table != tt.wantTable || pk != tt.wantPK {
+				t.Errorf("item read from %v %v, want %s %s", table, pk, tt.wantTable, tt.wantPK)
+			}
+			// Tenant settings and quotas always come from production
+			if table, pk := config["TableName"], config["Key"].(map[string]any)["PK"].(map[string]any)["S"]; table != "store" || pk != "TENANT#1" {
+				t.Errorf("tenant config read from %v %v, want store TENANT#1", table, pk)
+			}
+		})
+	}
+}
+
+func TestCanaryTableCreatedOnFirstRequest(t *testing.T) {
+	store, fake := newIsolatedFakeDynamoStore(t, CanaryIsolationTable)
+	ctx := canaryctx.WithCanary(context.Background(), "7")
+
+	for range 2 {
+		if _, err := store.GetItem(ctx, testTenantID, "widget"); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("GetItem() error = %v, want ErrItemNotFound", err)
+		}
+	}
+
+	creates := fake.calls("CreateTable")
+	if len(creates) != 1 {
+		t.Fatalf("CreateTable calls = %d, want 1", len(creates))
+	}
+	if create := creates[0]; create["TableName"] != "store-pr-7" || create["BillingMode"] != "PAY_PER_REQUEST" || len(create["KeySchema"].([]any)) != 2 {
+		t.Errorf("CreateTable = %v, want store-pr-7 on demand with the production key schema", create)
+	}
+
+	ttl := fake.calls("UpdateTimeToLive")
+	if len(ttl) != 1 {
+		t.Fatalf("UpdateTimeToLive calls = %d, want 1", len(ttl))
+	}
+	if attribute := ttl[0]["TimeToLiveSpecification"].(map[string]any)["AttributeName"]; attribute != "ExpiresAt" {
+		t.Errorf("TTL attribute = %v, want ExpiresAt", attribute)
+	}
+
+	// The second request finds the table known to be active
+	if describes := len(fake.calls("DescribeTable")); describes != 3 {
+		t.Errorf("DescribeTable calls = %d, want 3 for the first request only", describes)
+	}
+}
+
+func TestCanaryTableReused(t *testing.T) {
+	store, fake := newIsolatedFakeDynamoStore(t, CanaryIsolationTable)
+	fake.tables["store-pr-7"] = true
+	ctx := canaryctx.WithCanary(context.Background(), "7")
+
+	if _, err := store.GetItem(ctx, testTenantID, "widget"); !errors.Is(err, ErrItemNotFound) {
+		t.Fatalf("GetItem() error = %v, want ErrItemNotFound", err)
+	}
+	if creates := len(fake.calls("CreateTable")); creates != 0 {
+		t.Errorf("CreateTable calls = %d, want 0 for an existing table", creates)
+	}
+}
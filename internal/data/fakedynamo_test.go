@@ -0,0 +1,318 @@
+package data
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const testTenantID = 1
+
+// fakeDynamo serves the DynamoDB JSON API from memory for store tests. Gets,
+// batch gets and queries on :pk and :sk_prefix read the stored records; transactions
+// follow a script of outcomes and only apply their puts when they succeed.
+// Puts are stored unless scripted to fail their condition. Tables can be
+// described and created; other writes are accepted and ignored.
+type fakeDynamo struct {
+	mu       sync.Mutex
+	records  map[string]map[string]any // by PK and SK, in wire format
+	script   []fakeTransaction
+	requests map[string][]map[string]any // by operation
+	// unprocessedGets is how many BatchGetItem calls leave all their keys
+	// unprocessed, as under throttling
+	unprocessedGets int
+	// failedPuts is how many PutItem calls fail their condition, as when
+	// another caller wrote first
+	failedPuts int
+	tables     map[string]bool // existing tables; the store's table exists
+}
+
+// fakeTransaction is the scripted outcome of one TransactWriteItems call
+type fakeTransaction struct {
+	// cancel lists the cancellation reason code per transaction item; empty
+	// means the transaction succeeds
+	cancel []string
+	// seed is stored when the transaction runs, as by a concurrent caller
+	seed any
+}
+
+// newFakeDynamoStore starts a fake DynamoDB and returns a store using it
+func newFakeDynamoStore(t *testing.T) (*DynamoStore, *fakeDynamo) {
+	t.Helper()
+	return newIsolatedFakeDynamoStore(t, CanaryIsolationShared)
+}
+
+// newIsolatedFakeDynamoStore returns a store on a fake DynamoDB that keeps
+// canary data apart under isolation
+func newIsolatedFakeDynamoStore(t *testing.T, isolation CanaryIsolation) (*DynamoStore, *fakeDynamo) {
+	t.Helper()
+	fake := &fakeDynamo{
+		records:  make(map[string]map[string]any),
+		requests: make(map[string][]map[string]any),
+		tables:   map[string]bool{"store": true},
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := dynamodb.New(dynamodb.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "test", SecretAccessKey: "test"}, nil
+		}),
+		RetryMaxAttempts: 1,
+	})
+	return NewDynamoStore(client, "store", isolation), fake
+}
+
+// put stores record, a struct with PK and SK attributes
+func (f *fakeDynamo) put(t *testing.T, record any) {
+	t.Helper()
+	av, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		t.Fatalf("failed to marshal record: %v", err)
+	}
+	wire := make(map[string]any, len(av))
+	for name, value := range av {
+		wire[name] = wireValue(value)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.store(wire)
+}
+
+// calls returns the requests made for a DynamoDB operation, e.g. "GetItem"
+func (f *fakeDynamo) calls(operation string) []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[operation]
+}
+
+func (f *fakeDynamo) store(record map[string]any) {
+	f.records[recordKey(record)] = record
+}
+
+func (f *fakeDynamo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	operation := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "DynamoDB_20120810.")
+	var input map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests[operation] = append(f.requests[operation], input)
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	output := map[string]any{}
+	switch operation {
+	case "GetItem":
+		if record, ok := f.records[recordKey(input["Key"].(map[string]any))]; ok {
+			output["Item"] = record
+		}
+	case "PutItem":
+		if f.failedPuts > 0 {
+			f.failedPuts--
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{
+				"__type":  "com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException",
+				"Message": "The conditional request failed",
+			})
+			return
+		}
+		f.store(input["Item"].(map[string]any))
+	case "DescribeTable":
+		name := input["TableName"].(string)
+		if !f.tables[name] {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{
+				"__type":  "com.amazonaws.dynamodb.v20120810#ResourceNotFoundException",
+				"Message": "Requested resource not found",
+			})
+			return
+		}
+		output["Table"] = map[string]any{
+			"TableName":            name,
+			"TableStatus":          "ACTIVE",
+			"KeySchema":            []any{map[string]any{"AttributeName": "PK", "KeyType": "HASH"}, map[string]any{"AttributeName": "SK", "KeyType": "RANGE"}},
+			"AttributeDefinitions": []any{map[string]any{"AttributeName": "PK", "AttributeType": "S"}, map[string]any{"AttributeName": "SK", "AttributeType": "S"}},
+		}
+	case "CreateTable":
+		f.tables[input["TableName"].(string)] = true
+	case "DescribeTimeToLive":
+		output["TimeToLiveDescription"] = map[string]any{"AttributeName": "ExpiresAt", "TimeToLiveStatus": "ENABLED"}
+	case "BatchGetItem":
+		f.batchGet(input, output)
+	case "Query":
+		f.query(input, output)
+	case "TransactWriteItems":
+		var outcome fakeTransaction
+		if len(f.script) > 0 {
+			outcome, f.script = f.script[0], f.script[1:]
+		}
+		if outcome.seed != nil {
+			av, _ := attributevalue.MarshalMap(outcome.seed)
+			wire := make(map[string]any, len(av))
+			for name, value := range av {
+				wire[name] = wireValue(value)
+			}
+			f.store(wire)
+		}
+		if len(outcome.cancel) > 0 {
+			reasons := make([]map[string]string, len(outcome.cancel))
+			for i, code := range outcome.cancel {
+				reasons[i] = map[string]string{"Code": code}
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{
+				"__type":              "com.amazonaws.dynamodb.v20120810#TransactionCanceledException",
+				"Message":             "Transaction cancelled",
+				"CancellationReasons": reasons,
+			})
+			return
+		}
+		for _, item := range input["TransactItems"].([]any) {
+			if put, ok := item.(map[string]any)["Put"]; ok {
+				f.store(put.(map[string]any)["Item"].(map[string]any))
+			}
+		}
+	}
+	json.NewEncoder(w).Encode(output)
+}
+
+// batchGet answers a BatchGetItem from the stored records
+func (f *fakeDynamo) batchGet(input, output map[string]any) {
+	responses := map[string]any{}
+	unprocessed := map[string]any{}
+	for table, request := range input["RequestItems"].(map[string]any) {
+		keys := request.(map[string]any)["Keys"].([]any)
+		if f.unprocessedGets > 0 {
+			unprocessed[table] = map[string]any{"Keys": keys}
+			continue
+		}
+		records := []any{}
+		for _, key := range keys {
+			if record, ok := f.records[recordKey(key.(map[string]any))]; ok {
+				records = append(records, record)
+			}
+		}
+		responses[table] = records
+	}
+	if f.unprocessedGets > 0 {
+		f.unprocessedGets--
+	}
+	output["Responses"] = responses
+	output["UnprocessedKeys"] = unprocessed
+}
+
+// query answers a query for the records under :pk whose SK starts with
+// :sk_prefix, in SK order and paged by Limit and ExclusiveStartKey
+func (f *fakeDynamo) query(input, output map[string]any) {
+	values := input["ExpressionAttributeValues"].(map[string]any)
+	pk := values[":pk"].(map[string]any)["S"].(string)
+	prefix := values[":sk_prefix"].(map[string]any)["S"].(string)
+	after := ""
+	if start, ok := input["ExclusiveStartKey"].(map[string]any); ok {
+		after = start["SK"].(map[string]any)["S"].(string)
+	}
+
+	var keys []string
+	for key := range f.records {
+		if sk, ok := strings.CutPrefix(key, pk+"|"); ok && strings.HasPrefix(sk, prefix) && sk > after {
+			keys = append(keys, sk)
+		}
+	}
+	sort.Strings(keys)
+
+	items := []any{}
+	for _, sk := range keys {
+		if limit, ok := input["Limit"].(float64); ok && len(items) == int(limit) {
+			last := items[len(items)-1].(map[string]any)
+			output["LastEvaluatedKey"] = map[string]any{"PK": last["PK"], "SK": last["SK"]}
+			break
+		}
+		items = append(items, f.records[pk+"|"+sk])
+	}
+	output["Items"] = items
+	output["Count"] = len(items)
+}
+
+func recordKey(record map[string]any) string {
+	pk := record["PK"].(map[string]any)["S"]
+	sk := record["SK"].(map[string]any)["S"]
+	return fmt.Sprintf("%s|%s", pk, sk)
+}
+
+// wireValue converts an attribute value to DynamoDB's JSON wire format
+func wireValue(value types.AttributeValue) any {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]any{"S": v.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]any{"N": v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]any{"BOOL": v.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]any{"NULL": v.Value}
+	case *types.AttributeValueMemberB:
+		return map[string]any{"B": base64.StdEncoding.EncodeToString(v.Value)}
+	case *types.AttributeValueMemberSS:
+		return map[string]any{"SS": v.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]any{"NS": v.Value}
+	case *types.AttributeValueMemberL:
+		list := make([]any, len(v.Value))
+		for i, element := range v.Value {
+			list[i] = wireValue(element)
+		}
+		return map[string]any{"L": list}
+	case *types.AttributeValueMemberM:
+		m := make(map[string]any, len(v.Value))
+		for name, element := range v.Value {
+			m[name] = wireValue(element)
+		}
+		return map[string]any{"M": m}
+	default:
+		panic(fmt.Sprintf("unsupported attribute value %T", value))
+	}
+}
+
+// cancelledAt returns cancellation reasons with a failed condition at index
+func cancelledAt(items, index int) []string {
+	reasons := make([]string, items)
+	for i := range reasons {
+		reasons[i] = "None"
+	}
+	reasons[index] = "ConditionalCheckFailed"
+	return reasons
+}
+
+func testItem(status ItemStatus, inventoryCount int32) Item {
+	return Item{
+		PK:             "TENANT#1",
+		SK:             "ITEM#widget",
+		ItemID:         "widget",
+		TenantID:       testTenantID,
+		Name:           "Widget",
+		Status:         status,
+		InventoryCount: inventoryCount,
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
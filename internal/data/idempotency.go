@@ -0,0 +1,153 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is sent again
+// with a request that differs from the one it was first used for
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+
+const (
+	// idempotencySKPrefix prefixes stored results: IDEMPOTENCY#{method}#{key}
+	idempotencySKPrefix = "IDEMPOTENCY#"
+	// idempotencyRetention is how long a result can be replayed before the
+	// table's TTL on ExpiresAt removes it
+	idempotencyRetention = 24 * time.Hour
+)
+
+// Idempotency identifies a client-retryable mutation. RequestHash fingerprints
+// the request so a retry can be told apart from a reused key.
+type Idempotency struct {
+	Key         string
+	RequestHash string
+}
+
+type idempotencyContextKey string
+
+const idempotencyKey idempotencyContextKey = "idempotency"
+
+// WithIdempotency attaches an idempotency key to ctx. CreateItem and
+// UpdateInventory called with it apply at most once per key and return the
+// original result to retries.
+func WithIdempotency(ctx context.Context, idempotency Idempotency) context.Context {
+	return context.WithValue(ctx, idempotencyKey, idempotency)
+}
+
+// IdempotencyFromContext returns the idempotency key attached to ctx, if any
+func IdempotencyFromContext(ctx context.Context) (Idempotency, bool) {
+	idempotency, ok := ctx.Value(idempotencyKey).(Idempotency)
+	return idempotency, ok && idempotency.Key != ""
+}
+
+// idempotencyRecord is the stored result of a keyed mutation
+type idempotencyRecord struct {
+	PK            string    `dynamodbav:"PK"` // Partition key: TENANT#{tenant_id}
+	SK            string    `dynamodbav:"SK"` // Sort key: IDEMPOTENCY#{method}#{key}
+	RequestHash   string    `dynamodbav:"RequestHash"`
+	Item          Item      `dynamodbav:"Item"`
+	PreviousCount int32     `dynamodbav:"PreviousCount"`
+	CreatedAt     time.Time `dynamodbav:"CreatedAt"`
+	ExpiresAt     int64     `dynamodbav:"ExpiresAt"`
+}
+
+func idempotencySK(method, key string) string {
+	return idempotencySKPrefix + method + "#" + key
+}
+
+// storedResult looks up the result stored under the key. found is false if
+// the key is unused or expired; a key used for a different request returns
+// ErrIdempotencyKeyReused.
+func (s *DynamoStore) storedResult(ctx context.Context, tenantID int64, method string, idempotency Idempotency) (record idempotencyRecord, found bool, err error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return idempotencyRecord{}, false, err
+	}
+
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(scope.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			"SK": &types.AttributeValueMemberS{Value: idempotencySK(method, idempotency.Key)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+			"method":    method,
+		}).Error("Failed to get idempotency record")
+		return idempotencyRecord{}, false, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+	if result.Item == nil {
+		return idempotencyRecord{}, false, nil
+	}
+
+	if err := attributevalue.UnmarshalMap(result.Item, &record); err != nil {
+		return idempotencyRecord{}, false, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+	// TTL deletes lazily, so expired records may still be read
+	if record.ExpiresAt <= time.Now().Unix() {
+		return idempotencyRecord{}, false, nil
+	}
+	if record.RequestHash != idempotency.RequestHash {
+		return idempotencyRecord{}, false, ErrIdempotencyKeyReused
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"method":    method,
+		"item_id":   record.Item.ItemID,
+	}).Info("Replaying idempotent request")
+
+	return record, true, nil
+}
+
+// idempotencyPut builds the transaction entry that stores a mutation's result
+// under its key. Its condition fails if a concurrent request claimed the key
+// first.
+func (s *DynamoStore) idempotencyPut(ctx context.Context, tenantID int64, method string, idempotency Idempotency, item Item, previousCount int32, now time.Time) (*types.Put, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record := idempotencyRecord{
+		PK:            scope.tenantPK(tenantID),
+		SK:            idempotencySK(method, idempotency.Key),
+		RequestHash:   idempotency.RequestHash,
+		Item:          item,
+		PreviousCount: previousCount,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(idempotencyRetention).Unix(),
+	}
+
+	av, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	return &types.Put{
+		TableName:           aws.String(scope.table),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(PK) OR #expiresAt <= :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#expiresAt": "ExpiresAt",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Unix(), 10)},
+		},
+	}, nil
+}
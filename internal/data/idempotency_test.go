@@ -0,0 +1,206 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// storedItem is the result a previous call with the test key produced
+var storedItem = Item{
+	PK:             "TENANT#1",
+	SK:             "ITEM#stored",
+	ItemID:         "stored",
+	TenantID:       testTenantID,
+	Name:           "Stored",
+	Status:         ItemStatusActive,
+	InventoryCount: 7,
+}
+
+func storedRecord(method, hash string, expiresIn time.Duration) idempotencyRecord {
+	return idempotencyRecord{
+		PK:            "TENANT#1",
+		SK:            idempotencySK(method, "key-1"),
+		RequestHash:   hash,
+		Item:          storedItem,
+		PreviousCount: 3,
+		CreatedAt:     time.Now(),
+		ExpiresAt:     time.Now().Add(expiresIn).Unix(),
+	}
+}
+
+func TestCreateItemIdempotency(t *testing.T) {
+	key := Idempotency{Key: "key-1", RequestHash: "hash-1"}
+
+	tests := []struct {
+		name             string
+		idempotency      *Idempotency
+		stored           *idempotencyRecord
+		script           []fakeTransaction
+		wantErr          error
+		wantStored       bool // returns storedItem rather than a new item
+		wantTransactions int
+		wantTransactSize int
+	}{
+		{
+			name:             "without a key the item is created",
+			wantTransactions: 1,
+			wantTransactSize: 4,
+		},
+		{
+			name:             "first call with a key stores its result",
+			idempotency:      &key,
+			wantTransactions: 1,
+			wantTransactSize: 5,
+		},
+		{
+			name:             "retry replays the stored result",
+			idempotency:      &key,
+			stored:           ptr(storedRecord(AuditMethodCreateItem, "hash-1", time.Hour)),
+			wantStored:       true,
+			wantTransactions: 0,
+		},
+		{
+			name:             "key reused with a different request is rejected",
+			idempotency:      &key,
+			stored:           ptr(storedRecord(AuditMethodCreateItem, "hash-2", time.Hour)),
+			wantErr:          ErrIdempotencyKeyReused,
+			wantTransactions: 0,
+		},
+		{
+			name:             "expired result is not replayed",
+			idempotency:      &key,
+			stored:           ptr(storedRecord(AuditMethodCreateItem, "hash-2", -time.Hour)),
+			wantTransactions: 1,
+			wantTransactSize: 5,
+		},
+		{
+			name:        "concurrent retry that stored first is replayed",
+			idempotency: &key,
+			script: []fakeTransaction{{
+				cancel: cancelledAt(5, 4),
+				seed:   storedRecord(AuditMethodCreateItem, "hash-1", time.Hour),
+			}},
+			wantStored:       true,
+			wantTransactions: 1,
+			wantTransactSize: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			if tt.stored != nil {
+				fake.put(t, *tt.stored)
+			}
+			fake.script = tt.script
+
+			ctx := context.Background()
+			if tt.idempotency != nil {
+				ctx = WithIdempotency(ctx, *tt.idempotency)
+			}
+			item, err := store.CreateItem(ctx, testTenantID, "Widget", "", 9.99, ItemCategoryHome, "", 5, 0, nil, "alice")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if tt.wantStored && item.ItemID != storedItem.ItemID {
+					t.Errorf("CreateItem() = item %q, want the stored item %q", item.ItemID, storedItem.ItemID)
+				}
+				if !tt.wantStored && (item.ItemID == storedItem.ItemID || item.Name != "Widget") {
+					t.Errorf("CreateItem() = %+v, want a new Widget", item)
+				}
+			}
+
+			transactions := fake.calls("TransactWriteItems")
+			if len(transactions) != tt.wantTransactions {
+				t.Fatalf("TransactWriteItems calls = %d, want %d", len(transactions), tt.wantTransactions)
+			}
+			if tt.wantTransactions > 0 {
+				if size := len(transactions[0]["TransactItems"].([]any)); size != tt.wantTransactSize {
+					t.Errorf("transaction items = %d, want %d", size, tt.wantTransactSize)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateInventoryIdempotency(t *testing.T) {
+	key := Idempotency{Key: "key-1", RequestHash: "hash-1"}
+	current := testItem(ItemStatusActive, 5)
+
+	tests := []struct {
+		name              string
+		stored            *idempotencyRecord
+		script            []fakeTransaction
+		wantErr           error
+		wantStored        bool
+		wantPreviousCount int32
+		wantTransactions  int
+	}{
+		{
+			name:              "first call stores its result",
+			wantPreviousCount: 5,
+			wantTransactions:  1,
+		},
+		{
+			name:              "retry replays the stored result",
+			stored:            ptr(storedRecord(AuditMethodUpdateInventory, "hash-1", time.Hour)),
+			wantStored:        true,
+			wantPreviousCount: 3,
+		},
+		{
+			name:    "key reused with a different request is rejected",
+			stored:  ptr(storedRecord(AuditMethodUpdateInventory, "hash-2", time.Hour)),
+			wantErr: ErrIdempotencyKeyReused,
+		},
+		{
+			name: "concurrent retry that stored first is replayed",
+			script: []fakeTransaction{{
+				cancel: cancelledAt(3, 2),
+				seed:   storedRecord(AuditMethodUpdateInventory, "hash-1", time.Hour),
+			}},
+			wantStored:        true,
+			wantPreviousCount: 3,
+			wantTransactions:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			fake.put(t, current)
+			if tt.stored != nil {
+				fake.put(t, *tt.stored)
+			}
+			fake.script = tt.script
+
+			ctx := WithIdempotency(context.Background(), key)
+			item, previousCount, err := store.UpdateInventory(ctx, testTenantID, current.ItemID, -2, "sale", "alice")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateInventory() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if tt.wantStored && item.ItemID != storedItem.ItemID {
+					t.Errorf("UpdateInventory() = item %q, want the stored item %q", item.ItemID, storedItem.ItemID)
+				}
+				if previousCount != tt.wantPreviousCount {
+					t.Errorf("UpdateInventory() previous count = %d, want %d", previousCount, tt.wantPreviousCount)
+				}
+			}
+
+			transactions := fake.calls("TransactWriteItems")
+			if len(transactions) != tt.wantTransactions {
+				t.Fatalf("TransactWriteItems calls = %d, want %d", len(transactions), tt.wantTransactions)
+			}
+			if tt.wantTransactions > 0 {
+				if size := len(transactions[0]["TransactItems"].([]any)); size != 3 {
+					t.Errorf("transaction items = %d, want 3 with the stored result", size)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+const (
+	// leasePK prefixes the partition key of a lease record, e.g.
+	// LEASE#inventory_stats
+	leasePK = "LEASE#"
+	leaseSK = "LEASE"
+)
+
+// leaseRecord names the replica holding a lease until it expires. The
+// table's TTL on ExpiresAt removes leases nobody renews.
+type leaseRecord struct {
+	PK         string    `dynamodbav:"PK"`
+	SK         string    `dynamodbav:"SK"`
+	Holder     string    `dynamodbav:"Holder"`
+	AcquiredAt time.Time `dynamodbav:"AcquiredAt"`
+	ExpiresAt  int64     `dynamodbav:"ExpiresAt"`
+}
+
+// AcquireLease takes the named lease for holder for duration, or renews it if
+// holder already has it, so a background job runs on one replica at a time.
+// It reports false without an error while another holder's lease is live.
+// Leases are kept in the production table whatever the caller's canary.
+func (s *DynamoStore) AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error) {
+	now := time.Now()
+	record := leaseRecord{
+		PK:         leasePK + name,
+		SK:         leaseSK,
+		Holder:     holder,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(duration).Unix(),
+	}
+
+	av, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal lease: %w", err)
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(PK) OR #holder = :holder OR #expiresAt <= :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#holder":    "Holder",
+			"#expiresAt": "ExpiresAt",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":holder": &types.AttributeValueMemberS{Value: holder},
+			":now":    &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Unix(), 10)},
+		},
+	})
+	var held *types.ConditionalCheckFailedException
+	if errors.As(err, &held) {
+		return false, nil
+	}
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"lease":  name,
+			"holder": holder,
+		}).Error("Failed to acquire lease")
+		return false, fmt.Errorf("failed to acquire lease %s: %w", name, err)
+	}
+	return true, nil
+}
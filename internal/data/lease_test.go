@@ -0,0 +1,52 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+)
+
+func TestAcquireLease(t *testing.T) {
+	tests := []struct {
+		name       string
+		failedPuts int
+		want       bool
+	}{
+		{name: "free or own lease is taken", want: true},
+		{name: "lease held by another replica is not", failedPuts: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newIsolatedFakeDynamoStore(t, CanaryIsolationTable)
+			fake.failedPuts = tt.failedPuts
+
+			// Leases are shared by canary and production replicas
+			ctx := canaryctx.WithCanary(context.Background(), "7")
+			acquired, err := store.AcquireLease(ctx, "inventory_stats", "replica-a", time.Minute)
+
+			if err != nil {
+				t.Fatalf("AcquireLease() error = %v", err)
+			}
+			if acquired != tt.want {
+				t.Errorf("AcquireLease() = %t, want %t", acquired, tt.want)
+			}
+			puts := fake.calls("PutItem")
+			if len(puts) != 1 {
+				t.Fatalf("PutItem calls = %d, want 1", len(puts))
+			}
+			if table := puts[0]["TableName"]; table != "store" {
+				t.Errorf("lease table = %v, want the production table", table)
+			}
+			item := puts[0]["Item"].(map[string]any)
+			if pk := item["PK"].(map[string]any)["S"]; pk != "LEASE#inventory_stats" {
+				t.Errorf("lease PK = %v, want LEASE#inventory_stats", pk)
+			}
+			if holder := item["Holder"].(map[string]any)["S"]; holder != "replica-a" {
+				t.Errorf("lease holder = %v, want replica-a", holder)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+// ErrQuotaExceeded is matched by every QuotaExceededError
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Quota names, as reported in QuotaExceededError
+const (
+	QuotaMaxItems             = "max_items"
+	QuotaMaxWritesPerDay      = "max_writes_per_day"
+	QuotaMaxTagsPerItem       = "max_tags_per_item"
+	QuotaMaxDescriptionLength = "max_description_length"
+)
+
+const (
+	// tenantUsageSK is the sort key of the running item count record
+	tenantUsageSK = "USAGE"
+	// dailyUsageSKPrefix prefixes the per-day write counter records: USAGE#{yyyy-mm-dd}
+	dailyUsageSKPrefix = "USAGE#"
+	// dailyUsageRetention is how long daily counters are kept before the
+	// table's TTL on ExpiresAt removes them
+	dailyUsageRetention = 48 * time.Hour
+)
+
+// TenantQuotas holds plan limits for a tenant. Zero means unlimited.
+type TenantQuotas struct {
+	MaxItems             int32 `dynamodbav:"MaxItems"`
+	MaxWritesPerDay      int32 `dynamodbav:"MaxWritesPerDay"`
+	MaxTagsPerItem       int32 `dynamodbav:"MaxTagsPerItem"`
+	MaxDescriptionLength int32 `dynamodbav:"MaxDescriptionLength"`
+}
+
+// TenantUsage holds a tenant's current usage against its quotas
+type TenantUsage struct {
+	TenantID    int64
+	ItemCount   int64
+	WritesToday int64
+	Quotas      TenantQuotas
+}
+
+// QuotaExceededError is returned when a write would exceed a tenant quota
+type QuotaExceededError struct {
+	Quota string
+	Limit int64
+	Value int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota %s exceeded: limit %d, got %d", e.Quota, e.Limit, e.Value)
+}
+
+// Is lets errors.Is(err, ErrQuotaExceeded) match any quota violation
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// checkItemQuotas enforces the per-item quotas that need no stored state
+func checkItemQuotas(quotas TenantQuotas, description string, tags []string) error {
+	if quotas.MaxTagsPerItem > 0 && len(tags) > int(quotas.MaxTagsPerItem) {
+		return &QuotaExceededError{Quota: QuotaMaxTagsPerItem, Limit: int64(quotas.MaxTagsPerItem), Value: int64(len(tags))}
+	}
+	if length := utf8.RuneCountInString(description); quotas.MaxDescriptionLength > 0 && length > int(quotas.MaxDescriptionLength) {
+		return &QuotaExceededError{Quota: QuotaMaxDescriptionLength, Limit: int64(quotas.MaxDescriptionLength), Value: int64(length)}
+	}
+	return nil
+}
+
+func dailyUsageSK(now time.Time) string {
+	return dailyUsageSKPrefix + now.UTC().Format("2006-01-02")
+}
+
+// itemCountUpdate adjusts the tenant's running item count by delta inside a
+// transaction. A positive delta is refused once the count reaches maxItems,
+// and a negative one if it would take the count below 0.
+func itemCountUpdate(scope storageScope, tenantID int64, delta int, maxItems int32) *types.Update {
+	update := &types.Update{
+		TableName: aws.String(scope.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			"SK": &types.AttributeValueMemberS{Value: tenantUsageSK},
+		},
+		UpdateExpression: aws.String("ADD #count :delta"),
+		ExpressionAttributeNames: map[string]string{
+			"#count": "ItemCount",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":delta": &types.AttributeValueMemberN{Value: strconv.Itoa(delta)},
+		},
+	}
+	switch {
+	case delta > 0 && maxItems > 0:
+		update.ConditionExpression = aws.String("attribute_not_exists(#count) OR #count < :max")
+		update.ExpressionAttributeValues[":max"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", maxItems)}
+	case delta < 0:
+		update.ConditionExpression = aws.String("#count >= :needed")
+		update.ExpressionAttributeValues[":needed"] = &types.AttributeValueMemberN{Value: strconv.Itoa(-delta)}
+	}
+	return update
+}
+
+// transactReleasingItem runs a transaction whose item at index lowers the
+// tenant's item count. Counts that were not backfilled can reach 0 while
+// items remain; the transaction is then retried without that update, keeping
+// the count at 0 until RecountItems corrects it.
+func (s *DynamoStore) transactReleasingItem(ctx context.Context, tenantID int64, input *dynamodb.TransactWriteItemsInput, index int) error {
+	_, err := s.client.TransactWriteItems(ctx, input)
+	if !conditionFailed(err, index) {
+		return err
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+	}).Warn("Item count already at 0, leaving it unchanged; run RecountItems to correct it")
+	retry := *input
+	retry.TransactItems = slices.Delete(slices.Clone(input.TransactItems), index, index+1)
+	_, err = s.client.TransactWriteItems(ctx, &retry)
+	return err
+}
+
+// dailyWritesUpdate counts one write against today's counter inside a
+// transaction, refusing it once the count reaches maxWrites
+func dailyWritesUpdate(scope storageScope, tenantID int64, now time.Time, maxWrites int32) *types.Update {
+	update := &types.Update{
+		TableName: aws.String(scope.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			"SK": &types.AttributeValueMemberS{Value: dailyUsageSK(now)},
+		},
+		UpdateExpression: aws.String("ADD #writes :one SET #expiresAt = :expiresAt"),
+		ExpressionAttributeNames: map[string]string{
+			"#writes":    "Writes",
+			"#expiresAt": "ExpiresAt",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one":       &types.AttributeValueMemberN{Value: "1"},
+			":expiresAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Add(dailyUsageRetention).Unix(), 10)},
+		},
+	}
+	if maxWrites > 0 {
+		update.ConditionExpression = aws.String("attribute_not_exists(#writes) OR #writes < :max")
+		update.ExpressionAttributeValues[":max"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", maxWrites)}
+	}
+	return update
+}
+
+// conditionFailed reports whether the transaction was cancelled because the
+// condition on the item at the given index failed
+func conditionFailed(err error, index int) bool {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) || index >= len(canceled.CancellationReasons) {
+		return false
+	}
+	return aws.ToString(canceled.CancellationReasons[index].Code) == "ConditionalCheckFailed"
+}
+
+// GetTenantUsage reports a tenant's current item count and today's writes
+// alongside its quotas. ItemCount tracks items created minus items deleted.
+func (s *DynamoStore) GetTenantUsage(ctx context.Context, tenantID int64) (TenantUsage, error) {
+	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return TenantUsage{}, err
+	}
+
+	config, err := s.GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return TenantUsage{}, err
+	}
+
+	pk := &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)}
+	records, err := s.batchGet(ctx, scope.table, []map[string]types.AttributeValue{
+		{"PK": pk, "SK": &types.AttributeValueMemberS{Value: tenantUsageSK}},
+		{"PK": pk, "SK": &types.AttributeValueMemberS{Value: dailyUsageSK(start)}},
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+		}).Error("Failed to get tenant usage")
+		return TenantUsage{}, fmt.Errorf("failed to get tenant usage: %w", err)
+	}
+
+	usage := TenantUsage{
+		TenantID: tenantID,
+		Quotas:   config.TenantQuotas,
+	}
+	for _, record := range records {
+		var counters struct {
+			SK        string `dynamodbav:"SK"`
+			ItemCount int64  `dynamodbav:"ItemCount"`
+			Writes    int64  `dynamodbav:"Writes"`
+		}
+		if err := attributevalue.UnmarshalMap(record, &counters); err != nil {
+			logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"tenant_id": tenantID,
+			}).Error("Failed to unmarshal tenant usage")
+			return TenantUsage{}, fmt.Errorf("failed to unmarshal tenant usage: %w", err)
+		}
+		if counters.SK == tenantUsageSK {
+			usage.ItemCount = counters.ItemCount
+		} else {
+			usage.WritesToday = counters.Writes
+		}
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":    tenantID,
+		"item_count":   usage.ItemCount,
+		"writes_today": usage.WritesToday,
+		"duration":     time.Since(start),
+	}).Debug("Tenant usage retrieved successfully")
+
+	return usage, nil
+}
+
+// batchGet reads keys from table, retrying keys DynamoDB leaves unprocessed
+// under throttling so missing records are never mistaken for absent ones
+func (s *DynamoStore) batchGet(ctx context.Context, table string, keys []map[string]types.AttributeValue) ([]map[string]types.AttributeValue, error) {
+	var records []map[string]types.AttributeValue
+	backoff := 50 * time.Millisecond
+	for attempt := 0; len(keys) > 0; attempt++ {
+		if attempt > 0 {
+			if attempt > 8 {
+				return nil, fmt.Errorf("%d keys still unprocessed after retries", len(keys))
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		result, err := s.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]types.KeysAndAttributes{table: {Keys: keys}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, result.Responses[table]...)
+		keys = result.UnprocessedKeys[table].Keys
+	}
+	return records, nil
+}
+
+// RecountItems sets a tenant's running item count to the number of items that
+// are not discontinued, returning the count before and after. It backfills
+// counts of tenants created before items were counted. Items created or
+// deleted while it runs may be missed, so run it when the tenant is quiet.
+func (s *DynamoStore) RecountItems(ctx context.Context, tenantID int64) (int64, int64, error) {
+	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var count int64
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(scope.table),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :skPrefix)"),
+		FilterExpression:       aws.String("#status <> :discontinued"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":           &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			":skPrefix":     &types.AttributeValueMemberS{Value: "ITEM#"},
+			":discontinued": &types.AttributeValueMemberN{Value: strconv.Itoa(int(ItemStatusDiscontinued))},
+		},
+		Select:         types.SelectCount,
+		ConsistentRead: aws.Bool(true),
+	}
+	paginator := dynamodb.NewQueryPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"tenant_id": tenantID,
+			}).Error("Failed to count items")
+			return 0, 0, fmt.Errorf("failed to count items: %w", err)
+		}
+		count += int64(page.Count)
+	}
+
+	result, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(scope.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			"SK": &types.AttributeValueMemberS{Value: tenantUsageSK},
+		},
+		UpdateExpression: aws.String("SET #count = :count"),
+		ExpressionAttributeNames: map[string]string{
+			"#count": "ItemCount",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":count": &types.AttributeValueMemberN{Value: strconv.FormatInt(count, 10)},
+		},
+		ReturnValues: types.ReturnValueUpdatedOld,
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+		}).Error("Failed to set item count")
+		return 0, 0, fmt.Errorf("failed to set item count: %w", err)
+	}
+
+	var previous struct {
+		ItemCount int64 `dynamodbav:"ItemCount"`
+	}
+	if err := attributevalue.UnmarshalMap(result.Attributes, &previous); err != nil {
+		return 0, 0, fmt.Errorf("failed to unmarshal item count: %w", err)
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":           tenantID,
+		"previous_item_count": previous.ItemCount,
+		"item_count":          count,
+		"duration":            time.Since(start),
+	}).Info("Item count recounted successfully")
+
+	return previous.ItemCount, count, nil
+}
@@ -0,0 +1,178 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUpdateItemItemCount(t *testing.T) {
+	tests := []struct {
+		name             string
+		from, to         ItemStatus
+		script           []fakeTransaction
+		wantErr          error
+		wantQuota        string
+		wantTransactions []int // items per TransactWriteItems call
+	}{
+		{
+			name:             "status change within counted statuses",
+			from:             ItemStatusActive,
+			to:               ItemStatusInactive,
+			wantTransactions: []int{3},
+		},
+		{
+			name:             "reactivation takes a slot",
+			from:             ItemStatusDiscontinued,
+			to:               ItemStatusActive,
+			wantTransactions: []int{4},
+		},
+		{
+			name:             "reactivation over max_items is refused",
+			from:             ItemStatusDiscontinued,
+			to:               ItemStatusActive,
+			script:           []fakeTransaction{{cancel: cancelledAt(4, 3)}},
+			wantQuota:        QuotaMaxItems,
+			wantTransactions: []int{4},
+		},
+		{
+			name:             "discontinuing releases a slot",
+			from:             ItemStatusActive,
+			to:               ItemStatusDiscontinued,
+			wantTransactions: []int{4},
+		},
+		{
+			name:             "discontinuing at a zero count leaves the count",
+			from:             ItemStatusActive,
+			to:               ItemStatusDiscontinued,
+			script:           []fakeTransaction{{cancel: cancelledAt(4, 3)}},
+			wantTransactions: []int{4, 3},
+		},
+		{
+			name:             "status changed since the read is read again",
+			from:             ItemStatusDiscontinued,
+			to:               ItemStatusActive,
+			script:           []fakeTransaction{{cancel: cancelledAt(4, 0)}},
+			wantTransactions: []int{4, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			fake.put(t, testItem(tt.from, 5))
+			fake.put(t, TenantConfig{PK: "TENANT#1", SK: tenantConfigSK, TenantID: testTenantID, TenantQuotas: TenantQuotas{MaxItems: 10}})
+			fake.script = tt.script
+
+			_, err := store.UpdateItem(context.Background(), testTenantID, "widget", "Widget", "", 9.99, ItemCategoryHome, tt.to, "", 5, 0, nil, "alice")
+
+			var quotaErr *QuotaExceededError
+			switch {
+			case tt.wantQuota != "":
+				if !errors.As(err, &quotaErr) || quotaErr.Quota != tt.wantQuota {
+					t.Fatalf("UpdateItem() error = %v, want %s quota exceeded", err, tt.wantQuota)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Fatalf("UpdateItem() error = %v, want %v", err, tt.wantErr)
+			}
+
+			transactions := fake.calls("TransactWriteItems")
+			if len(transactions) != len(tt.wantTransactions) {
+				t.Fatalf("TransactWriteItems calls = %d, want %d", len(transactions), len(tt.wantTransactions))
+			}
+			for i, want := range tt.wantTransactions {
+				if size := len(transactions[i]["TransactItems"].([]any)); size != want {
+					t.Errorf("transaction %d items = %d, want %d", i, size, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteItemItemCount(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           ItemStatus
+		script           []fakeTransaction
+		wantTransactions []int // items per TransactWriteItems call
+	}{
+		{
+			name:             "releases a slot",
+			status:           ItemStatusActive,
+			wantTransactions: []int{3},
+		},
+		{
+			name:             "zero count is left unchanged",
+			status:           ItemStatusActive,
+			script:           []fakeTransaction{{cancel: cancelledAt(3, 1)}},
+			wantTransactions: []int{3, 2},
+		},
+		{
+			name:   "discontinued item is not deleted again",
+			status: ItemStatusDiscontinued,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			fake.put(t, testItem(tt.status, 5))
+			fake.script = tt.script
+
+			if err := store.DeleteItem(context.Background(), testTenantID, "widget", "alice"); err != nil {
+				t.Fatalf("DeleteItem() error = %v", err)
+			}
+
+			transactions := fake.calls("TransactWriteItems")
+			if len(transactions) != len(tt.wantTransactions) {
+				t.Fatalf("TransactWriteItems calls = %d, want %d", len(transactions), len(tt.wantTransactions))
+			}
+			for i, want := range tt.wantTransactions {
+				if size := len(transactions[i]["TransactItems"].([]any)); size != want {
+					t.Errorf("transaction %d items = %d, want %d", i, size, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTenantUsageRetriesUnprocessedKeys(t *testing.T) {
+	tests := []struct {
+		name            string
+		unprocessedGets int
+		timeout         time.Duration
+		wantErr         bool
+		wantCalls       int // 0 skips the check
+	}{
+		{name: "all keys processed", wantCalls: 1},
+		{name: "throttled keys are read again", unprocessedGets: 2, wantCalls: 3},
+		{name: "keys that stay unprocessed are an error", unprocessedGets: 100, timeout: 200 * time.Millisecond, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			fake.put(t, map[string]any{"PK": "TENANT#1", "SK": tenantUsageSK, "ItemCount": 4})
+			fake.unprocessedGets = tt.unprocessedGets
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			usage, err := store.GetTenantUsage(ctx, testTenantID)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetTenantUsage() error = %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && usage.ItemCount != 4 {
+				t.Errorf("GetTenantUsage() item count = %d, want 4", usage.ItemCount)
+			}
+			if calls := len(fake.calls("BatchGetItem")); tt.wantCalls > 0 && calls != tt.wantCalls {
+				t.Errorf("BatchGetItem calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+// ErrStorageTimeout is returned when an operation attempt outlives its
+// timeout while the caller is still waiting
+var ErrStorageTimeout = errors.New("storage timed out")
+
+// ErrCircuitOpen is matched by every CircuitOpenError
+var ErrCircuitOpen = errors.New("storage circuit breaker is open")
+
+// CircuitOpenError is returned without calling the store while the circuit
+// breaker is open
+type CircuitOpenError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrCircuitOpen, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is lets errors.Is(err, ErrCircuitOpen) match any CircuitOpenError
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// ResilienceConfig tunes a ResilientStore
+type ResilienceConfig struct {
+	// Timeout bounds each attempt of an operation, unless OperationTimeouts
+	// names the operation, e.g. "list_items"
+	Timeout           time.Duration
+	OperationTimeouts map[string]time.Duration
+
+	// MaxAttempts is the most attempts of a retryable operation, including
+	// the first. Delays between them are drawn at random up to BaseDelay
+	// doubled per retry, capped at MaxDelay.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+
+	// BreakerThreshold consecutive failed operations open the circuit for
+	// BreakerCooldown; 0 disables the breaker
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// ClientOptions bounds each DynamoDB HTTP request by requestTimeout, so a
+// stalled connection fails and is retried by the SDK instead of using up the
+// whole operation timeout
+func ClientOptions(requestTimeout time.Duration) func(*dynamodb.Options) {
+	return func(o *dynamodb.Options) {
+		if requestTimeout > 0 {
+			o.HTTPClient = awshttp.NewBuildableClient().WithTimeout(requestTimeout)
+		}
+	}
+}
+
+// BreakerState is the state of a circuit breaker
+type BreakerState int32
+
+const (
+	// BreakerClosed lets every call through
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen lets a single probe through after the cooldown
+	BreakerHalfOpen
+	// BreakerOpen fails calls fast without calling the store
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half_open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// ResilienceStats are the circuit breaker state and cumulative counters of a
+// ResilientStore
+type ResilienceStats struct {
+	State    BreakerState
+	Retries  uint64
+	Timeouts uint64
+	Rejected uint64 // calls failed fast by the open breaker
+	Trips    uint64 // times the breaker opened
+}
+
+// ResilientStore wraps a StoreInterface with per-operation timeouts, jittered
+// retries and a circuit breaker. Reads are retried on throttling, transient
+// and timeout errors; CreateItem and UpdateInventory only when they carry an
+// idempotency key, and other writes never, since a failed attempt may still
+// have been applied.
+type ResilientStore struct {
+	next    StoreInterface
+	config  ResilienceConfig
+	breaker *circuitBreaker
+
+	retries, timeouts atomic.Uint64
+}
+
+// NewResilientStore creates a resilience decorator around next
+func NewResilientStore(next StoreInterface, config ResilienceConfig) *ResilientStore {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	return &ResilientStore{
+		next:    next,
+		config:  config,
+		breaker: newCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
+	}
+}
+
+// Stats returns the breaker state and counters so far
+func (s *ResilientStore) Stats() ResilienceStats {
+	state, trips, rejected := s.breaker.stats()
+	return ResilienceStats{
+		State:    state,
+		Retries:  s.retries.Load(),
+		Timeouts: s.timeouts.Load(),
+		Rejected: rejected,
+		Trips:    trips,
+	}
+}
+
+// BreakerCheck is a health check that fails while the breaker is open
+func (s *ResilientStore) BreakerCheck(ctx context.Context) error {
+	if state, _, _ := s.breaker.stats(); state == BreakerOpen {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+func (s *ResilientStore) timeout(operation string) time.Duration {
+	if timeout, ok := s.config.OperationTimeouts[operation]; ok {
+		return timeout
+	}
+	return s.config.Timeout
+}
+
+// call runs fn through the breaker, with a timeout per attempt, retrying
+// retryable failures when retrySafe
+func (s *ResilientStore) call(ctx context.Context, operation string, retrySafe bool, fn func(ctx context.Context) error) error {
+	if err := s.breaker.allow(); err != nil {
+		return err
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = s.attempt(ctx, operation, fn)
+		if err == nil || !retrySafe || !retryable(err) || attempt >= s.config.MaxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		delay := s.backoff(attempt)
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"operation": operation,
+			"attempt":   attempt,
+			"delay":     delay,
+		}).Warn("Retrying store operation")
+		s.retries.Add(1)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.breaker.abandon()
+			return err
+		case <-timer.C:
+		}
+	}
+
+	// A caller giving up says nothing about the store's health
+	if ctx.Err() != nil {
+		s.breaker.abandon()
+	} else {
+		s.breaker.record(ctx, err)
+	}
+	return err
+}
+
+func (s *ResilientStore) attempt(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	timeout := s.timeout(operation)
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := fn(attemptCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		s.timeouts.Add(1)
+		return fmt.Errorf("%w: %s after %s", ErrStorageTimeout, operation, timeout)
+	}
+	return err
+}
+
+// backoff returns a random delay up to the exponential backoff of attempt
+func (s *ResilientStore) backoff(attempt int) time.Duration {
+	limit := s.config.BaseDelay << (attempt - 1)
+	if limit <= 0 || limit > s.config.MaxDelay {
+		limit = s.config.MaxDelay
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit) + 1
+}
+
+// retryable reports whether err is a timeout or a transient DynamoDB failure
+// that a new attempt may not hit
+func retryable(err error) bool {
+	if errors.Is(err, ErrStorageTimeout) {
+		return true
+	}
+
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		for _, reason := range canceled.CancellationReasons {
+			if reason.Code != nil && (*reason.Code == "ThrottlingError" || *reason.Code == "TransactionConflict") {
+				return true
+			}
+		}
+		return false
+	}
+
+	return retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+}
+
+// failure reports whether err counts against the store's health. Errors the
+// store answered with, such as a missing item, a failed condition or a
+// transaction conflict, do not.
+func failure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		for _, reason := range canceled.CancellationReasons {
+			if reason.Code != nil && *reason.Code == "ThrottlingError" {
+				return true
+			}
+		}
+		return false
+	}
+	return retryable(err)
+}
+
+func (s *ResilientStore) CreateItem(ctx context.Context, tenantID int64, name, description string, price float64, category ItemCategory, sku string, inventoryCount, lowStockThreshold int32, tags []string, createdBy string) (item Item, err error) {
+	_, keyed := IdempotencyFromContext(ctx)
+	err = s.call(ctx, "create_item", keyed, func(ctx context.Context) (err error) {
+		item, err = s.next.CreateItem(ctx, tenantID, name, description, price, category, sku, inventoryCount, lowStockThreshold, tags, createdBy)
+		return err
+	})
+	return item, err
+}
+
+func (s *ResilientStore) GetItem(ctx context.Context, tenantID int64, itemID string) (item Item, err error) {
+	err = s.call(ctx, "get_item", true, func(ctx context.Context) (err error) {
+		item, err = s.next.GetItem(ctx, tenantID, itemID)
+		return err
+	})
+	return item, err
+}
+
+func (s *ResilientStore) UpdateItem(ctx context.Context, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount, lowStockThreshold int32, tags []string, updatedBy string) (item Item, err error) {
+	err = s.call(ctx, "update_item", false, func(ctx context.Context) (err error) {
+		item, err = s.next.UpdateItem(ctx, tenantID, itemID, name, description, price, category, status, sku, inventoryCount, lowStockThreshold, tags, updatedBy)
+		return err
+	})
+	return item, err
+}
+
+func (s *ResilientStore) DeleteItem(ctx context.Context, tenantID int64, itemID, deletedBy string) error {
+	return s.call(ctx, "delete_item", false, func(ctx context.Context) error {
+		return s.next.DeleteItem(ctx, tenantID, itemID, deletedBy)
+	})
+}
+
+func (s *ResilientStore) ListItems(ctx context.Context, tenantID int64, category ItemCategory, status ItemStatus, searchQuery string, pageSize int32, pageToken string) (items []Item, nextPageToken string, totalCount int32, err error) {
+	err = s.call(ctx, "list_items", true, func(ctx context.Context) (err error) {
+		items, nextPageToken, totalCount, err = s.next.ListItems(ctx, tenantID, category, status, searchQuery, pageSize, pageToken)
+		return err
+	})
+	return items, nextPageToken, totalCount, err
+}
+
+func (s *ResilientStore) UpdateInventory(ctx context.Context, tenantID int64, itemID string, quantityChange int32, reason, updatedBy string) (item Item, previousCount int32, err error) {
+	_, keyed := IdempotencyFromContext(ctx)
+	err = s.call(ctx, "update_inventory", keyed, func(ctx context.Context) (err error) {
+		item, previousCount, err = s.next.UpdateInventory(ctx, tenantID, itemID, quantityChange, reason, updatedBy)
+		return err
+	})
+	return item, previousCount, err
+}
+
+func (s *ResilientStore) ListLowStockItems(ctx context.Context, tenantID int64, pageSize int32, pageToken string) (items []Item, nextPageToken string, err error) {
+	err = s.call(ctx, "list_low_stock_items", true, func(ctx context.Context) (err error) {
+		items, nextPageToken, err = s.next.ListLowStockItems(ctx, tenantID, pageSize, pageToken)
+		return err
+	})
+	return items, nextPageToken, err
+}
+
+func (s *ResilientStore) GetTenantConfig(ctx context.Context, tenantID int64) (config TenantConfig, err error) {
+	err = s.call(ctx, "get_tenant_config", true, func(ctx context.Context) (err error) {
+		config, err = s.next.GetTenantConfig(ctx, tenantID)
+		return err
+	})
+	return config, err
+}
+
+func (s *ResilientStore) UpdateTenantConfig(ctx context.Context, tenantID int64, defaultLowStockThreshold int32, updatedBy string) (config TenantConfig, err error) {
+	err = s.call(ctx, "update_tenant_config", false, func(ctx context.Context) (err error) {
+		config, err = s.next.UpdateTenantConfig(ctx, tenantID, defaultLowStockThreshold, updatedBy)
+		return err
+	})
+	return config, err
+}
+
+func (s *ResilientStore) SetTenantQuotas(ctx context.Context, tenantID int64, quotas TenantQuotas, updatedBy string) (config TenantConfig, err error) {
+	err = s.call(ctx, "set_tenant_quotas", false, func(ctx context.Context) (err error) {
+		config, err = s.next.SetTenantQuotas(ctx, tenantID, quotas, updatedBy)
+		return err
+	})
+	return config, err
+}
+
+func (s *ResilientStore) GetTenantUsage(ctx context.Context, tenantID int64) (usage TenantUsage, err error) {
+	err = s.call(ctx, "get_tenant_usage", true, func(ctx context.Context) (err error) {
+		usage, err = s.next.GetTenantUsage(ctx, tenantID)
+		return err
+	})
+	return usage, err
+}
+
+// RecountItems sets the count from the items themselves, so repeating it is safe
+func (s *ResilientStore) RecountItems(ctx context.Context, tenantID int64) (previous, count int64, err error) {
+	err = s.call(ctx, "recount_items", true, func(ctx context.Context) (err error) {
+		previous, count, err = s.next.RecountItems(ctx, tenantID)
+		return err
+	})
+	return previous, count, err
+}
+
+func (s *ResilientStore) ListAuditEvents(ctx context.Context, tenantID int64, filter AuditFilter, pageSize int32, pageToken string) (events []AuditEvent, nextPageToken string, err error) {
+	err = s.call(ctx, "list_audit_events", true, func(ctx context.Context) (err error) {
+		events, nextPageToken, err = s.next.ListAuditEvents(ctx, tenantID, filter, pageSize, pageToken)
+		return err
+	})
+	return events, nextPageToken, err
+}
+
+func (s *ResilientStore) InventoryStats(ctx context.Context) (stats []TenantInventory, err error) {
+	err = s.call(ctx, "inventory_stats", true, func(ctx context.Context) (err error) {
+		stats, err = s.next.InventoryStats(ctx)
+		return err
+	})
+	return stats, err
+}
+
+// AcquireLease only renews a lease its holder already has, so repeating it is safe
+func (s *ResilientStore) AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (acquired bool, err error) {
+	err = s.call(ctx, "acquire_lease", true, func(ctx context.Context) (err error) {
+		acquired, err = s.next.AcquireLease(ctx, name, holder, duration)
+		return err
+	})
+	return acquired, err
+}
+
+// circuitBreaker opens after threshold consecutive failures, fails calls fast
+// for the cooldown, then lets one probe through: its success closes the
+// circuit and its failure opens it again
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+	trips    uint64
+	rejected uint64
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns a CircuitOpenError if a call may not go through now
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen {
+		if wait := b.cooldown - time.Since(b.openedAt); wait > 0 {
+			b.rejected++
+			return &CircuitOpenError{RetryAfter: wait}
+		}
+		b.state = BreakerHalfOpen
+	}
+	if b.state == BreakerHalfOpen {
+		if b.probing {
+			b.rejected++
+			return &CircuitOpenError{RetryAfter: b.cooldown}
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record counts the outcome of an allowed call; a nil err is a success
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.state == BreakerHalfOpen && b.probing
+	if wasProbe {
+		b.probing = false
+	}
+
+	if !failure(err) {
+		b.failures = 0
+		if wasProbe {
+			b.state = BreakerClosed
+			logging.FromContext(ctx).Info("Storage circuit breaker closed")
+		}
+		return
+	}
+
+	b.failures++
+	if wasProbe || (b.state == BreakerClosed && b.failures >= b.threshold) {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		b.trips++
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"failures": b.failures,
+			"cooldown": b.cooldown,
+		}).Error("Storage circuit breaker opened")
+	}
+}
+
+// abandon ends an allowed call without counting it, freeing the probe slot
+// if it was the half-open probe
+func (b *circuitBreaker) abandon() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+}
+
+func (b *circuitBreaker) stats() (state BreakerState, trips, rejected uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state = b.state
+	if state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		state = BreakerHalfOpen
+	}
+	return state, b.trips, b.rejected
+}
@@ -0,0 +1,153 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errTimeout := fmt.Errorf("get item: %w", ErrStorageTimeout)
+
+	// Each step acts on the breaker and checks the state it leaves
+	type step struct {
+		action    string // allow, record, abandon or cooldown
+		err       error  // recorded outcome
+		wantOpen  bool   // allow must return a CircuitOpenError
+		wantState BreakerState
+	}
+	tests := []struct {
+		name         string
+		threshold    int
+		steps        []step
+		wantTrips    uint64
+		wantRejected uint64
+	}{
+		{
+			name:      "disabled breaker never opens",
+			threshold: 0,
+			steps: []step{
+				{action: "record", err: errTimeout, wantState: BreakerClosed},
+				{action: "record", err: errTimeout, wantState: BreakerClosed},
+				{action: "allow", wantState: BreakerClosed},
+			},
+		},
+		{
+			name:      "opens after threshold consecutive failures",
+			threshold: 2,
+			steps: []step{
+				{action: "allow", wantState: BreakerClosed},
+				{action: "record", err: errTimeout, wantState: BreakerClosed},
+				{action: "allow", wantState: BreakerClosed},
+				{action: "record", err: errTimeout, wantState: BreakerOpen},
+				{action: "allow", wantOpen: true, wantState: BreakerOpen},
+				{action: "allow", wantOpen: true, wantState: BreakerOpen},
+			},
+			wantTrips:    1,
+			wantRejected: 2,
+		},
+		{
+			name:      "success resets the failure count",
+			threshold: 2,
+			steps: []step{
+				{action: "record", err: errTimeout, wantState: BreakerClosed},
+				{action: "record", wantState: BreakerClosed},
+				{action: "record", err: errTimeout, wantState: BreakerClosed},
+				{action: "allow", wantState: BreakerClosed},
+			},
+		},
+		{
+			name:      "answers from the store do not count",
+			threshold: 1,
+			steps: []step{
+				{action: "record", err: ErrItemNotFound, wantState: BreakerClosed},
+				{action: "record", err: ErrInsufficientInventory, wantState: BreakerClosed},
+				{action: "record", err: context.Canceled, wantState: BreakerClosed},
+				{action: "allow", wantState: BreakerClosed},
+			},
+		},
+		{
+			name:      "successful probe closes after cooldown",
+			threshold: 1,
+			steps: []step{
+				{action: "record", err: errTimeout, wantState: BreakerOpen},
+				{action: "cooldown", wantState: BreakerHalfOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "allow", wantOpen: true, wantState: BreakerHalfOpen},
+				{action: "record", wantState: BreakerClosed},
+				{action: "allow", wantState: BreakerClosed},
+			},
+			wantTrips:    1,
+			wantRejected: 1,
+		},
+		{
+			name:      "failed probe opens again",
+			threshold: 3,
+			steps: []step{
+				{action: "record", err: errTimeout, wantState: BreakerClosed},
+				{action: "record", err: errTimeout, wantState: BreakerClosed},
+				{action: "record", err: errTimeout, wantState: BreakerOpen},
+				{action: "cooldown", wantState: BreakerHalfOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "record", err: errTimeout, wantState: BreakerOpen},
+				{action: "allow", wantOpen: true, wantState: BreakerOpen},
+			},
+			wantTrips:    2,
+			wantRejected: 1,
+		},
+		{
+			name:      "abandoned probe frees the probe slot",
+			threshold: 1,
+			steps: []step{
+				{action: "record", err: errTimeout, wantState: BreakerOpen},
+				{action: "cooldown", wantState: BreakerHalfOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "abandon", wantState: BreakerHalfOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "record", wantState: BreakerClosed},
+			},
+			wantTrips: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			breaker := newCircuitBreaker(tt.threshold, time.Minute)
+
+			for i, step := range tt.steps {
+				switch step.action {
+				case "allow":
+					err := breaker.allow()
+					var openErr *CircuitOpenError
+					if isOpen := errors.As(err, &openErr); isOpen != step.wantOpen {
+						t.Fatalf("step %d: allow() = %v, want open %t", i, err, step.wantOpen)
+					}
+					if step.wantOpen && (!errors.Is(err, ErrCircuitOpen) || openErr.RetryAfter <= 0) {
+						t.Fatalf("step %d: allow() = %v, want ErrCircuitOpen with a retry delay", i, err)
+					}
+				case "record":
+					breaker.record(ctx, step.err)
+				case "abandon":
+					breaker.abandon()
+				case "cooldown":
+					breaker.mu.Lock()
+					breaker.openedAt = breaker.openedAt.Add(-breaker.cooldown)
+					breaker.mu.Unlock()
+				default:
+					t.Fatalf("step %d: unknown action %q", i, step.action)
+				}
+
+				if state, _, _ := breaker.stats(); state != step.wantState {
+					t.Fatalf("step %d (%s): state = %s, want %s", i, step.action, state, step.wantState)
+				}
+			}
+
+			if _, trips, rejected := breaker.stats(); trips != tt.wantTrips || rejected != tt.wantRejected {
+				t.Errorf("trips, rejected = %d, %d, want %d, %d", trips, rejected, tt.wantTrips, tt.wantRejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+// TenantInventory summarises a tenant's live catalog
+type TenantInventory struct {
+	TenantID   int64
+	ItemCount  int64
+	StockValue float64 // sum of price * inventory count
+}
+
+// InventoryStats scans the production table and totals the items and stock
+// value of every tenant. Discontinued items and canary records are skipped.
+// It reads the whole table, so call it periodically rather than per request.
+func (s *DynamoStore) InventoryStats(ctx context.Context) ([]TenantInventory, error) {
+	start := time.Now()
+
+	input := &dynamodb.ScanInput{
+		TableName:            aws.String(s.tableName),
+		FilterExpression:     aws.String("begins_with(PK, :tenant) AND begins_with(SK, :item) AND #status <> :discontinued"),
+		ProjectionExpression: aws.String("TenantID, Price, InventoryCount"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "Status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":tenant":       &types.AttributeValueMemberS{Value: "TENANT#"},
+			":item":         &types.AttributeValueMemberS{Value: "ITEM#"},
+			":discontinued": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", int(ItemStatusDiscontinued))},
+		},
+	}
+
+	totals := make(map[int64]*TenantInventory)
+	paginator := dynamodb.NewScanPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logging.WithError(err).Error("Failed to scan inventory stats")
+			return nil, fmt.Errorf("failed to scan inventory stats: %w", err)
+		}
+
+		var items []Item
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			logging.WithError(err).Error("Failed to unmarshal inventory stats")
+			return nil, fmt.Errorf("failed to unmarshal inventory stats: %w", err)
+		}
+		for _, item := range items {
+			total, ok := totals[item.TenantID]
+			if !ok {
+				total = &TenantInventory{TenantID: item.TenantID}
+				totals[item.TenantID] = total
+			}
+			total.ItemCount++
+			total.StockValue += item.Price * float64(item.InventoryCount)
+		}
+	}
+
+	stats := make([]TenantInventory, 0, len(totals))
+	for _, total := range totals {
+		stats = append(stats, *total)
+	}
+
+	logging.WithFields(logrus.Fields{
+		"table":    s.tableName,
+		"tenants":  len(stats),
+		"duration": time.Since(start),
+	}).Debug("Inventory stats collected")
+
+	return stats, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/rinsecrm/store-service/core/logging"
 )
@@ -19,6 +21,14 @@ import (
 // ErrItemNotFound is returned when an item is not found
 var ErrItemNotFound = errors.New("item not found")
 
+// ErrInsufficientInventory is returned when an inventory change would leave
+// the count negative
+var ErrInsufficientInventory = errors.New("insufficient inventory")
+
+// ErrConcurrentUpdate is returned when an item changed between being read and
+// written in a way that invalidates the write; the caller may retry it
+var ErrConcurrentUpdate = errors.New("item was changed concurrently")
+
 // ItemCategory represents different types of store items
 type ItemCategory int
 
@@ -44,96 +54,190 @@ const (
 
 // Item represents a store item with enhanced fields
 type Item struct {
-	PK             string       `dynamodbav:"PK"` // Partition key: TENANT#{tenant_id}
-	SK             string       `dynamodbav:"SK"` // Sort key: ITEM#{item_id}
-	ItemID         string       `dynamodbav:"ItemID"`
-	TenantID       int64        `dynamodbav:"TenantID"`
-	Name           string       `dynamodbav:"Name"`
-	Description    string       `dynamodbav:"Description"`
-	Price          float64      `dynamodbav:"Price"`
-	Category       ItemCategory `dynamodbav:"Category"`
-	Status         ItemStatus   `dynamodbav:"Status"`
-	SKU            string       `dynamodbav:"SKU"`
-	InventoryCount int32        `dynamodbav:"InventoryCount"`
-	Tags           []string     `dynamodbav:"Tags,omitempty"`
-	CreatedAt      time.Time    `dynamodbav:"CreatedAt"`
-	UpdatedAt      time.Time    `dynamodbav:"UpdatedAt"`
-	CreatedBy      string       `dynamodbav:"CreatedBy"`
-	UpdatedBy      string       `dynamodbav:"UpdatedBy"`
+	PK                string       `dynamodbav:"PK"` // Partition key: TENANT#{tenant_id}
+	SK                string       `dynamodbav:"SK"` // Sort key: ITEM#{item_id}
+	ItemID            string       `dynamodbav:"ItemID"`
+	TenantID          int64        `dynamodbav:"TenantID"`
+	Name              string       `dynamodbav:"Name"`
+	Description       string       `dynamodbav:"Description"`
+	Price             float64      `dynamodbav:"Price"`
+	Category          ItemCategory `dynamodbav:"Category"`
+	Status            ItemStatus   `dynamodbav:"Status"`
+	SKU               string       `dynamodbav:"SKU"`
+	InventoryCount    int32        `dynamodbav:"InventoryCount"`
+	LowStockThreshold int32        `dynamodbav:"LowStockThreshold"` // 0 means use the tenant default
+	Tags              []string     `dynamodbav:"Tags,omitempty"`
+	CreatedAt         time.Time    `dynamodbav:"CreatedAt"`
+	UpdatedAt         time.Time    `dynamodbav:"UpdatedAt"`
+	CreatedBy         string       `dynamodbav:"CreatedBy"`
+	UpdatedBy         string       `dynamodbav:"UpdatedBy"`
+}
+
+// IsLowStock reports whether the item's inventory is at or below its effective
+// low stock threshold. The item's own threshold wins over the tenant default.
+func (i Item) IsLowStock(defaultThreshold int32) bool {
+	threshold := i.LowStockThreshold
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+	return i.InventoryCount <= threshold
 }
 
 // StoreInterface defines the interface for store operations
 type StoreInterface interface {
-	CreateItem(ctx context.Context, tenantID int64, name, description string, price float64, category ItemCategory, sku string, inventoryCount int32, tags []string, createdBy string) (Item, error)
+	CreateItem(ctx context.Context, tenantID int64, name, description string, price float64, category ItemCategory, sku string, inventoryCount, lowStockThreshold int32, tags []string, createdBy string) (Item, error)
 	GetItem(ctx context.Context, tenantID int64, itemID string) (Item, error)
-	UpdateItem(ctx context.Context, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount int32, tags []string, updatedBy string) (Item, error)
-	DeleteItem(ctx context.Context, tenantID int64, itemID string) error
+	UpdateItem(ctx context.Context, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount, lowStockThreshold int32, tags []string, updatedBy string) (Item, error)
+	DeleteItem(ctx context.Context, tenantID int64, itemID, deletedBy string) error
 	ListItems(ctx context.Context, tenantID int64, category ItemCategory, status ItemStatus, searchQuery string, pageSize int32, pageToken string) ([]Item, string, int32, error)
 	UpdateInventory(ctx context.Context, tenantID int64, itemID string, quantityChange int32, reason, updatedBy string) (Item, int32, error)
+	ListLowStockItems(ctx context.Context, tenantID int64, pageSize int32, pageToken string) ([]Item, string, error)
+	GetTenantConfig(ctx context.Context, tenantID int64) (TenantConfig, error)
+	UpdateTenantConfig(ctx context.Context, tenantID int64, defaultLowStockThreshold int32, updatedBy string) (TenantConfig, error)
+	SetTenantQuotas(ctx context.Context, tenantID int64, quotas TenantQuotas, updatedBy string) (TenantConfig, error)
+	GetTenantUsage(ctx context.Context, tenantID int64) (TenantUsage, error)
+	RecountItems(ctx context.Context, tenantID int64) (int64, int64, error)
+	ListAuditEvents(ctx context.Context, tenantID int64, filter AuditFilter, pageSize int32, pageToken string) ([]AuditEvent, string, error)
+	InventoryStats(ctx context.Context) ([]TenantInventory, error)
+	AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error)
 }
 
 // DynamoStore implements StoreInterface using DynamoDB
 type DynamoStore struct {
-	client    *dynamodb.Client
-	tableName string
+	client          *dynamodb.Client
+	tableName       string
+	canaryIsolation CanaryIsolation
+
+	canaryTables       sync.Map // canary tables known to be active
+	canaryTableCreates singleflight.Group
 }
 
-// NewDynamoStore creates a new DynamoDB store instance
-func NewDynamoStore(client *dynamodb.Client, tableName string) *DynamoStore {
+// NewDynamoStore creates a new DynamoDB store instance. canaryIsolation
+// selects where requests carrying a canary PR number keep their data.
+func NewDynamoStore(client *dynamodb.Client, tableName string, canaryIsolation CanaryIsolation) *DynamoStore {
 	return &DynamoStore{
-		client:    client,
-		tableName: tableName,
+		client:          client,
+		tableName:       tableName,
+		canaryIsolation: canaryIsolation,
 	}
 }
 
-// CreateItem creates a new store item
-func (s *DynamoStore) CreateItem(ctx context.Context, tenantID int64, name, description string, price float64, category ItemCategory, sku string, inventoryCount int32, tags []string, createdBy string) (Item, error) {
+// CreateItem creates a new store item. With an idempotency key in ctx, a
+// retry returns the item the first call created.
+func (s *DynamoStore) CreateItem(ctx context.Context, tenantID int64, name, description string, price float64, category ItemCategory, sku string, inventoryCount, lowStockThreshold int32, tags []string, createdBy string) (Item, error) {
 	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return Item{}, err
+	}
+
+	idempotency, keyed := IdempotencyFromContext(ctx)
+	if keyed {
+		record, found, err := s.storedResult(ctx, tenantID, AuditMethodCreateItem, idempotency)
+		if err != nil {
+			return Item{}, err
+		}
+		if found {
+			return record.Item, nil
+		}
+	}
+
+	config, err := s.GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return Item{}, err
+	}
+	if err := checkItemQuotas(config.TenantQuotas, description, tags); err != nil {
+		return Item{}, err
+	}
 
 	itemID := uuid.New().String()
 	now := time.Now()
 
+	// Items created without stock start out of stock
+	status := inventoryStatus(ItemStatusActive, inventoryCount)
+
 	item := Item{
-		PK:             fmt.Sprintf("TENANT#%d", tenantID),
-		SK:             fmt.Sprintf("ITEM#%s", itemID),
-		ItemID:         itemID,
-		TenantID:       tenantID,
-		Name:           name,
-		Description:    description,
-		Price:          price,
-		Category:       category,
-		Status:         ItemStatusActive,
-		SKU:            sku,
-		InventoryCount: inventoryCount,
-		Tags:           tags,
-		CreatedAt:      now,
-		UpdatedAt:      now,
-		CreatedBy:      createdBy,
-		UpdatedBy:      createdBy,
+		PK:                scope.tenantPK(tenantID),
+		SK:                fmt.Sprintf("ITEM#%s", itemID),
+		ItemID:            itemID,
+		TenantID:          tenantID,
+		Name:              name,
+		Description:       description,
+		Price:             price,
+		Category:          category,
+		Status:            status,
+		SKU:               sku,
+		InventoryCount:    inventoryCount,
+		LowStockThreshold: lowStockThreshold,
+		Tags:              tags,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		CreatedBy:         createdBy,
+		UpdatedBy:         createdBy,
 	}
 
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_name": name,
 		}).Error("Failed to marshal item")
 		return Item{}, fmt.Errorf("failed to marshal item: %w", err)
 	}
 
-	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(s.tableName),
-		Item:      av,
+	audit, err := s.auditPut(ctx, tenantID, itemID, AuditMethodCreateItem, createdBy, nil, &item, now)
+	if err != nil {
+		return Item{}, err
+	}
+
+	// Write the item, count it against the tenant's quotas and record it in
+	// the audit log atomically, along with the result for retries
+	transactItems := []types.TransactWriteItem{
+		{Put: &types.Put{
+			TableName:           aws.String(scope.table),
+			Item:                av,
+			ConditionExpression: aws.String("attribute_not_exists(PK)"),
+		}},
+		{Update: itemCountUpdate(scope, tenantID, 1, config.MaxItems)},
+		{Update: dailyWritesUpdate(scope, tenantID, now, config.MaxWritesPerDay)},
+		{Put: audit},
+	}
+	if keyed {
+		put, err := s.idempotencyPut(ctx, tenantID, AuditMethodCreateItem, idempotency, item, 0, now)
+		if err != nil {
+			return Item{}, err
+		}
+		transactItems = append(transactItems, types.TransactWriteItem{Put: put})
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
 	})
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		if keyed && conditionFailed(err, 4) {
+			// A concurrent retry with the same key won; return its result
+			record, found, lookupErr := s.storedResult(ctx, tenantID, AuditMethodCreateItem, idempotency)
+			if lookupErr != nil {
+				return Item{}, lookupErr
+			}
+			if found {
+				return record.Item, nil
+			}
+		}
+		if conditionFailed(err, 1) {
+			return Item{}, &QuotaExceededError{Quota: QuotaMaxItems, Limit: int64(config.MaxItems), Value: int64(config.MaxItems) + 1}
+		}
+		if conditionFailed(err, 2) {
+			return Item{}, &QuotaExceededError{Quota: QuotaMaxWritesPerDay, Limit: int64(config.MaxWritesPerDay), Value: int64(config.MaxWritesPerDay) + 1}
+		}
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_id":   itemID,
 		}).Error("Failed to put item")
 		return Item{}, fmt.Errorf("failed to put item: %w", err)
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id": tenantID,
 		"item_id":   itemID,
 		"duration":  time.Since(start),
@@ -145,16 +249,21 @@ func (s *DynamoStore) CreateItem(ctx context.Context, tenantID int64, name, desc
 // GetItem retrieves an item by ID
 func (s *DynamoStore) GetItem(ctx context.Context, tenantID int64, itemID string) (Item, error) {
 	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return Item{}, err
+	}
 
 	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
-		TableName: aws.String(s.tableName),
+		TableName: aws.String(scope.table),
 		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("TENANT#%d", tenantID)},
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
 			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ITEM#%s", itemID)},
 		},
+		ConsistentRead: aws.Bool(consistentRead(ctx)),
 	})
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_id":   itemID,
 		}).Error("Failed to get item")
@@ -162,7 +271,7 @@ func (s *DynamoStore) GetItem(ctx context.Context, tenantID int64, itemID string
 	}
 
 	if result.Item == nil {
-		logging.WithFields(logrus.Fields{
+		logging.FromContext(ctx).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_id":   itemID,
 		}).Warn("Item not found")
@@ -172,14 +281,14 @@ func (s *DynamoStore) GetItem(ctx context.Context, tenantID int64, itemID string
 	var item Item
 	err = attributevalue.UnmarshalMap(result.Item, &item)
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_id":   itemID,
 		}).Error("Failed to unmarshal item")
 		return Item{}, fmt.Errorf("failed to unmarshal item: %w", err)
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id": tenantID,
 		"item_id":   itemID,
 		"duration":  time.Since(start),
@@ -189,12 +298,94 @@ func (s *DynamoStore) GetItem(ctx context.Context, tenantID int64, itemID string
 }
 
 // UpdateItem updates an existing item
-func (s *DynamoStore) UpdateItem(ctx context.Context, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount int32, tags []string, updatedBy string) (Item, error) {
+func (s *DynamoStore) UpdateItem(ctx context.Context, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount, lowStockThreshold int32, tags []string, updatedBy string) (Item, error) {
 	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return Item{}, err
+	}
+
+	config, err := s.GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return Item{}, err
+	}
+	if err := checkItemQuotas(config.TenantQuotas, description, tags); err != nil {
+		return Item{}, err
+	}
+
+	// The write is conditioned on the stock and status it was computed from,
+	// so a concurrent UpdateInventory makes it read again instead of being
+	// overwritten
+	for attempt := 1; ; attempt++ {
+		err = s.applyItemUpdate(ctx, scope, config, tenantID, itemID, name, description, price, category, status, sku, inventoryCount, lowStockThreshold, tags, updatedBy)
+		if !errors.Is(err, ErrConcurrentUpdate) || attempt == maxUpdateAttempts {
+			break
+		}
+		logging.FromContext(ctx).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+			"item_id":   itemID,
+			"attempt":   attempt,
+		}).Debug("Item changed concurrently, retrying")
+	}
+	if err != nil {
+		return Item{}, err
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"item_id":   itemID,
+		"duration":  time.Since(start),
+	}).Info("Item updated successfully")
+
+	// Return the updated item
+	return s.GetItem(ctx, tenantID, itemID)
+}
+
+// applyItemUpdate writes the update to the item as read now. It fails with
+// ErrConcurrentUpdate if the item's stock or status changed after the read.
+func (s *DynamoStore) applyItemUpdate(ctx context.Context, scope storageScope, config TenantConfig, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount, lowStockThreshold int32, tags []string, updatedBy string) error {
 	now := time.Now()
 
+	// Read the current version for the audit diff
+	before, err := s.GetItem(WithConsistentRead(ctx), tenantID, itemID)
+	if err != nil {
+		return err
+	}
+
+	// A change of stock moves the item between ACTIVE and OUT_OF_STOCK as
+	// UpdateInventory does
+	if inventoryCount != before.InventoryCount {
+		status = inventoryStatus(status, inventoryCount)
+	}
+
+	after := before
+	after.Name = name
+	after.Description = description
+	after.Price = price
+	after.Category = category
+	after.Status = status
+	after.SKU = sku
+	after.InventoryCount = inventoryCount
+	after.LowStockThreshold = lowStockThreshold
+	after.Tags = tags
+
+	audit, err := s.auditPut(ctx, tenantID, itemID, AuditMethodUpdateItem, updatedBy, &before, &after, now)
+	if err != nil {
+		return err
+	}
+
+	// Discontinued items do not count against max_items, so moving an item
+	// out of or into that status takes or releases a slot
+	countDelta := 0
+	switch {
+	case before.Status == ItemStatusDiscontinued && status != ItemStatusDiscontinued:
+		countDelta = 1
+	case before.Status != ItemStatusDiscontinued && status == ItemStatusDiscontinued:
+		countDelta = -1
+	}
+
 	// Build update expression
-	updateExpr := "SET #name = :name, #desc = :desc, #price = :price, #category = :category, #status = :status, #sku = :sku, #inventory = :inventory, #tags = :tags, #updatedAt = :updatedAt, #updatedBy = :updatedBy"
+	updateExpr := "SET #name = :name, #desc = :desc, #price = :price, #category = :category, #status = :status, #sku = :sku, #inventory = :inventory, #threshold = :threshold, #tags = :tags, #updatedAt = :updatedAt, #updatedBy = :updatedBy"
 
 	exprAttrNames := map[string]string{
 		"#name":      "Name",
@@ -204,6 +395,7 @@ func (s *DynamoStore) UpdateItem(ctx context.Context, tenantID int64, itemID, na
 		"#status":    "Status",
 		"#sku":       "SKU",
 		"#inventory": "InventoryCount",
+		"#threshold": "LowStockThreshold",
 		"#tags":      "Tags",
 		"#updatedAt": "UpdatedAt",
 		"#updatedBy": "UpdatedBy",
@@ -217,6 +409,7 @@ func (s *DynamoStore) UpdateItem(ctx context.Context, tenantID int64, itemID, na
 		":status":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", int(status))},
 		":sku":       &types.AttributeValueMemberS{Value: sku},
 		":inventory": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", inventoryCount)},
+		":threshold": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", lowStockThreshold)},
 		":updatedAt": &types.AttributeValueMemberS{Value: now.Format(time.RFC3339)},
 		":updatedBy": &types.AttributeValueMemberS{Value: updatedBy},
 	}
@@ -231,64 +424,124 @@ func (s *DynamoStore) UpdateItem(ctx context.Context, tenantID int64, itemID, na
 	} else {
 		exprAttrValues[":tags"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{}}
 	}
-
-	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-		TableName: aws.String(s.tableName),
-		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("TENANT#%d", tenantID)},
-			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ITEM#%s", itemID)},
+	exprAttrValues[":prevInventory"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", before.InventoryCount)}
+	exprAttrValues[":prevStatus"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", int(before.Status))}
+
+	// Update the item, count the write against the tenant's quota and record
+	// it in the audit log atomically, along with any change to the item count
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Update: &types.Update{
+				TableName: aws.String(scope.table),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+					"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ITEM#%s", itemID)},
+				},
+				UpdateExpression:          aws.String(updateExpr),
+				ConditionExpression:       aws.String("attribute_exists(PK) AND #inventory = :prevInventory AND #status = :prevStatus"),
+				ExpressionAttributeNames:  exprAttrNames,
+				ExpressionAttributeValues: exprAttrValues,
+			}},
+			{Update: dailyWritesUpdate(scope, tenantID, now, config.MaxWritesPerDay)},
+			{Put: audit},
 		},
-		UpdateExpression:          aws.String(updateExpr),
-		ExpressionAttributeNames:  exprAttrNames,
-		ExpressionAttributeValues: exprAttrValues,
-	})
+	}
+	switch countDelta {
+	case 1:
+		input.TransactItems = append(input.TransactItems, types.TransactWriteItem{Update: itemCountUpdate(scope, tenantID, 1, config.MaxItems)})
+		_, err = s.client.TransactWriteItems(ctx, input)
+	case -1:
+		input.TransactItems = append(input.TransactItems, types.TransactWriteItem{Update: itemCountUpdate(scope, tenantID, -1, 0)})
+		err = s.transactReleasingItem(ctx, tenantID, input, len(input.TransactItems)-1)
+	default:
+		_, err = s.client.TransactWriteItems(ctx, input)
+	}
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		if conditionFailed(err, 0) {
+			// Items are never removed, so a failed condition means another
+			// call changed the stock or status since it was read
+			return ErrConcurrentUpdate
+		}
+		if conditionFailed(err, 1) {
+			return &QuotaExceededError{Quota: QuotaMaxWritesPerDay, Limit: int64(config.MaxWritesPerDay), Value: int64(config.MaxWritesPerDay) + 1}
+		}
+		if countDelta == 1 && conditionFailed(err, 3) {
+			return &QuotaExceededError{Quota: QuotaMaxItems, Limit: int64(config.MaxItems), Value: int64(config.MaxItems) + 1}
+		}
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_id":   itemID,
 		}).Error("Failed to update item")
-		return Item{}, fmt.Errorf("failed to update item: %w", err)
+		return fmt.Errorf("failed to update item: %w", err)
 	}
-
-	logging.WithFields(logrus.Fields{
-		"tenant_id": tenantID,
-		"item_id":   itemID,
-		"duration":  time.Since(start),
-	}).Info("Item updated successfully")
-
-	// Return the updated item
-	return s.GetItem(ctx, tenantID, itemID)
+	return nil
 }
 
-// DeleteItem soft-deletes an item by setting status to discontinued
-func (s *DynamoStore) DeleteItem(ctx context.Context, tenantID int64, itemID string) error {
+// DeleteItem soft-deletes an item by setting status to discontinued and
+// releases its slot in the tenant's item quota
+func (s *DynamoStore) DeleteItem(ctx context.Context, tenantID int64, itemID, deletedBy string) error {
 	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
 
-	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-		TableName: aws.String(s.tableName),
-		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("TENANT#%d", tenantID)},
-			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ITEM#%s", itemID)},
-		},
-		UpdateExpression: aws.String("SET #status = :status, #updatedAt = :updatedAt"),
-		ExpressionAttributeNames: map[string]string{
-			"#status":    "Status",
-			"#updatedAt": "UpdatedAt",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":status":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", int(ItemStatusDiscontinued))},
-			":updatedAt": &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+	before, err := s.GetItem(ctx, tenantID, itemID)
+	if err != nil {
+		return err
+	}
+	if before.Status == ItemStatusDiscontinued {
+		// Already deleted; deleting twice is not an error
+		return nil
+	}
+	after := before
+	after.Status = ItemStatusDiscontinued
+	after.UpdatedBy = deletedBy
+
+	audit, err := s.auditPut(ctx, tenantID, itemID, AuditMethodDeleteItem, deletedBy, &before, &after, now)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactReleasingItem(ctx, tenantID, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Update: &types.Update{
+				TableName: aws.String(scope.table),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+					"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ITEM#%s", itemID)},
+				},
+				UpdateExpression:    aws.String("SET #status = :status, #updatedAt = :updatedAt, #updatedBy = :updatedBy"),
+				ConditionExpression: aws.String("attribute_exists(PK) AND #status <> :status"),
+				ExpressionAttributeNames: map[string]string{
+					"#status":    "Status",
+					"#updatedAt": "UpdatedAt",
+					"#updatedBy": "UpdatedBy",
+				},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":status":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", int(ItemStatusDiscontinued))},
+					":updatedAt": &types.AttributeValueMemberS{Value: now.Format(time.RFC3339)},
+					":updatedBy": &types.AttributeValueMemberS{Value: deletedBy},
+				},
+			}},
+			{Update: itemCountUpdate(scope, tenantID, -1, 0)},
+			{Put: audit},
 		},
-	})
+	}, 1)
+	if conditionFailed(err, 0) {
+		// Deleted concurrently; deleting twice is not an error
+		return nil
+	}
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_id":   itemID,
 		}).Error("Failed to delete item")
 		return fmt.Errorf("failed to delete item: %w", err)
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id": tenantID,
 		"item_id":   itemID,
 		"duration":  time.Since(start),
@@ -300,13 +553,17 @@ func (s *DynamoStore) DeleteItem(ctx context.Context, tenantID int64, itemID str
 // ListItems lists items with filtering and pagination
 func (s *DynamoStore) ListItems(ctx context.Context, tenantID int64, category ItemCategory, status ItemStatus, searchQuery string, pageSize int32, pageToken string) ([]Item, string, int32, error) {
 	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
 
 	// This is a simplified implementation - in production you'd want proper GSI for filtering
 	input := &dynamodb.QueryInput{
-		TableName:              aws.String(s.tableName),
+		TableName:              aws.String(scope.table),
 		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :sk_prefix)"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":pk":        &types.AttributeValueMemberS{Value: fmt.Sprintf("TENANT#%d", tenantID)},
+			":pk":        &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
 			":sk_prefix": &types.AttributeValueMemberS{Value: "ITEM#"},
 		},
 		Limit: aws.Int32(pageSize),
@@ -315,14 +572,14 @@ func (s *DynamoStore) ListItems(ctx context.Context, tenantID int64, category It
 	if pageToken != "" {
 		// Simplified pagination - in production, you'd properly encode/decode the last evaluated key
 		input.ExclusiveStartKey = map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("TENANT#%d", tenantID)},
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
 			"SK": &types.AttributeValueMemberS{Value: pageToken},
 		}
 	}
 
 	result, err := s.client.Query(ctx, input)
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 		}).Error("Failed to list items")
 		return nil, "", 0, fmt.Errorf("failed to list items: %w", err)
@@ -333,7 +590,7 @@ func (s *DynamoStore) ListItems(ctx context.Context, tenantID int64, category It
 		var i Item
 		err := attributevalue.UnmarshalMap(item, &i)
 		if err != nil {
-			logging.WithError(err).Error("Failed to unmarshal item in list")
+			logging.FromContext(ctx).WithError(err).Error("Failed to unmarshal item in list")
 			continue
 		}
 
@@ -357,7 +614,7 @@ func (s *DynamoStore) ListItems(ctx context.Context, tenantID int64, category It
 		}
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id":   tenantID,
 		"items_count": len(items),
 		"duration":    time.Since(start),
@@ -366,58 +623,66 @@ func (s *DynamoStore) ListItems(ctx context.Context, tenantID int64, category It
 	return items, nextPageToken, int32(len(items)), nil
 }
 
-// UpdateInventory updates the inventory count for an item
+// UpdateInventory updates the inventory count for an item. With an
+// idempotency key in ctx, the change is applied once and retries return the
+// first call's result.
 func (s *DynamoStore) UpdateInventory(ctx context.Context, tenantID int64, itemID string, quantityChange int32, reason, updatedBy string) (Item, int32, error) {
 	start := time.Now()
-
-	// First get the current item to know the previous inventory count
-	currentItem, err := s.GetItem(ctx, tenantID, itemID)
+	scope, err := s.scope(ctx)
 	if err != nil {
 		return Item{}, 0, err
 	}
 
-	previousCount := currentItem.InventoryCount
-	newCount := previousCount + quantityChange
-
-	// Ensure inventory doesn't go negative
-	if newCount < 0 {
-		return Item{}, previousCount, fmt.Errorf("insufficient inventory: current=%d, requested_change=%d", previousCount, quantityChange)
+	idempotency, keyed := IdempotencyFromContext(ctx)
+	if keyed {
+		record, found, err := s.storedResult(ctx, tenantID, AuditMethodUpdateInventory, idempotency)
+		if err != nil {
+			return Item{}, 0, err
+		}
+		if found {
+			return record.Item, record.PreviousCount, nil
+		}
 	}
 
-	// Update the inventory count
-	now := time.Now()
-	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-		TableName: aws.String(s.tableName),
-		Key: map[string]types.AttributeValue{
-			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("TENANT#%d", tenantID)},
-			"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ITEM#%s", itemID)},
-		},
-		UpdateExpression: aws.String("SET #inventory = :inventory, #updatedAt = :updatedAt, #updatedBy = :updatedBy"),
-		ExpressionAttributeNames: map[string]string{
-			"#inventory": "InventoryCount",
-			"#updatedAt": "UpdatedAt",
-			"#updatedBy": "UpdatedBy",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":inventory": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", newCount)},
-			":updatedAt": &types.AttributeValueMemberS{Value: now.Format(time.RFC3339)},
-			":updatedBy": &types.AttributeValueMemberS{Value: updatedBy},
-		},
-	})
-	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+	// The write is conditioned on the count it was computed from, so a
+	// concurrent change makes it read again instead of being overwritten
+	var currentItem, after Item
+	for attempt := 1; ; attempt++ {
+		currentItem, after, err = s.adjustInventory(ctx, scope, tenantID, itemID, quantityChange, updatedBy)
+		if !errors.Is(err, ErrConcurrentUpdate) || attempt == maxUpdateAttempts {
+			break
+		}
+		logging.FromContext(ctx).WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 			"item_id":   itemID,
-		}).Error("Failed to update inventory")
-		return Item{}, previousCount, fmt.Errorf("failed to update inventory: %w", err)
+			"attempt":   attempt,
+		}).Debug("Inventory changed concurrently, retrying")
 	}
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		// A concurrent retry with the same key won; return its result
+		record, found, lookupErr := s.storedResult(ctx, tenantID, AuditMethodUpdateInventory, idempotency)
+		if lookupErr != nil {
+			return Item{}, currentItem.InventoryCount, lookupErr
+		}
+		if found {
+			return record.Item, record.PreviousCount, nil
+		}
+	}
+	if err != nil {
+		return Item{}, currentItem.InventoryCount, err
+	}
+	previousCount := currentItem.InventoryCount
+	newCount := after.InventoryCount
+	newStatus := after.Status
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id":       tenantID,
 		"item_id":         itemID,
 		"previous_count":  previousCount,
 		"quantity_change": quantityChange,
 		"new_count":       newCount,
+		"previous_status": currentItem.Status,
+		"new_status":      newStatus,
 		"reason":          reason,
 		"duration":        time.Since(start),
 	}).Info("Inventory updated successfully")
@@ -430,3 +695,199 @@ func (s *DynamoStore) UpdateInventory(ctx context.Context, tenantID int64, itemI
 
 	return updatedItem, previousCount, nil
 }
+
+// maxUpdateAttempts bounds how often UpdateItem and UpdateInventory re-read an
+// item whose stock or status changed between their read and their write
+const maxUpdateAttempts = 3
+
+// errIdempotencyKeyTaken is returned by adjustInventory when a concurrent
+// retry with the same idempotency key stored its result first
+var errIdempotencyKeyTaken = errors.New("idempotency key taken by a concurrent retry")
+
+// adjustInventory applies quantityChange to the item as read now, returning it
+// before and after. It fails with ErrConcurrentUpdate if the item changed
+// after the read.
+func (s *DynamoStore) adjustInventory(ctx context.Context, scope storageScope, tenantID int64, itemID string, quantityChange int32, updatedBy string) (Item, Item, error) {
+	idempotency, keyed := IdempotencyFromContext(ctx)
+
+	currentItem, err := s.GetItem(WithConsistentRead(ctx), tenantID, itemID)
+	if err != nil {
+		return Item{}, Item{}, err
+	}
+
+	previousCount := currentItem.InventoryCount
+	newCount := previousCount + quantityChange
+
+	// Ensure inventory doesn't go negative
+	if newCount < 0 {
+		return currentItem, Item{}, fmt.Errorf("%w: current=%d, requested_change=%d", ErrInsufficientInventory, previousCount, quantityChange)
+	}
+
+	newStatus := inventoryStatus(currentItem.Status, newCount)
+
+	now := time.Now()
+	after := currentItem
+	after.InventoryCount = newCount
+	after.Status = newStatus
+	after.UpdatedAt = now.Truncate(time.Second) // stored with second precision
+	after.UpdatedBy = updatedBy
+
+	audit, err := s.auditPut(ctx, tenantID, itemID, AuditMethodUpdateInventory, updatedBy, &currentItem, &after, now)
+	if err != nil {
+		return currentItem, Item{}, err
+	}
+
+	// Update the inventory count and any stock-driven status change, and
+	// record it in the audit log atomically, along with the result for retries
+	transactItems := []types.TransactWriteItem{
+		{Update: &types.Update{
+			TableName: aws.String(scope.table),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+				"SK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ITEM#%s", itemID)},
+			},
+			UpdateExpression:    aws.String("SET #inventory = :inventory, #status = :status, #updatedAt = :updatedAt, #updatedBy = :updatedBy"),
+			ConditionExpression: aws.String("attribute_exists(PK) AND #inventory = :prevInventory AND #status = :prevStatus"),
+			ExpressionAttributeNames: map[string]string{
+				"#inventory": "InventoryCount",
+				"#status":    "Status",
+				"#updatedAt": "UpdatedAt",
+				"#updatedBy": "UpdatedBy",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":inventory":     &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", newCount)},
+				":status":        &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", int(newStatus))},
+				":prevInventory": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", previousCount)},
+				":prevStatus":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", int(currentItem.Status))},
+				":updatedAt":     &types.AttributeValueMemberS{Value: now.Format(time.RFC3339)},
+				":updatedBy":     &types.AttributeValueMemberS{Value: updatedBy},
+			},
+		}},
+		{Put: audit},
+	}
+	if keyed {
+		put, err := s.idempotencyPut(ctx, tenantID, AuditMethodUpdateInventory, idempotency, after, previousCount, now)
+		if err != nil {
+			return currentItem, Item{}, err
+		}
+		transactItems = append(transactItems, types.TransactWriteItem{Put: put})
+	}
+
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		if keyed && conditionFailed(err, 2) {
+			return currentItem, Item{}, fmt.Errorf("%w: %w", errIdempotencyKeyTaken, err)
+		}
+		if conditionFailed(err, 0) {
+			return currentItem, Item{}, ErrConcurrentUpdate
+		}
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+			"item_id":   itemID,
+		}).Error("Failed to update inventory")
+		return currentItem, Item{}, fmt.Errorf("failed to update inventory: %w", err)
+	}
+
+	return currentItem, after, nil
+}
+
+// inventoryStatus returns the status an item should have after its inventory
+// changes to newCount. Only ACTIVE and OUT_OF_STOCK items move automatically;
+// INACTIVE and DISCONTINUED items keep their status regardless of stock.
+func inventoryStatus(current ItemStatus, newCount int32) ItemStatus {
+	switch {
+	case current == ItemStatusActive && newCount == 0:
+		return ItemStatusOutOfStock
+	case current == ItemStatusOutOfStock && newCount > 0:
+		return ItemStatusActive
+	default:
+		return current
+	}
+}
+
+// ListLowStockItems lists items whose inventory is at or below their effective
+// low stock threshold. Discontinued items are never reported.
+func (s *DynamoStore) ListLowStockItems(ctx context.Context, tenantID int64, pageSize int32, pageToken string) ([]Item, string, error) {
+	start := time.Now()
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	config, err := s.GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(scope.table),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :sk_prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":        &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			":sk_prefix": &types.AttributeValueMemberS{Value: "ITEM#"},
+		},
+		Limit: aws.Int32(pageSize),
+	}
+
+	// Low stock is filtered after the read, so keep reading until the page
+	// is full or the tenant's items run out
+	var items []Item
+	nextPageToken := pageToken
+	for {
+		if nextPageToken != "" {
+			input.ExclusiveStartKey = map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+				"SK": &types.AttributeValueMemberS{Value: nextPageToken},
+			}
+		}
+
+		result, err := s.client.Query(ctx, input)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"tenant_id": tenantID,
+			}).Error("Failed to list low stock items")
+			return nil, "", fmt.Errorf("failed to list low stock items: %w", err)
+		}
+
+		nextPageToken = ""
+		if sk, ok := result.LastEvaluatedKey["SK"].(*types.AttributeValueMemberS); ok {
+			nextPageToken = sk.Value
+		}
+
+		for n, item := range result.Items {
+			var i Item
+			err := attributevalue.UnmarshalMap(item, &i)
+			if err != nil {
+				logging.FromContext(ctx).WithError(err).Error("Failed to unmarshal item in low stock list")
+				continue
+			}
+
+			if i.Status == ItemStatusDiscontinued || !i.IsLowStock(config.DefaultLowStockThreshold) {
+				continue
+			}
+
+			items = append(items, i)
+			if len(items) == int(pageSize) {
+				// The next page starts after the last item returned
+				if n < len(result.Items)-1 {
+					nextPageToken = i.SK
+				}
+				break
+			}
+		}
+
+		if len(items) == int(pageSize) || nextPageToken == "" {
+			break
+		}
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":   tenantID,
+		"items_count": len(items),
+		"duration":    time.Since(start),
+	}).Debug("Low stock items listed successfully")
+
+	return items, nextPageToken, nil
+}
@@ -0,0 +1,240 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsLowStock(t *testing.T) {
+	tests := []struct {
+		name             string
+		inventory        int32
+		threshold        int32
+		defaultThreshold int32
+		want             bool
+	}{
+		{name: "at the item threshold", inventory: 5, threshold: 5, defaultThreshold: 1, want: true},
+		{name: "above the item threshold", inventory: 6, threshold: 5, defaultThreshold: 10, want: false},
+		{name: "tenant default applies without an item threshold", inventory: 8, defaultThreshold: 10, want: true},
+		{name: "no thresholds only flags empty stock", inventory: 1, want: false},
+		{name: "empty stock with no thresholds", inventory: 0, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := Item{InventoryCount: tt.inventory, LowStockThreshold: tt.threshold}
+			if got := item.IsLowStock(tt.defaultThreshold); got != tt.want {
+				t.Errorf("IsLowStock(%d) = %t, want %t", tt.defaultThreshold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryStatus(t *testing.T) {
+	tests := []struct {
+		current  ItemStatus
+		newCount int32
+		want     ItemStatus
+	}{
+		{current: ItemStatusActive, newCount: 0, want: ItemStatusOutOfStock},
+		{current: ItemStatusActive, newCount: 3, want: ItemStatusActive},
+		{current: ItemStatusOutOfStock, newCount: 3, want: ItemStatusActive},
+		{current: ItemStatusOutOfStock, newCount: 0, want: ItemStatusOutOfStock},
+		{current: ItemStatusInactive, newCount: 0, want: ItemStatusInactive},
+		{current: ItemStatusDiscontinued, newCount: 3, want: ItemStatusDiscontinued},
+	}
+
+	for _, tt := range tests {
+		if got := inventoryStatus(tt.current, tt.newCount); got != tt.want {
+			t.Errorf("inventoryStatus(%d, %d) = %d, want %d", tt.current, tt.newCount, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateInventoryConcurrency(t *testing.T) {
+	tests := []struct {
+		name             string
+		quantityChange   int32
+		script           []fakeTransaction
+		wantErr          error
+		wantTransactions int
+	}{
+		{
+			name:             "unchanged count is written once",
+			quantityChange:   -2,
+			wantTransactions: 1,
+		},
+		{
+			name:             "count changed since the read is read again",
+			quantityChange:   -2,
+			script:           []fakeTransaction{{cancel: cancelledAt(2, 0)}},
+			wantTransactions: 2,
+		},
+		{
+			name:           "count that keeps changing is reported",
+			quantityChange: -2,
+			script: []fakeTransaction{
+				{cancel: cancelledAt(2, 0)},
+				{cancel: cancelledAt(2, 0)},
+				{cancel: cancelledAt(2, 0)},
+			},
+			wantErr:          ErrConcurrentUpdate,
+			wantTransactions: maxUpdateAttempts,
+		},
+		{
+			name:           "insufficient stock is not written",
+			quantityChange: -6,
+			wantErr:        ErrInsufficientInventory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			fake.put(t, testItem(ItemStatusActive, 5))
+			fake.script = tt.script
+
+			_, previousCount, err := store.UpdateInventory(context.Background(), testTenantID, "widget", tt.quantityChange, "sale", "alice")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateInventory() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && previousCount != 5 {
+				t.Errorf("UpdateInventory() previous count = %d, want 5", previousCount)
+			}
+			if transactions := fake.calls("TransactWriteItems"); len(transactions) != tt.wantTransactions {
+				t.Errorf("TransactWriteItems calls = %d, want %d", len(transactions), tt.wantTransactions)
+			}
+			// Every attempt reads the count it conditions its write on
+			consistentReads := 0
+			for _, get := range fake.calls("GetItem") {
+				if get["ConsistentRead"] == true {
+					consistentReads++
+				}
+			}
+			if wantReads := max(tt.wantTransactions, 1); consistentReads != wantReads {
+				t.Errorf("consistent GetItem calls = %d, want %d", consistentReads, wantReads)
+			}
+		})
+	}
+}
+
+func TestUpdateItemConcurrency(t *testing.T) {
+	tests := []struct {
+		name             string
+		inventoryCount   int32
+		script           []fakeTransaction
+		wantErr          error
+		wantStatus       ItemStatus // status written by the last transaction
+		wantTransactions int
+	}{
+		{
+			name:             "stock change moves the status",
+			inventoryCount:   0,
+			wantStatus:       ItemStatusOutOfStock,
+			wantTransactions: 1,
+		},
+		{
+			name:             "stock changed since the read is read again",
+			inventoryCount:   0,
+			script:           []fakeTransaction{{cancel: cancelledAt(3, 0)}},
+			wantStatus:       ItemStatusOutOfStock,
+			wantTransactions: 2,
+		},
+		{
+			name:           "item that keeps changing is reported",
+			inventoryCount: 0,
+			script: []fakeTransaction{
+				{cancel: cancelledAt(3, 0)},
+				{cancel: cancelledAt(3, 0)},
+				{cancel: cancelledAt(3, 0)},
+			},
+			wantErr:          ErrConcurrentUpdate,
+			wantTransactions: maxUpdateAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeDynamoStore(t)
+			fake.put(t, testItem(ItemStatusActive, 5))
+			fake.script = tt.script
+
+			_, err := store.UpdateItem(context.Background(), testTenantID, "widget", "Widget", "", 9.99, ItemCategoryHome, ItemStatusActive, "", tt.inventoryCount, 0, nil, "alice")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateItem() error = %v, want %v", err, tt.wantErr)
+			}
+			transactions := fake.calls("TransactWriteItems")
+			if len(transactions) != tt.wantTransactions {
+				t.Fatalf("TransactWriteItems calls = %d, want %d", len(transactions), tt.wantTransactions)
+			}
+
+			// The item write is conditioned on the stock and status it read
+			last := transactions[len(transactions)-1]["TransactItems"].([]any)[0].(map[string]any)["Update"].(map[string]any)
+			values := last["ExpressionAttributeValues"].(map[string]any)
+			if condition := last["ConditionExpression"]; condition != "attribute_exists(PK) AND #inventory = :prevInventory AND #status = :prevStatus" {
+				t.Errorf("item condition = %v, want the stock and status read", condition)
+			}
+			if prev := values[":prevInventory"].(map[string]any)["N"]; prev != "5" {
+				t.Errorf(":prevInventory = %v, want 5", prev)
+			}
+			if tt.wantStatus != ItemStatusUnspecified {
+				if status := values[":status"].(map[string]any)["N"]; status != fmt.Sprint(int(tt.wantStatus)) {
+					t.Errorf(":status = %v, want %d", status, tt.wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestListLowStockItemsFillsPages(t *testing.T) {
+	store, fake := newFakeDynamoStore(t)
+	fake.put(t, TenantConfig{PK: "TENANT#1", SK: tenantConfigSK, TenantID: testTenantID, DefaultLowStockThreshold: 5})
+	for _, item := range []struct {
+		id        string
+		inventory int32
+		status    ItemStatus
+	}{
+		{"a", 50, ItemStatusActive},
+		{"b", 1, ItemStatusActive},
+		{"c", 50, ItemStatusActive},
+		{"d", 50, ItemStatusActive},
+		{"e", 2, ItemStatusActive},
+		{"f", 50, ItemStatusActive},
+		{"g", 0, ItemStatusOutOfStock},
+		{"h", 0, ItemStatusDiscontinued},
+	} {
+		fake.put(t, Item{PK: "TENANT#1", SK: "ITEM#" + item.id, ItemID: item.id, TenantID: testTenantID, InventoryCount: item.inventory, Status: item.status})
+	}
+
+	var pages [][]string
+	var tokens []string
+	token := ""
+	for {
+		items, next, err := store.ListLowStockItems(context.Background(), testTenantID, 2, token)
+		if err != nil {
+			t.Fatalf("ListLowStockItems() error = %v", err)
+		}
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.ItemID)
+		}
+		pages = append(pages, ids)
+		tokens = append(tokens, next)
+		if next == "" || len(pages) > 5 {
+			break
+		}
+		token = next
+	}
+
+	want := [][]string{{"b", "e"}, {"g"}}
+	if fmt.Sprint(pages) != fmt.Sprint(want) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint([]string{"ITEM#e", ""}) {
+		t.Errorf("page tokens = %q, want the last item of the full page, then none", tokens)
+	}
+}
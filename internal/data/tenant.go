@@ -0,0 +1,170 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+// tenantConfigSK is the sort key of the per-tenant settings record
+const tenantConfigSK = "CONFIG"
+
+// TenantConfig holds per-tenant settings, stored alongside the tenant's items
+type TenantConfig struct {
+	PK                       string    `dynamodbav:"PK"` // Partition key: TENANT#{tenant_id}
+	SK                       string    `dynamodbav:"SK"` // Sort key: CONFIG
+	TenantID                 int64     `dynamodbav:"TenantID"`
+	DefaultLowStockThreshold int32     `dynamodbav:"DefaultLowStockThreshold"`
+	UpdatedAt                time.Time `dynamodbav:"UpdatedAt"`
+	UpdatedBy                string    `dynamodbav:"UpdatedBy"`
+	TenantQuotas
+}
+
+// GetTenantConfig retrieves the settings for a tenant. Tenants without a stored
+// record get the zero-value defaults.
+func (s *DynamoStore) GetTenantConfig(ctx context.Context, tenantID int64) (TenantConfig, error) {
+	start := time.Now()
+	scope := s.productionScope()
+
+	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(scope.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			"SK": &types.AttributeValueMemberS{Value: tenantConfigSK},
+		},
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+		}).Error("Failed to get tenant config")
+		return TenantConfig{}, fmt.Errorf("failed to get tenant config: %w", err)
+	}
+
+	config := TenantConfig{
+		PK:       scope.tenantPK(tenantID),
+		SK:       tenantConfigSK,
+		TenantID: tenantID,
+	}
+	if result.Item != nil {
+		err = attributevalue.UnmarshalMap(result.Item, &config)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"tenant_id": tenantID,
+			}).Error("Failed to unmarshal tenant config")
+			return TenantConfig{}, fmt.Errorf("failed to unmarshal tenant config: %w", err)
+		}
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"duration":  time.Since(start),
+	}).Debug("Tenant config retrieved successfully")
+
+	return config, nil
+}
+
+// UpdateTenantConfig updates the settings for a tenant, creating the record if
+// needed. The tenant's quotas are left as they are.
+func (s *DynamoStore) UpdateTenantConfig(ctx context.Context, tenantID int64, defaultLowStockThreshold int32, updatedBy string) (TenantConfig, error) {
+	start := time.Now()
+
+	config, err := s.setTenantConfig(ctx, tenantID, map[string]types.AttributeValue{
+		"DefaultLowStockThreshold": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", defaultLowStockThreshold)},
+	}, updatedBy)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+		}).Error("Failed to update tenant config")
+		return TenantConfig{}, fmt.Errorf("failed to update tenant config: %w", err)
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":                   tenantID,
+		"default_low_stock_threshold": defaultLowStockThreshold,
+		"duration":                    time.Since(start),
+	}).Info("Tenant config updated successfully")
+
+	return config, nil
+}
+
+// SetTenantQuotas replaces the plan limits of a tenant, creating the record if
+// needed. The tenant's other settings are left as they are.
+func (s *DynamoStore) SetTenantQuotas(ctx context.Context, tenantID int64, quotas TenantQuotas, updatedBy string) (TenantConfig, error) {
+	start := time.Now()
+
+	config, err := s.setTenantConfig(ctx, tenantID, map[string]types.AttributeValue{
+		"MaxItems":             &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", quotas.MaxItems)},
+		"MaxWritesPerDay":      &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", quotas.MaxWritesPerDay)},
+		"MaxTagsPerItem":       &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", quotas.MaxTagsPerItem)},
+		"MaxDescriptionLength": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", quotas.MaxDescriptionLength)},
+	}, updatedBy)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+		}).Error("Failed to set tenant quotas")
+		return TenantConfig{}, fmt.Errorf("failed to set tenant quotas: %w", err)
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":              tenantID,
+		"max_items":              quotas.MaxItems,
+		"max_writes_per_day":     quotas.MaxWritesPerDay,
+		"max_tags_per_item":      quotas.MaxTagsPerItem,
+		"max_description_length": quotas.MaxDescriptionLength,
+		"duration":               time.Since(start),
+	}).Info("Tenant quotas set successfully")
+
+	return config, nil
+}
+
+// setTenantConfig sets the given attributes of a tenant's settings record,
+// along with who changed it and when, and returns the whole record
+func (s *DynamoStore) setTenantConfig(ctx context.Context, tenantID int64, attributes map[string]types.AttributeValue, updatedBy string) (TenantConfig, error) {
+	scope := s.productionScope()
+
+	attributes = maps.Clone(attributes)
+	attributes["TenantID"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", tenantID)}
+	attributes["UpdatedAt"] = &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)}
+	attributes["UpdatedBy"] = &types.AttributeValueMemberS{Value: updatedBy}
+
+	names := make(map[string]string, len(attributes))
+	values := make(map[string]types.AttributeValue, len(attributes))
+	sets := make([]string, 0, len(attributes))
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		names["#"+name] = name
+		values[":"+name] = attributes[name]
+		sets = append(sets, fmt.Sprintf("#%s = :%s", name, name))
+	}
+
+	result, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(scope.table),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: scope.tenantPK(tenantID)},
+			"SK": &types.AttributeValueMemberS{Value: tenantConfigSK},
+		},
+		UpdateExpression:          aws.String("SET " + strings.Join(sets, ", ")),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return TenantConfig{}, err
+	}
+
+	var config TenantConfig
+	if err := attributevalue.UnmarshalMap(result.Attributes, &config); err != nil {
+		return TenantConfig{}, fmt.Errorf("failed to unmarshal tenant config: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,144 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rinsecrm/store-service/internal/tracing"
+)
+
+// TracedStore wraps a StoreInterface with a span per call, tagged with the
+// tenant and item and marked failed when the store returns an error
+type TracedStore struct {
+	next StoreInterface
+}
+
+// NewTracedStore creates a tracing decorator around next
+func NewTracedStore(next StoreInterface) *TracedStore {
+	return &TracedStore{next: next}
+}
+
+func (s *TracedStore) start(ctx context.Context, name string, tenantID int64, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, tracing.TenantIDKey.Int64(tenantID))
+	return tracing.StartSpanWithOptions(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// end records err unless it is an expected outcome such as a missing item
+func end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, ErrItemNotFound) {
+		tracing.RecordError(span, err)
+	}
+	span.End()
+}
+
+func (s *TracedStore) CreateItem(ctx context.Context, tenantID int64, name, description string, price float64, category ItemCategory, sku string, inventoryCount, lowStockThreshold int32, tags []string, createdBy string) (Item, error) {
+	ctx, span := s.start(ctx, "data.create_item", tenantID)
+	item, err := s.next.CreateItem(ctx, tenantID, name, description, price, category, sku, inventoryCount, lowStockThreshold, tags, createdBy)
+	if err == nil {
+		span.SetAttributes(tracing.ItemIDKey.String(item.ItemID))
+	}
+	end(span, err)
+	return item, err
+}
+
+func (s *TracedStore) GetItem(ctx context.Context, tenantID int64, itemID string) (Item, error) {
+	ctx, span := s.start(ctx, "data.get_item", tenantID, tracing.ItemIDKey.String(itemID))
+	item, err := s.next.GetItem(ctx, tenantID, itemID)
+	end(span, err)
+	return item, err
+}
+
+func (s *TracedStore) UpdateItem(ctx context.Context, tenantID int64, itemID, name, description string, price float64, category ItemCategory, status ItemStatus, sku string, inventoryCount, lowStockThreshold int32, tags []string, updatedBy string) (Item, error) {
+	ctx, span := s.start(ctx, "data.update_item", tenantID, tracing.ItemIDKey.String(itemID))
+	item, err := s.next.UpdateItem(ctx, tenantID, itemID, name, description, price, category, status, sku, inventoryCount, lowStockThreshold, tags, updatedBy)
+	end(span, err)
+	return item, err
+}
+
+func (s *TracedStore) DeleteItem(ctx context.Context, tenantID int64, itemID, deletedBy string) error {
+	ctx, span := s.start(ctx, "data.delete_item", tenantID, tracing.ItemIDKey.String(itemID))
+	err := s.next.DeleteItem(ctx, tenantID, itemID, deletedBy)
+	end(span, err)
+	return err
+}
+
+func (s *TracedStore) ListItems(ctx context.Context, tenantID int64, category ItemCategory, status ItemStatus, searchQuery string, pageSize int32, pageToken string) ([]Item, string, int32, error) {
+	ctx, span := s.start(ctx, "data.list_items", tenantID)
+	items, nextPageToken, totalCount, err := s.next.ListItems(ctx, tenantID, category, status, searchQuery, pageSize, pageToken)
+	end(span, err)
+	return items, nextPageToken, totalCount, err
+}
+
+func (s *TracedStore) UpdateInventory(ctx context.Context, tenantID int64, itemID string, quantityChange int32, reason, updatedBy string) (Item, int32, error) {
+	ctx, span := s.start(ctx, "data.update_inventory", tenantID, tracing.ItemIDKey.String(itemID))
+	item, previousCount, err := s.next.UpdateInventory(ctx, tenantID, itemID, quantityChange, reason, updatedBy)
+	end(span, err)
+	return item, previousCount, err
+}
+
+func (s *TracedStore) ListLowStockItems(ctx context.Context, tenantID int64, pageSize int32, pageToken string) ([]Item, string, error) {
+	ctx, span := s.start(ctx, "data.list_low_stock_items", tenantID)
+	items, nextPageToken, err := s.next.ListLowStockItems(ctx, tenantID, pageSize, pageToken)
+	end(span, err)
+	return items, nextPageToken, err
+}
+
+func (s *TracedStore) GetTenantConfig(ctx context.Context, tenantID int64) (TenantConfig, error) {
+	ctx, span := s.start(ctx, "data.get_tenant_config", tenantID)
+	config, err := s.next.GetTenantConfig(ctx, tenantID)
+	end(span, err)
+	return config, err
+}
+
+func (s *TracedStore) UpdateTenantConfig(ctx context.Context, tenantID int64, defaultLowStockThreshold int32, updatedBy string) (TenantConfig, error) {
+	ctx, span := s.start(ctx, "data.update_tenant_config", tenantID)
+	config, err := s.next.UpdateTenantConfig(ctx, tenantID, defaultLowStockThreshold, updatedBy)
+	end(span, err)
+	return config, err
+}
+
+func (s *TracedStore) SetTenantQuotas(ctx context.Context, tenantID int64, quotas TenantQuotas, updatedBy string) (TenantConfig, error) {
+	ctx, span := s.start(ctx, "data.set_tenant_quotas", tenantID)
+	config, err := s.next.SetTenantQuotas(ctx, tenantID, quotas, updatedBy)
+	end(span, err)
+	return config, err
+}
+
+func (s *TracedStore) GetTenantUsage(ctx context.Context, tenantID int64) (TenantUsage, error) {
+	ctx, span := s.start(ctx, "data.get_tenant_usage", tenantID)
+	usage, err := s.next.GetTenantUsage(ctx, tenantID)
+	end(span, err)
+	return usage, err
+}
+
+func (s *TracedStore) RecountItems(ctx context.Context, tenantID int64) (int64, int64, error) {
+	ctx, span := s.start(ctx, "data.recount_items", tenantID)
+	previous, count, err := s.next.RecountItems(ctx, tenantID)
+	end(span, err)
+	return previous, count, err
+}
+
+func (s *TracedStore) ListAuditEvents(ctx context.Context, tenantID int64, filter AuditFilter, pageSize int32, pageToken string) ([]AuditEvent, string, error) {
+	ctx, span := s.start(ctx, "data.list_audit_events", tenantID)
+	events, nextPageToken, err := s.next.ListAuditEvents(ctx, tenantID, filter, pageSize, pageToken)
+	end(span, err)
+	return events, nextPageToken, err
+}
+
+func (s *TracedStore) InventoryStats(ctx context.Context) ([]TenantInventory, error) {
+	ctx, span := tracing.StartSpan(ctx, "data.inventory_stats")
+	stats, err := s.next.InventoryStats(ctx)
+	end(span, err)
+	return stats, err
+}
+
+func (s *TracedStore) AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error) {
+	ctx, span := tracing.StartSpan(ctx, "data.acquire_lease")
+	acquired, err := s.next.AcquireLease(ctx, name, holder, duration)
+	end(span, err)
+	return acquired, err
+}
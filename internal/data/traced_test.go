@@ -0,0 +1,65 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/rinsecrm/store-service/internal/tracing"
+)
+
+// getItemStore answers GetItem with err and leaves the rest unimplemented
+type getItemStore struct {
+	StoreInterface
+	err error
+}
+
+func (s getItemStore) GetItem(ctx context.Context, tenantID int64, itemID string) (Item, error) {
+	return Item{ItemID: itemID, TenantID: tenantID}, s.err
+}
+
+func TestTracedStoreSpans(t *testing.T) {
+	if err := tracing.Start(tracing.Config{ServiceName: "store-service-test", Exporter: tracing.ExporterMemory, SampleRatio: 1}); err != nil {
+		t.Fatalf("failed to start tracing: %v", err)
+	}
+	t.Cleanup(func() { tracing.Stop(context.Background()) })
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+	}{
+		{name: "success", wantStatus: codes.Unset},
+		{name: "missing item is not a failure", err: ErrItemNotFound, wantStatus: codes.Unset},
+		{name: "storage error marks the span failed", err: ErrStorageTimeout, wantStatus: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracing.ResetSpans()
+			store := NewTracedStore(getItemStore{err: tt.err})
+
+			if _, err := store.GetItem(context.Background(), 42, "widget"); !errors.Is(err, tt.err) {
+				t.Fatalf("GetItem() error = %v, want %v", err, tt.err)
+			}
+
+			spans := tracing.Spans()
+			if len(spans) != 1 || spans[0].Name != "data.get_item" {
+				t.Fatalf("spans = %+v, want one data.get_item span", spans)
+			}
+			span := spans[0]
+			if span.Status.Code != tt.wantStatus {
+				t.Errorf("span status = %s, want %s", span.Status.Code, tt.wantStatus)
+			}
+			attributes := map[string]any{}
+			for _, attr := range span.Attributes {
+				attributes[string(attr.Key)] = attr.Value.AsInterface()
+			}
+			if attributes[string(tracing.TenantIDKey)] != int64(42) || attributes[string(tracing.ItemIDKey)] != "widget" {
+				t.Errorf("span attributes = %v, want tenant 42 and item widget", attributes)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+	"github.com/rinsecrm/store-service/internal/server"
+	storeproto "github.com/rinsecrm/store-service/proto"
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// OpenAPIPath serves the OpenAPI v2 spec generated from store.proto
+const OpenAPIPath = "/openapi.json"
+
+// Gateway translates REST/JSON requests into calls on the gRPC server. Calls
+// go through the gRPC server so every server interceptor still applies.
+type Gateway struct {
+	conn    *grpc.ClientConn
+	handler http.Handler
+}
+
+// New creates a gateway that forwards to the gRPC server serving listener
+func New(ctx context.Context, listener *Listener) (*Gateway, error) {
+	conn, err := grpc.NewClient("passthrough:///gateway",
+		grpc.WithContextDialer(listener.dial),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gateway client: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+	if err := pb.RegisterStoreServiceHandler(ctx, mux, conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register gateway handlers: %w", err)
+	}
+
+	root := http.NewServeMux()
+	root.HandleFunc(OpenAPIPath, serveOpenAPISpec)
+	root.Handle("/", mux)
+
+	return &Gateway{conn: conn, handler: root}, nil
+}
+
+// Handler returns the HTTP handler serving the REST API and its OpenAPI spec
+func (g *Gateway) Handler() http.Handler {
+	return g.handler
+}
+
+// Close releases the gateway's connection to the gRPC server
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// headerMatcher forwards X-Canary as gRPC metadata so canaryctx sees REST
+// requests the same way as gRPC ones, and Idempotency-Key so REST clients can
+// retry mutations safely; other headers use the default rules
+func headerMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case canaryctx.CanaryHeader:
+		return canaryctx.CanaryHeader, true
+	case "Idempotency-Key":
+		return server.IdempotencyKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(storeproto.OpenAPISpec)
+}
@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// storeServer records the calls the gateway forwards
+type storeServer struct {
+	pb.UnimplementedStoreServiceServer
+	md   metadata.MD
+	peer *peer.Peer
+}
+
+func (s *storeServer) record(ctx context.Context) {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	s.peer, _ = peer.FromContext(ctx)
+}
+
+func (s *storeServer) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.GetItemResponse, error) {
+	s.record(ctx)
+	if req.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "item not found")
+	}
+	return &pb.GetItemResponse{Item: &pb.Item{Id: req.Id, TenantId: req.TenantId, Name: "Widget"}}, nil
+}
+
+func (s *storeServer) CreateItem(ctx context.Context, req *pb.CreateItemRequest) (*pb.CreateItemResponse, error) {
+	s.record(ctx)
+	return &pb.CreateItemResponse{Item: &pb.Item{Id: "new", TenantId: req.TenantId, Name: req.Name}}, nil
+}
+
+// gatewayResponse holds the fields of item responses and errors the tests check
+type gatewayResponse struct {
+	Item    itemJSON `json:"item"`
+	Message string   `json:"message"`
+}
+
+type itemJSON struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// newTestGateway serves srv on a gRPC server behind a gateway
+func newTestGateway(t *testing.T, srv pb.StoreServiceServer) *Gateway {
+	t.Helper()
+	listener := NewListener()
+	grpcServer := grpc.NewServer()
+	pb.RegisterStoreServiceServer(grpcServer, srv)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	gw, err := New(context.Background(), listener)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { gw.Close() })
+	return gw
+}
+
+func TestGateway(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		headers  map[string]string
+		wantCode int
+		wantBody gatewayResponse
+		wantMD   map[string]string
+	}{
+		{
+			name:     "get item",
+			method:   http.MethodGet,
+			path:     "/v1/tenants/1/items/widget",
+			headers:  map[string]string{"X-Canary": "7"},
+			wantCode: http.StatusOK,
+			wantBody: gatewayResponse{Item: itemJSON{ID: "widget", Name: "Widget"}},
+			wantMD:   map[string]string{"x-canary": "7"},
+		},
+		{
+			name:     "create item with an idempotency key",
+			method:   http.MethodPost,
+			path:     "/v1/tenants/1/items",
+			body:     `{"name": "Gadget", "unknown_field": true}`,
+			headers:  map[string]string{"Idempotency-Key": "key-1"},
+			wantCode: http.StatusOK,
+			wantBody: gatewayResponse{Item: itemJSON{ID: "new", Name: "Gadget"}},
+			wantMD:   map[string]string{"idempotency-key": "key-1"},
+		},
+		{
+			name:     "gRPC status maps to HTTP",
+			method:   http.MethodGet,
+			path:     "/v1/tenants/1/items/missing",
+			wantCode: http.StatusNotFound,
+			wantBody: gatewayResponse{Message: "item not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &storeServer{}
+			gw := newTestGateway(t, srv)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+
+			gw.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body)
+			}
+			var body gatewayResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %+v, want %+v", body, tt.wantBody)
+			}
+			for key, want := range tt.wantMD {
+				if got := srv.md.Get(key); len(got) != 1 || got[0] != want {
+					t.Errorf("metadata %s = %v, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	gw := newTestGateway(t, &storeServer{})
+	rec := httptest.NewRecorder()
+
+	gw.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("GET %s = %d %s, want 200 application/json", OpenAPIPath, rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var spec struct {
+		Paths map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec is not JSON: %v", err)
+	}
+	if _, ok := spec.Paths["/v1/tenants/{tenantId}/items/{id}"]; !ok {
+		t.Errorf("spec paths = %v, want the item path", spec.Paths)
+	}
+}
+
+func TestGatewayPeerAddress(t *testing.T) {
+	srv := &storeServer{}
+	gw := newTestGateway(t, srv)
+	req := httptest.NewRequest(http.MethodGet, "/v1/tenants/1/items/widget", nil)
+	req.RemoteAddr = "203.0.113.9:41000"
+	rec := httptest.NewRecorder()
+
+	gw.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	// The server can tell the call came from the gateway, so it may trust
+	// the client address the gateway forwards
+	if _, ok := srv.peer.Addr.(Addr); !ok {
+		t.Errorf("peer address = %T %v, want the gateway Addr", srv.peer.Addr, srv.peer.Addr)
+	}
+	if forwarded := srv.md.Get("x-forwarded-for"); len(forwarded) != 1 || forwarded[0] != "203.0.113.9" {
+		t.Errorf("x-forwarded-for = %v, want the HTTP client 203.0.113.9", forwarded)
+	}
+}
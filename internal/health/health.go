@@ -0,0 +1,231 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/rinsecrm/store-service/core/logging"
+)
+
+// CheckFunc reports whether a dependency is usable
+type CheckFunc func(ctx context.Context) error
+
+// Checker runs readiness checks periodically and publishes the result through
+// the standard gRPC health service and HTTP probe handlers
+type Checker struct {
+	server   *health.Server
+	services []string
+	interval time.Duration
+	timeout  time.Duration
+
+	mu           sync.RWMutex
+	checks       map[string]CheckFunc
+	statusChecks map[string]CheckFunc // by gRPC health service name
+	failures     map[string]string
+	ready        bool
+	shuttingDown bool
+}
+
+// NewChecker creates a checker that reports on the given gRPC service names in
+// addition to the overall server status (""). Services start NOT_SERVING.
+func NewChecker(interval, timeout time.Duration, services ...string) *Checker {
+	c := &Checker{
+		server:       health.NewServer(),
+		services:     append([]string{""}, services...),
+		interval:     interval,
+		timeout:      timeout,
+		checks:       make(map[string]CheckFunc),
+		statusChecks: make(map[string]CheckFunc),
+		failures:     make(map[string]string),
+	}
+	c.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Server returns the gRPC health service to register
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// AddCheck registers a named readiness check
+func (c *Checker) AddCheck(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// AddStatusCheck registers a check reported only as the serving status of its
+// own gRPC health service name. It does not affect readiness, so it suits
+// dependencies the server can partly work without.
+func (c *Checker) AddStatusCheck(service string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statusChecks[service] = check
+	c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Run checks readiness immediately and then every interval until ctx is done
+func (c *Checker) Run(ctx context.Context) {
+	c.checkAll(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkAll(ctx)
+		}
+	}
+}
+
+// Shutdown reports NOT_SERVING from now on, so load balancers drain the
+// instance before the server stops
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	c.shuttingDown = true
+	c.ready = false
+	c.mu.Unlock()
+
+	c.server.Shutdown()
+	logging.Info("Health status set to NOT_SERVING for shutdown")
+}
+
+// Ready reports whether every check passed on the last run
+func (c *Checker) Ready() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ready
+}
+
+func (c *Checker) checkAll(ctx context.Context) {
+	c.mu.RLock()
+	checks := maps.Clone(c.checks)
+	statusChecks := maps.Clone(c.statusChecks)
+	c.mu.RUnlock()
+
+	for service, check := range statusChecks {
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		err := check(checkCtx)
+		cancel()
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		c.server.SetServingStatus(service, status)
+	}
+
+	failures := make(map[string]string)
+	for name, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		err := check(checkCtx)
+		cancel()
+		if err != nil {
+			failures[name] = err.Error()
+		}
+	}
+
+	c.mu.Lock()
+	if c.shuttingDown {
+		c.mu.Unlock()
+		return
+	}
+	wasReady := c.ready
+	c.ready = len(failures) == 0
+	c.failures = failures
+	c.mu.Unlock()
+
+	if c.ready {
+		c.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+		if !wasReady {
+			logging.Info("Readiness checks passed, serving")
+		}
+		return
+	}
+
+	c.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	logging.WithFields(logrus.Fields{
+		"failures": failures,
+	}).Warn("Readiness checks failed, not serving")
+}
+
+func (c *Checker) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
+
+// LivenessHandler answers /healthz: the process is up and serving HTTP
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+}
+
+// ReadinessHandler answers /readyz: every readiness check passed and the
+// server is not shutting down
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.RLock()
+		ready, shuttingDown := c.ready, c.shuttingDown
+		var failures []string
+		for name, reason := range c.failures {
+			failures = append(failures, name+": "+reason)
+		}
+		c.mu.RUnlock()
+
+		if ready {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, "ok")
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		if shuttingDown {
+			fmt.Fprintln(w, "shutting down")
+			return
+		}
+		sort.Strings(failures)
+		if len(failures) == 0 {
+			fmt.Fprintln(w, "not ready")
+			return
+		}
+		fmt.Fprintln(w, strings.Join(failures, "\n"))
+	})
+}
+
+// DynamoTableCheck verifies that the table exists and is usable
+func DynamoTableCheck(client *dynamodb.Client, tableName string) CheckFunc {
+	return func(ctx context.Context) error {
+		result, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(tableName),
+		})
+		if err != nil {
+			return fmt.Errorf("describe table %s: %w", tableName, err)
+		}
+		if result.Table == nil {
+			return fmt.Errorf("table %s not found", tableName)
+		}
+		switch result.Table.TableStatus {
+		case types.TableStatusActive, types.TableStatusUpdating:
+			return nil
+		default:
+			return fmt.Errorf("table %s is %s", tableName, result.Table.TableStatus)
+		}
+	}
+}
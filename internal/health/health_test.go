@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const storeService = "store.v1.StoreService"
+
+// servingStatus returns what the gRPC health service reports for service
+func servingStatus(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q) error = %v", service, err)
+	}
+	return resp.Status
+}
+
+// readyz returns the status code and body of the readiness probe
+func readyz(c *Checker) (int, string) {
+	rec := httptest.NewRecorder()
+	c.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestReadiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]CheckFunc
+		wantStatus healthpb.HealthCheckResponse_ServingStatus
+		wantCode   int
+		wantBody   string
+	}{
+		{
+			name:       "every check passes",
+			checks:     map[string]CheckFunc{"dynamodb": func(context.Context) error { return nil }},
+			wantStatus: healthpb.HealthCheckResponse_SERVING,
+			wantCode:   http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name: "a check fails",
+			checks: map[string]CheckFunc{
+				"dynamodb": func(context.Context) error { return errors.New("table store is CREATING") },
+				"other":    func(context.Context) error { return nil },
+			},
+			wantStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+			wantCode:   http.StatusServiceUnavailable,
+			wantBody:   "dynamodb: table store is CREATING",
+		},
+		{
+			name: "a check outlives its timeout",
+			checks: map[string]CheckFunc{"dynamodb": func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+			wantStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+			wantCode:   http.StatusServiceUnavailable,
+			wantBody:   "dynamodb: context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(time.Minute, 10*time.Millisecond, storeService)
+			if status := servingStatus(t, c, ""); status != healthpb.HealthCheckResponse_NOT_SERVING {
+				t.Errorf("status before the first check = %s, want NOT_SERVING", status)
+			}
+			for name, check := range tt.checks {
+				c.AddCheck(name, check)
+			}
+
+			c.checkAll(context.Background())
+
+			for _, service := range []string{"", storeService} {
+				if status := servingStatus(t, c, service); status != tt.wantStatus {
+					t.Errorf("status of %q = %s, want %s", service, status, tt.wantStatus)
+				}
+			}
+			code, body := readyz(c)
+			if code != tt.wantCode || strings.TrimSpace(body) != tt.wantBody {
+				t.Errorf("/readyz = %d %q, want %d %q", code, body, tt.wantCode, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	c := NewChecker(time.Minute, time.Second, storeService)
+	c.AddCheck("dynamodb", func(context.Context) error { return nil })
+	c.checkAll(context.Background())
+
+	c.Shutdown()
+	// A check finishing after shutdown must not report ready again
+	c.checkAll(context.Background())
+
+	if c.Ready() {
+		t.Error("Ready() = true after shutdown")
+	}
+	if status := servingStatus(t, c, storeService); status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status after shutdown = %s, want NOT_SERVING", status)
+	}
+	if code, body := readyz(c); code != http.StatusServiceUnavailable || strings.TrimSpace(body) != "shutting down" {
+		t.Errorf("/readyz after shutdown = %d %q, want 503 shutting down", code, body)
+	}
+}
+
+func TestAddStatusCheck(t *testing.T) {
+	const storageService = "store.v1.StoreService.storage"
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "check passes", wantStatus: healthpb.HealthCheckResponse_SERVING},
+		{name: "check fails", err: errors.New("storage circuit breaker is open"), wantStatus: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(time.Minute, time.Second, storeService)
+			c.AddCheck("dynamodb", func(context.Context) error { return nil })
+			c.AddStatusCheck(storageService, func(context.Context) error { return tt.err })
+
+			c.checkAll(context.Background())
+
+			if status := servingStatus(t, c, storageService); status != tt.wantStatus {
+				t.Errorf("status of %q = %s, want %s", storageService, status, tt.wantStatus)
+			}
+			// Only its own service reflects it; readiness is unaffected
+			if !c.Ready() || servingStatus(t, c, storeService) != healthpb.HealthCheckResponse_SERVING {
+				t.Error("status check changed readiness")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rinsecrm/store-service/internal/data"
+)
+
+// Item cache metrics, read from the cache's own counters at scrape time. The
+// hit rate is hits / (hits + misses) of store_cache_requests_total.
+var (
+	cacheRequestsDesc = prometheus.NewDesc(
+		"store_cache_requests_total",
+		"Total number of GetItem calls seen by the item cache, by result (hit, miss or bypass)",
+		[]string{"result"}, nil,
+	)
+	cacheCoalescedDesc = prometheus.NewDesc(
+		"store_cache_coalesced_total",
+		"Total number of cache misses answered by a load another caller started",
+		nil, nil,
+	)
+	cacheEvictionsDesc = prometheus.NewDesc(
+		"store_cache_evictions_total",
+		"Total number of items evicted from the cache to stay within its size",
+		nil, nil,
+	)
+	cacheInvalidationsDesc = prometheus.NewDesc(
+		"store_cache_invalidations_total",
+		"Total number of items invalidated by writes",
+		nil, nil,
+	)
+	cacheEntriesDesc = prometheus.NewDesc(
+		"store_cache_entries",
+		"Number of items currently cached, including expired ones not yet removed",
+		nil, nil,
+	)
+)
+
+// RegisterCacheStats exposes the item cache's counters; call it once with
+// the cache's Stats method
+func RegisterCacheStats(stats func() data.CacheStats) {
+	prometheus.MustRegister(cacheCollector(stats))
+}
+
+type cacheCollector func() data.CacheStats
+
+func (c cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheRequestsDesc
+	ch <- cacheCoalescedDesc
+	ch <- cacheEvictionsDesc
+	ch <- cacheInvalidationsDesc
+	ch <- cacheEntriesDesc
+}
+
+func (c cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c()
+	ch <- prometheus.MustNewConstMetric(cacheRequestsDesc, prometheus.CounterValue, float64(stats.Hits), "hit")
+	ch <- prometheus.MustNewConstMetric(cacheRequestsDesc, prometheus.CounterValue, float64(stats.Misses), "miss")
+	ch <- prometheus.MustNewConstMetric(cacheRequestsDesc, prometheus.CounterValue, float64(stats.Bypasses), "bypass")
+	ch <- prometheus.MustNewConstMetric(cacheCoalescedDesc, prometheus.CounterValue, float64(stats.Coalesced))
+	ch <- prometheus.MustNewConstMetric(cacheEvictionsDesc, prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(cacheInvalidationsDesc, prometheus.CounterValue, float64(stats.Invalidations))
+	ch <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(stats.Entries))
+}
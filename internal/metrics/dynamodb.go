@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// InstrumentDynamoDB counts the capacity consumed by every DynamoDB operation.
+// Pass it to dynamodb.NewFromConfig. Operations that report consumed capacity
+// are asked for the TOTAL unless the caller already chose a level.
+func InstrumentDynamoDB(o *dynamodb.Options) {
+	o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DynamoDBCapacity", dynamoCapacity), middleware.After)
+	})
+}
+
+func dynamoCapacity(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	requestConsumedCapacity(in.Parameters)
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	operation := awsmiddleware.GetOperationName(ctx)
+	for _, c := range responseConsumedCapacity(out.Result) {
+		if c.TableName == nil || c.CapacityUnits == nil {
+			continue
+		}
+		RecordConsumedCapacity(*c.TableName, operation, *c.CapacityUnits)
+	}
+	return out, metadata, err
+}
+
+// requestConsumedCapacity asks for the total consumed capacity unless the
+// caller already chose a level
+func requestConsumedCapacity(params interface{}) {
+	var level *types.ReturnConsumedCapacity
+	switch in := params.(type) {
+	case *dynamodb.GetItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.PutItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.UpdateItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.DeleteItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.QueryInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.ScanInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.BatchGetItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.BatchWriteItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.TransactWriteItemsInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.TransactGetItemsInput:
+		level = &in.ReturnConsumedCapacity
+	}
+	if level != nil && *level == "" {
+		*level = types.ReturnConsumedCapacityTotal
+	}
+}
+
+// responseConsumedCapacity extracts the per-table consumed capacity from an
+// operation's output
+func responseConsumedCapacity(result interface{}) []types.ConsumedCapacity {
+	one := func(c *types.ConsumedCapacity) []types.ConsumedCapacity {
+		if c == nil {
+			return nil
+		}
+		return []types.ConsumedCapacity{*c}
+	}
+
+	switch out := result.(type) {
+	case *dynamodb.GetItemOutput:
+		return one(out.ConsumedCapacity)
+	case *dynamodb.PutItemOutput:
+		return one(out.ConsumedCapacity)
+	case *dynamodb.UpdateItemOutput:
+		return one(out.ConsumedCapacity)
+	case *dynamodb.DeleteItemOutput:
+		return one(out.ConsumedCapacity)
+	case *dynamodb.QueryOutput:
+		return one(out.ConsumedCapacity)
+	case *dynamodb.ScanOutput:
+		return one(out.ConsumedCapacity)
+	case *dynamodb.BatchGetItemOutput:
+		return out.ConsumedCapacity
+	case *dynamodb.BatchWriteItemOutput:
+		return out.ConsumedCapacity
+	case *dynamodb.TransactWriteItemsOutput:
+		return out.ConsumedCapacity
+	case *dynamodb.TransactGetItemsOutput:
+		return out.ConsumedCapacity
+	}
+	return nil
+}
@@ -1,15 +1,21 @@
 package metrics
 
 import (
+	"container/list"
 	"context"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
 )
 
 // gRPC server metrics
@@ -19,7 +25,7 @@ var (
 			Name: "grpc_server_calls_total",
 			Help: "Total number of gRPC server calls",
 		},
-		[]string{"service", "method", "status_code"},
+		[]string{"service", "method", "status_code", "canary"},
 	)
 
 	grpcServerCallDuration = prometheus.NewHistogramVec(
@@ -28,7 +34,7 @@ var (
 			Help:    "gRPC server call duration in seconds",
 			Buckets: prometheus.DefBuckets,
 		},
-		[]string{"service", "method"},
+		[]string{"service", "method", "canary"},
 	)
 
 	grpcServerCallsInFlight = prometheus.NewGauge(
@@ -38,13 +44,39 @@ var (
 		},
 	)
 
+	// gRPC server streaming metrics
+	grpcServerStreamMessagesReceived = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_stream_messages_received_total",
+			Help: "Total number of messages received on gRPC server streams",
+		},
+		[]string{"service", "method", "canary"},
+	)
+
+	grpcServerStreamMessagesSent = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_stream_messages_sent_total",
+			Help: "Total number of messages sent on gRPC server streams",
+		},
+		[]string{"service", "method", "canary"},
+	)
+
+	grpcServerStreamDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_server_stream_duration_seconds",
+			Help:    "gRPC server stream duration in seconds",
+			Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 900, 3600},
+		},
+		[]string{"service", "method", "canary"},
+	)
+
 	// Business metrics
 	storeOperationsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "store_operations_total",
-			Help: "Total number of store operations",
+			Help: "Total number of store operations by outcome (ok, not_found, invalid, conflict, exhausted, error)",
 		},
-		[]string{"operation"},
+		[]string{"operation", "outcome"},
 	)
 
 	storeOperationDuration = prometheus.NewHistogramVec(
@@ -53,16 +85,92 @@ var (
 			Help:    "Store operation duration in seconds",
 			Buckets: prometheus.DefBuckets,
 		},
-		[]string{"operation"},
+		[]string{"operation", "outcome"},
 	)
 
 	storeOperationErrors = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "store_operation_errors_total",
-			Help: "Total number of store operation errors",
+			Help: "Total number of store operations that failed with an internal error",
 		},
 		[]string{"operation"},
 	)
+
+	// Inventory metrics
+	inventoryUnitsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "inventory_units_total",
+			Help: "Total inventory units added or removed, by reason",
+		},
+		[]string{"direction", "reason"},
+	)
+
+	inventoryInsufficientStockTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "inventory_insufficient_stock_total",
+			Help: "Total number of inventory changes rejected because stock would go negative",
+		},
+		[]string{"reason"},
+	)
+
+	// DynamoDB metrics
+	dynamoConsumedCapacityTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dynamodb_consumed_capacity_units_total",
+			Help: "Total DynamoDB capacity units consumed, by table and operation",
+		},
+		[]string{"table", "operation"},
+	)
+
+	// Security metrics
+	authorizationDeniedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_authorization_denied_total",
+			Help: "Total number of gRPC calls denied by the authorization policy",
+		},
+		[]string{"service", "method", "canary"},
+	)
+
+	// Rate limiter metrics
+	rateLimitedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_rate_limited_total",
+			Help: "Total number of gRPC calls rejected by the rate limiter",
+		},
+		[]string{"service", "method", "canary"},
+	)
+
+	rateLimitActiveBuckets = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "rate_limit_active_buckets",
+			Help: "Current number of tenant/method token buckets held by the rate limiter",
+		},
+	)
+
+	rateLimitTokensRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rate_limit_tokens_remaining",
+			Help: "Tokens left in a tenant/method token bucket after its last call, for tenants with their own tenant label",
+		},
+		[]string{"tenant", "method"},
+	)
+
+	// Traffic mirroring metrics
+	mirrorRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirror_requests_total",
+			Help: "Total number of calls mirrored to the canary, by comparison result",
+		},
+		[]string{"service", "method", "result"},
+	)
+
+	mirrorFieldMismatchesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirror_field_mismatches_total",
+			Help: "Total number of response fields that differed between stable and canary",
+		},
+		[]string{"service", "method", "field"},
+	)
 )
 
 // init registers all metrics
@@ -70,9 +178,147 @@ func init() {
 	prometheus.MustRegister(grpcServerCallsTotal)
 	prometheus.MustRegister(grpcServerCallDuration)
 	prometheus.MustRegister(grpcServerCallsInFlight)
+	prometheus.MustRegister(grpcServerStreamMessagesReceived)
+	prometheus.MustRegister(grpcServerStreamMessagesSent)
+	prometheus.MustRegister(grpcServerStreamDuration)
 	prometheus.MustRegister(storeOperationsTotal)
 	prometheus.MustRegister(storeOperationDuration)
 	prometheus.MustRegister(storeOperationErrors)
+	prometheus.MustRegister(inventoryUnitsTotal)
+	prometheus.MustRegister(inventoryInsufficientStockTotal)
+	prometheus.MustRegister(dynamoConsumedCapacityTotal)
+	prometheus.MustRegister(authorizationDeniedTotal)
+	prometheus.MustRegister(rateLimitedTotal)
+	prometheus.MustRegister(rateLimitActiveBuckets)
+	prometheus.MustRegister(rateLimitTokensRemaining)
+	prometheus.MustRegister(mirrorRequestsTotal)
+	prometheus.MustRegister(mirrorFieldMismatchesTotal)
+}
+
+// labelOther replaces label values beyond a boundedLabels limit
+const labelOther = "other"
+
+// boundedLabels caps the distinct values of a label: the first max values
+// seen keep their own label value and any further ones become "other", so
+// caller-supplied values cannot blow up metric cardinality
+type boundedLabels struct {
+	mu   sync.Mutex
+	max  int
+	seen map[string]struct{}
+}
+
+func newBoundedLabels(max int) *boundedLabels {
+	return &boundedLabels{max: max, seen: make(map[string]struct{})}
+}
+
+func (b *boundedLabels) setMax(max int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.max = max
+}
+
+func (b *boundedLabels) value(v string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.seen[v]; ok {
+		return v
+	}
+	if len(b.seen) >= b.max {
+		return labelOther
+	}
+	b.seen[v] = struct{}{}
+	return v
+}
+
+// recentLabels caps the distinct values of a label like boundedLabels, but
+// keeps the most recently seen values: a new value past the limit replaces the
+// least recently seen one, whose series are deleted by evict
+type recentLabels struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // most recently seen first
+	seen  map[string]*list.Element
+	evict func(string)
+}
+
+func newRecentLabels(max int, evict func(string)) *recentLabels {
+	return &recentLabels{max: max, order: list.New(), seen: make(map[string]*list.Element), evict: evict}
+}
+
+func (r *recentLabels) setMax(max int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.max = max
+	for r.order.Len() > max {
+		r.evictOldest()
+	}
+}
+
+func (r *recentLabels) value(v string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.seen[v]; ok {
+		r.order.MoveToFront(e)
+		return v
+	}
+	if r.max <= 0 {
+		return labelOther
+	}
+	if r.order.Len() >= r.max {
+		r.evictOldest()
+	}
+	r.seen[v] = r.order.PushFront(v)
+	return v
+}
+
+func (r *recentLabels) evictOldest() {
+	oldest := r.order.Remove(r.order.Back()).(string)
+	delete(r.seen, oldest)
+	r.evict(oldest)
+}
+
+// canaryLabels bounds the canary label. Production traffic is labelled "" and
+// the most recently seen canaries by PR number; a new canary past the limit
+// takes over from the one seen least recently, whose series are dropped.
+var canaryLabels = newRecentLabels(20, deleteCanarySeries)
+
+// deleteCanarySeries drops every series labelled with canary
+func deleteCanarySeries(canary string) {
+	labels := prometheus.Labels{"canary": canary}
+	grpcServerCallsTotal.DeletePartialMatch(labels)
+	grpcServerCallDuration.DeletePartialMatch(labels)
+	grpcServerStreamMessagesReceived.DeletePartialMatch(labels)
+	grpcServerStreamMessagesSent.DeletePartialMatch(labels)
+	grpcServerStreamDuration.DeletePartialMatch(labels)
+	authorizationDeniedTotal.DeletePartialMatch(labels)
+	rateLimitedTotal.DeletePartialMatch(labels)
+}
+
+// inventoryReasons bounds the free-form reason label of inventory metrics
+var inventoryReasons = newBoundedLabels(50)
+
+// SetMaxCanaryLabels sets how many distinct canary PR numbers get their own label value
+func SetMaxCanaryLabels(max int) {
+	canaryLabels.setMax(max)
+}
+
+// CanaryLabel returns the canary label value for a request context
+func CanaryLabel(ctx context.Context) string {
+	canary, ok := canaryctx.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return canaryLabels.value(canary)
+}
+
+// SplitFullMethod extracts service and method names from a gRPC full method
+// name (format: /package.Service/Method)
+func SplitFullMethod(fullMethod string) (string, string) {
+	parts := strings.Split(fullMethod, "/")
+	if len(parts) >= 3 {
+		return parts[1], parts[2] // package.Service, Method
+	}
+	return "unknown", fullMethod
 }
 
 // UnaryServerInterceptor provides Prometheus metrics for gRPC unary calls
@@ -80,16 +326,8 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		start := time.Now()
 
-		// Extract service and method names from FullMethod (format: /package.Service/Method)
-		parts := strings.Split(info.FullMethod, "/")
-		var service, method string
-		if len(parts) >= 3 {
-			service = parts[1] // package.Service
-			method = parts[2]  // Method
-		} else {
-			service = "unknown"
-			method = info.FullMethod
-		}
+		service, method := SplitFullMethod(info.FullMethod)
+		canary := CanaryLabel(ctx)
 
 		// Increment in-flight calls counter
 		grpcServerCallsInFlight.Inc()
@@ -112,27 +350,192 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 		}
 
 		// Record metrics
-		grpcServerCallsTotal.WithLabelValues(service, method, code).Inc()
-		grpcServerCallDuration.WithLabelValues(service, method).Observe(durationSeconds)
+		grpcServerCallsTotal.WithLabelValues(service, method, code, canary).Inc()
+		observe(ctx, grpcServerCallDuration.WithLabelValues(service, method, canary), durationSeconds)
 
 		return resp, err
 	}
 }
 
+// StreamServerInterceptor provides Prometheus metrics for gRPC streaming
+// calls: messages sent and received, stream duration, and the final status
+// in grpc_server_calls_total
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		service, method := SplitFullMethod(info.FullMethod)
+		canary := CanaryLabel(ss.Context())
+
+		grpcServerCallsInFlight.Inc()
+		defer grpcServerCallsInFlight.Dec()
+
+		err := handler(srv, &monitoredServerStream{
+			ServerStream: ss,
+			received:     grpcServerStreamMessagesReceived.WithLabelValues(service, method, canary),
+			sent:         grpcServerStreamMessagesSent.WithLabelValues(service, method, canary),
+		})
+
+		code := "OK"
+		if err != nil {
+			st, _ := status.FromError(err)
+			code = st.Code().String()
+		}
+
+		grpcServerCallsTotal.WithLabelValues(service, method, code, canary).Inc()
+		observe(ss.Context(), grpcServerStreamDuration.WithLabelValues(service, method, canary), time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// monitoredServerStream counts messages that pass through a server stream
+type monitoredServerStream struct {
+	grpc.ServerStream
+	received prometheus.Counter
+	sent     prometheus.Counter
+}
+
+func (s *monitoredServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Inc()
+	}
+	return err
+}
+
+func (s *monitoredServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Inc()
+	}
+	return err
+}
+
+// Store operation outcomes
+const (
+	OutcomeOK       = "ok"
+	OutcomeNotFound = "not_found"
+	OutcomeInvalid  = "invalid"
+	OutcomeConflict = "conflict"
+	// OutcomeExhausted covers rate limiting, quota rejections and storage
+	// throttling, kept apart from conflicts so dashboards show throttling
+	OutcomeExhausted = "exhausted"
+	OutcomeError     = "error"
+)
+
+// Outcome classifies the gRPC status of a finished operation
+func Outcome(err error) string {
+	switch status.Code(err) {
+	case codes.OK:
+		return OutcomeOK
+	case codes.NotFound:
+		return OutcomeNotFound
+	case codes.InvalidArgument, codes.OutOfRange:
+		return OutcomeInvalid
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return OutcomeConflict
+	case codes.ResourceExhausted:
+		return OutcomeExhausted
+	default:
+		return OutcomeError
+	}
+}
+
 // Business metrics functions
-func RecordStoreOperation(operation string) {
-	storeOperationsTotal.WithLabelValues(operation).Inc()
+func RecordStoreOperation(ctx context.Context, operation, outcome string, duration time.Duration) {
+	storeOperationsTotal.WithLabelValues(operation, outcome).Inc()
+	observe(ctx, storeOperationDuration.WithLabelValues(operation, outcome), duration.Seconds())
+	if outcome == OutcomeError {
+		storeOperationErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// Inventory metrics functions
+func RecordInventoryChange(reason string, quantityChange int32) {
+	direction := "added"
+	units := float64(quantityChange)
+	if quantityChange < 0 {
+		direction = "removed"
+		units = -units
+	}
+	if units == 0 {
+		return
+	}
+	inventoryUnitsTotal.WithLabelValues(direction, inventoryReasonLabel(reason)).Add(units)
+}
+
+func RecordInsufficientStock(reason string) {
+	inventoryInsufficientStockTotal.WithLabelValues(inventoryReasonLabel(reason)).Inc()
+}
+
+func inventoryReasonLabel(reason string) string {
+	reason = strings.ToLower(strings.TrimSpace(reason))
+	if reason == "" {
+		return "unspecified"
+	}
+	return inventoryReasons.value(reason)
+}
+
+// DynamoDB metrics functions
+func RecordConsumedCapacity(table, operation string, units float64) {
+	dynamoConsumedCapacityTotal.WithLabelValues(table, operation).Add(units)
+}
+
+// Security metrics functions
+func RecordAuthorizationDenied(service, method, canary string) {
+	authorizationDeniedTotal.WithLabelValues(service, method, canary).Inc()
+}
+
+// Rate limiter metrics functions
+func RecordRateLimited(service, method, canary string) {
+	rateLimitedTotal.WithLabelValues(service, method, canary).Inc()
+}
+
+func SetRateLimitActiveBuckets(count int) {
+	rateLimitActiveBuckets.Set(float64(count))
 }
 
-func RecordStoreOperationDuration(operation string, duration float64) {
-	storeOperationDuration.WithLabelValues(operation).Observe(duration)
+// SetRateLimitTokensRemaining reports a bucket's level. The tenant label is
+// bounded like the other tenant metrics, but bucket levels cannot be summed
+// into "other", so tenants without their own label are not reported.
+func SetRateLimitTokensRemaining(tenant, method string, tokens float64) {
+	if tenantLabels.value(tenant) != tenant {
+		return
+	}
+	rateLimitTokensRemaining.WithLabelValues(tenant, method).Set(tokens)
+}
+
+func DeleteRateLimitBucket(tenant, method string) {
+	rateLimitTokensRemaining.DeleteLabelValues(tenant, method)
+}
+
+// Traffic mirroring metrics functions
+func RecordMirrorResult(service, method, result string) {
+	mirrorRequestsTotal.WithLabelValues(service, method, result).Inc()
 }
 
-func RecordStoreOperationError(operation string) {
-	storeOperationErrors.WithLabelValues(operation).Inc()
+func RecordMirrorFieldMismatch(service, method, field string) {
+	mirrorFieldMismatchesTotal.WithLabelValues(service, method, field).Inc()
 }
 
-// MetricsHandler returns the Prometheus metrics handler
+// MetricsHandler returns the Prometheus metrics handler. OpenMetrics is
+// offered so scrapers that ask for it receive histogram exemplars.
 func MetricsHandler() http.Handler {
-	return promhttp.Handler()
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		}),
+	)
+}
+
+// observe records a histogram sample, attaching the trace ID of a sampled
+// span as an exemplar so dashboards can jump from a latency bucket to a trace
+func observe(ctx context.Context, o prometheus.Observer, value float64) {
+	sc := trace.SpanContextFromContext(ctx)
+	if eo, ok := o.(prometheus.ExemplarObserver); ok && sc.IsSampled() {
+		eo.ObserveWithExemplar(value, prometheus.Labels{"trace_id": sc.TraceID().String()})
+		return
+	}
+	o.Observe(value)
 }
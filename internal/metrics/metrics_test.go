@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+)
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "success", want: OutcomeOK},
+		{name: "missing item", err: status.Error(codes.NotFound, "item not found"), want: OutcomeNotFound},
+		{name: "invalid request", err: status.Error(codes.InvalidArgument, "bad price"), want: OutcomeInvalid},
+		{name: "concurrent update", err: status.Error(codes.Aborted, "concurrent update"), want: OutcomeConflict},
+		{name: "quota exceeded is throttling, not a conflict", err: status.Error(codes.ResourceExhausted, "quota exceeded"), want: OutcomeExhausted},
+		{name: "storage failure", err: status.Error(codes.Internal, "storage failed"), want: OutcomeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Outcome(tt.err); got != tt.want {
+				t.Errorf("Outcome(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecentLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		max         int
+		values      []string
+		want        []string
+		wantEvicted []string
+	}{
+		{
+			name:   "values within the limit keep their label",
+			max:    2,
+			values: []string{"1", "2", "1"},
+			want:   []string{"1", "2", "1"},
+		},
+		{
+			name:        "new value replaces the least recently seen",
+			max:         2,
+			values:      []string{"1", "2", "1", "3", "1"},
+			want:        []string{"1", "2", "1", "3", "1"},
+			wantEvicted: []string{"2"},
+		},
+		{
+			name:        "evicted value is labelled again when it returns",
+			max:         1,
+			values:      []string{"1", "2", "1"},
+			want:        []string{"1", "2", "1"},
+			wantEvicted: []string{"1", "2"},
+		},
+		{
+			name:   "zero limit groups everything",
+			max:    0,
+			values: []string{"1"},
+			want:   []string{labelOther},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var evicted []string
+			labels := newRecentLabels(tt.max, func(v string) { evicted = append(evicted, v) })
+
+			var got []string
+			for _, v := range tt.values {
+				got = append(got, labels.value(v))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(evicted, tt.wantEvicted) {
+				t.Errorf("evicted = %v, want %v", evicted, tt.wantEvicted)
+			}
+		})
+	}
+}
+
+func TestRecentLabelsShrink(t *testing.T) {
+	var evicted []string
+	labels := newRecentLabels(3, func(v string) { evicted = append(evicted, v) })
+	for _, v := range []string{"1", "2", "3"} {
+		labels.value(v)
+	}
+
+	labels.setMax(1)
+	if !slices.Equal(evicted, []string{"1", "2"}) {
+		t.Errorf("evicted = %v, want the two least recently seen", evicted)
+	}
+}
+
+func TestCanaryLabelDropsEvictedSeries(t *testing.T) {
+	previous := canaryLabels
+	canaryLabels = newRecentLabels(1, deleteCanarySeries)
+	t.Cleanup(func() { canaryLabels = previous })
+	grpcServerCallsTotal.Reset()
+
+	for _, canary := range []string{"101", "102"} {
+		label := CanaryLabel(canaryctx.WithCanary(context.Background(), canary))
+		if label != canary {
+			t.Fatalf("CanaryLabel(%s) = %q, want its own label", canary, label)
+		}
+		grpcServerCallsTotal.WithLabelValues("store.v1.StoreService", "GetItem", "OK", label).Inc()
+	}
+
+	if series := testutil.CollectAndCount(grpcServerCallsTotal); series != 1 {
+		t.Errorf("grpc_server_calls_total series = %d, want 1 after the first canary was evicted", series)
+	}
+	if count := testutil.ToFloat64(grpcServerCallsTotal.WithLabelValues("store.v1.StoreService", "GetItem", "OK", "102")); count != 1 {
+		t.Errorf("calls for canary 102 = %v, want 1", count)
+	}
+}
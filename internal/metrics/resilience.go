@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rinsecrm/store-service/internal/data"
+)
+
+// Store resilience metrics, read from the resilience decorator at scrape time
+var (
+	breakerStateDesc = prometheus.NewDesc(
+		"store_circuit_breaker_state",
+		"Storage circuit breaker state: 1 for the current state (closed, half_open or open), 0 otherwise",
+		[]string{"state"}, nil,
+	)
+	breakerTripsDesc = prometheus.NewDesc(
+		"store_circuit_breaker_trips_total",
+		"Total number of times the storage circuit breaker opened",
+		nil, nil,
+	)
+	breakerRejectedDesc = prometheus.NewDesc(
+		"store_circuit_breaker_rejected_total",
+		"Total number of store operations failed fast with UNAVAILABLE by the open circuit breaker",
+		nil, nil,
+	)
+	storeRetriesDesc = prometheus.NewDesc(
+		"store_retries_total",
+		"Total number of store operation attempts retried after a throttling, transient or timeout error",
+		nil, nil,
+	)
+	storeTimeoutsDesc = prometheus.NewDesc(
+		"store_timeouts_total",
+		"Total number of store operation attempts that exceeded their timeout",
+		nil, nil,
+	)
+)
+
+var breakerStates = []data.BreakerState{data.BreakerClosed, data.BreakerHalfOpen, data.BreakerOpen}
+
+// RegisterResilienceStats exposes the circuit breaker state and retry
+// counters; call it once with the resilience decorator's Stats method
+func RegisterResilienceStats(stats func() data.ResilienceStats) {
+	prometheus.MustRegister(resilienceCollector(stats))
+}
+
+type resilienceCollector func() data.ResilienceStats
+
+func (c resilienceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- breakerStateDesc
+	ch <- breakerTripsDesc
+	ch <- breakerRejectedDesc
+	ch <- storeRetriesDesc
+	ch <- storeTimeoutsDesc
+}
+
+func (c resilienceCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c()
+	for _, state := range breakerStates {
+		value := 0.0
+		if stats.State == state {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(breakerStateDesc, prometheus.GaugeValue, value, state.String())
+	}
+	ch <- prometheus.MustNewConstMetric(breakerTripsDesc, prometheus.CounterValue, float64(stats.Trips))
+	ch <- prometheus.MustNewConstMetric(breakerRejectedDesc, prometheus.CounterValue, float64(stats.Rejected))
+	ch <- prometheus.MustNewConstMetric(storeRetriesDesc, prometheus.CounterValue, float64(stats.Retries))
+	ch <- prometheus.MustNewConstMetric(storeTimeoutsDesc, prometheus.CounterValue, float64(stats.Timeouts))
+}
@@ -0,0 +1,271 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/data"
+)
+
+// Tenant metrics. The tenant label is bounded by tenantLabels: only
+// allowlisted or top-volume tenants get their own value, the rest are "other".
+var (
+	tenantRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tenant_requests_total",
+			Help: "Total number of store operations per tenant",
+		},
+		[]string{"tenant", "operation"},
+	)
+
+	tenantRequestErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tenant_request_errors_total",
+			Help: "Total number of store operations per tenant that failed with an internal error",
+		},
+		[]string{"tenant", "operation"},
+	)
+
+	tenantRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "tenant_request_duration_seconds",
+			Help:    "Store operation duration per tenant in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"tenant"},
+	)
+
+	tenantItems = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tenant_items",
+			Help: "Number of live catalog items per tenant, refreshed periodically",
+		},
+		[]string{"tenant"},
+	)
+
+	tenantStockValue = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tenant_stock_value",
+			Help: "Total stock value (price * inventory count) per tenant, refreshed periodically",
+		},
+		[]string{"tenant"},
+	)
+
+	tenantInventoryCollected = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "tenant_inventory_last_collected_timestamp_seconds",
+			Help: "Unix time of the last successful tenant inventory collection",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(tenantRequestsTotal)
+	prometheus.MustRegister(tenantRequestErrorsTotal)
+	prometheus.MustRegister(tenantRequestDuration)
+	prometheus.MustRegister(tenantItems)
+	prometheus.MustRegister(tenantStockValue)
+	prometheus.MustRegister(tenantInventoryCollected)
+}
+
+// tenantLabeler picks the tenant label value. With an allowlist only those
+// tenants are labelled. Otherwise the busiest topN tenants are: request
+// counts are ranked every interval, and tenants that drop out of the top
+// have their series deleted so the label set stays bounded.
+type tenantLabeler struct {
+	mu        sync.Mutex
+	topN      int
+	interval  time.Duration
+	allowlist map[string]struct{}
+	counts    map[string]uint64
+	top       map[string]struct{}
+	ranked    time.Time
+}
+
+var tenantLabels = &tenantLabeler{
+	topN:     20,
+	interval: time.Minute,
+	counts:   make(map[string]uint64),
+	top:      make(map[string]struct{}),
+}
+
+// SetTenantLabels configures which tenants get their own tenant label value:
+// every tenant in allowlist if it is non-empty, otherwise the topN busiest
+// tenants, re-ranked every interval
+func SetTenantLabels(topN int, interval time.Duration, allowlist []string) {
+	tenantLabels.mu.Lock()
+	defer tenantLabels.mu.Unlock()
+	tenantLabels.topN = topN
+	tenantLabels.interval = interval
+	tenantLabels.allowlist = nil
+	if len(allowlist) > 0 {
+		tenantLabels.allowlist = make(map[string]struct{}, len(allowlist))
+		for _, tenant := range allowlist {
+			tenantLabels.allowlist[tenant] = struct{}{}
+		}
+	}
+}
+
+// request counts a request for tenant and returns its label value
+func (l *tenantLabeler) request(tenant string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.allowlist != nil {
+		return l.lookup(tenant)
+	}
+
+	l.counts[tenant]++
+	if time.Since(l.ranked) >= l.interval {
+		l.rank()
+	}
+	if _, ok := l.top[tenant]; !ok && len(l.top) < l.topN {
+		// Until the top is full, tenants are admitted as they appear
+		l.top[tenant] = struct{}{}
+	}
+	return l.lookup(tenant)
+}
+
+// value returns tenant's label value without counting a request
+func (l *tenantLabeler) value(tenant string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lookup(tenant)
+}
+
+func (l *tenantLabeler) lookup(tenant string) string {
+	set := l.top
+	if l.allowlist != nil {
+		set = l.allowlist
+	}
+	if _, ok := set[tenant]; ok {
+		return tenant
+	}
+	return labelOther
+}
+
+// rank replaces the top set with the busiest tenants since the last ranking
+// and drops the series of tenants that fell out
+func (l *tenantLabeler) rank() {
+	tenants := make([]string, 0, len(l.counts))
+	for tenant := range l.counts {
+		tenants = append(tenants, tenant)
+	}
+	sort.Slice(tenants, func(i, j int) bool {
+		if l.counts[tenants[i]] != l.counts[tenants[j]] {
+			return l.counts[tenants[i]] > l.counts[tenants[j]]
+		}
+		return tenants[i] < tenants[j]
+	})
+
+	top := make(map[string]struct{}, l.topN)
+	for _, tenant := range tenants[:min(l.topN, len(tenants))] {
+		top[tenant] = struct{}{}
+	}
+	for tenant := range l.top {
+		if _, ok := top[tenant]; !ok {
+			deleteTenantSeries(tenant)
+		}
+	}
+
+	l.top = top
+	l.counts = make(map[string]uint64, len(top))
+	l.ranked = time.Now()
+}
+
+func deleteTenantSeries(tenant string) {
+	labels := prometheus.Labels{"tenant": tenant}
+	tenantRequestsTotal.DeletePartialMatch(labels)
+	tenantRequestErrorsTotal.DeletePartialMatch(labels)
+	tenantRequestDuration.DeletePartialMatch(labels)
+	tenantItems.DeletePartialMatch(labels)
+	tenantStockValue.DeletePartialMatch(labels)
+	rateLimitTokensRemaining.DeletePartialMatch(labels)
+}
+
+// RecordTenantOperation records a finished store operation against the
+// tenant it was made for. Requests without a valid tenant are not recorded.
+func RecordTenantOperation(ctx context.Context, tenantID int64, operation, outcome string, duration time.Duration) {
+	if tenantID <= 0 {
+		return
+	}
+	tenant := tenantLabels.request(strconv.FormatInt(tenantID, 10))
+	tenantRequestsTotal.WithLabelValues(tenant, operation).Inc()
+	observe(ctx, tenantRequestDuration.WithLabelValues(tenant), duration.Seconds())
+	if outcome == OutcomeError {
+		tenantRequestErrorsTotal.WithLabelValues(tenant, operation).Inc()
+	}
+}
+
+// inventoryLease is the lease held by the replica collecting tenant inventory
+const inventoryLease = "inventory_stats"
+
+// InventorySource scans tenant inventory, once per interval across replicas
+type InventorySource interface {
+	InventoryStats(ctx context.Context) ([]data.TenantInventory, error)
+	AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error)
+}
+
+// RunInventoryCollector refreshes the tenant_items and tenant_stock_value
+// gauges from source immediately and then every interval until ctx is done.
+// Only the replica holding the inventory lease as holder scans the table;
+// the others export no tenant inventory gauges. Scrapes read the last
+// collected values and never touch DynamoDB.
+func RunInventoryCollector(ctx context.Context, interval time.Duration, source InventorySource, holder string) {
+	collectInventory(ctx, interval, source, holder)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			collectInventory(ctx, interval, source, holder)
+		}
+	}
+}
+
+func collectInventory(ctx context.Context, interval time.Duration, source InventorySource, holder string) {
+	// The lease outlives one missed renewal, so a slow scan keeps it
+	leader, err := source.AcquireLease(ctx, inventoryLease, holder, 2*interval)
+	if err != nil {
+		logging.WithError(err).Warn("Failed to acquire the tenant inventory lease")
+		return
+	}
+	if !leader {
+		// Another replica collects; drop values left from holding the lease
+		tenantItems.Reset()
+		tenantStockValue.Reset()
+		return
+	}
+
+	inventory, err := source.InventoryStats(ctx)
+	if err != nil {
+		// Keep the previous values; the timestamp gauge shows they are stale
+		logging.WithError(err).Warn("Failed to collect tenant inventory metrics")
+		return
+	}
+
+	items := make(map[string]float64)
+	value := make(map[string]float64)
+	for _, tenant := range inventory {
+		label := tenantLabels.value(strconv.FormatInt(tenant.TenantID, 10))
+		items[label] += float64(tenant.ItemCount)
+		value[label] += tenant.StockValue
+	}
+
+	tenantItems.Reset()
+	tenantStockValue.Reset()
+	for label := range items {
+		tenantItems.WithLabelValues(label).Set(items[label])
+		tenantStockValue.WithLabelValues(label).Set(value[label])
+	}
+	tenantInventoryCollected.SetToCurrentTime()
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/rinsecrm/store-service/internal/data"
+)
+
+// inventorySource reports stats while it holds the lease
+type inventorySource struct {
+	leader   bool
+	leaseErr error
+	stats    []data.TenantInventory
+	scans    int
+}
+
+func (s *inventorySource) InventoryStats(ctx context.Context) ([]data.TenantInventory, error) {
+	s.scans++
+	return s.stats, nil
+}
+
+func (s *inventorySource) AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error) {
+	return s.leader, s.leaseErr
+}
+
+func TestCollectInventory(t *testing.T) {
+	stats := []data.TenantInventory{
+		{TenantID: 1, ItemCount: 3, StockValue: 30},
+		{TenantID: 2, ItemCount: 2, StockValue: 5},
+	}
+
+	tests := []struct {
+		name       string
+		source     *inventorySource
+		wantScans  int
+		wantSeries int
+	}{
+		{name: "lease holder scans", source: &inventorySource{leader: true, stats: stats}, wantScans: 1, wantSeries: 1},
+		{name: "other replicas drop their values", source: &inventorySource{stats: stats}, wantScans: 0, wantSeries: 0},
+		{name: "lease failure keeps the previous values", source: &inventorySource{leaseErr: errors.New("storage timed out")}, wantScans: 0, wantSeries: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Start from values a previous collection left behind
+			tenantItems.Reset()
+			tenantItems.WithLabelValues(labelOther).Set(1)
+
+			collectInventory(context.Background(), time.Minute, tt.source, "replica-a")
+
+			if tt.source.scans != tt.wantScans {
+				t.Errorf("InventoryStats calls = %d, want %d", tt.source.scans, tt.wantScans)
+			}
+			if series := testutil.CollectAndCount(tenantItems); series != tt.wantSeries {
+				t.Fatalf("tenant_items series = %d, want %d", series, tt.wantSeries)
+			}
+			if tt.wantScans > 0 {
+				// Tenants without traffic of their own share the other label
+				if items := testutil.ToFloat64(tenantItems.WithLabelValues(labelOther)); items != 5 {
+					t.Errorf("tenant_items{tenant=%q} = %v, want 5", labelOther, items)
+				}
+				if value := testutil.ToFloat64(tenantStockValue.WithLabelValues(labelOther)); value != 35 {
+					t.Errorf("tenant_stock_value{tenant=%q} = %v, want 35", labelOther, value)
+				}
+			}
+		})
+	}
+}
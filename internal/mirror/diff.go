@@ -0,0 +1,123 @@
+package mirror
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldDiff is one field whose value differs between the stable and canary responses
+type FieldDiff struct {
+	Path   string `json:"path"`
+	Stable string `json:"stable"`
+	Canary string `json:"canary"`
+}
+
+// maxFieldDiffs caps the differences collected for one response pair
+const maxFieldDiffs = 20
+
+// diffMessages compares two messages of the same type field by field. Paths
+// name fields by their proto names with list indexes, e.g. items[2].price.
+func diffMessages(stable, canary proto.Message) []FieldDiff {
+	var diffs []FieldDiff
+	diffMessage("", stable.ProtoReflect(), canary.ProtoReflect(), &diffs)
+	return diffs
+}
+
+func diffMessage(path string, a, b protoreflect.Message, diffs *[]FieldDiff) {
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len() && len(*diffs) < maxFieldDiffs; i++ {
+		fd := fields.Get(i)
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		switch {
+		case fd.IsList():
+			diffList(fieldPath, fd, a.Get(fd).List(), b.Get(fd).List(), diffs)
+		case fd.IsMap():
+			if !proto.Equal(mapHolder(a, fd), mapHolder(b, fd)) {
+				addDiff(diffs, fieldPath, formatValue(fd, a.Get(fd)), formatValue(fd, b.Get(fd)))
+			}
+		case fd.Message() != nil:
+			if a.Has(fd) != b.Has(fd) {
+				addDiff(diffs, fieldPath, presence(a.Has(fd)), presence(b.Has(fd)))
+				continue
+			}
+			if a.Has(fd) {
+				diffMessage(fieldPath, a.Get(fd).Message(), b.Get(fd).Message(), diffs)
+			}
+		default:
+			if !a.Get(fd).Equal(b.Get(fd)) {
+				addDiff(diffs, fieldPath, formatValue(fd, a.Get(fd)), formatValue(fd, b.Get(fd)))
+			}
+		}
+	}
+}
+
+func diffList(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.List, diffs *[]FieldDiff) {
+	if a.Len() != b.Len() {
+		addDiff(diffs, path+".length", fmt.Sprint(a.Len()), fmt.Sprint(b.Len()))
+	}
+	for i := 0; i < min(a.Len(), b.Len()) && len(*diffs) < maxFieldDiffs; i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		if fd.Message() != nil {
+			diffMessage(elementPath, a.Get(i).Message(), b.Get(i).Message(), diffs)
+		} else if !a.Get(i).Equal(b.Get(i)) {
+			addDiff(diffs, elementPath, formatValue(fd, a.Get(i)), formatValue(fd, b.Get(i)))
+		}
+	}
+}
+
+// mapHolder copies one map field into an otherwise empty message so maps can
+// be compared with proto.Equal
+func mapHolder(m protoreflect.Message, fd protoreflect.FieldDescriptor) proto.Message {
+	holder := m.New()
+	if m.Has(fd) {
+		holder.Set(fd, m.Get(fd))
+	}
+	return holder.Interface()
+}
+
+func addDiff(diffs *[]FieldDiff, path, stable, canary string) {
+	if len(*diffs) < maxFieldDiffs {
+		*diffs = append(*diffs, FieldDiff{Path: path, Stable: stable, Canary: canary})
+	}
+}
+
+func presence(set bool) string {
+	if set {
+		return "<set>"
+	}
+	return "<unset>"
+}
+
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.Enum() != nil && !fd.IsList() && !fd.IsMap() {
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// fieldLabel normalises a diff path for use as a metric label by dropping
+// list indexes, so the label set is bounded by the schema
+func fieldLabel(path string) string {
+	var b strings.Builder
+	skip := false
+	for _, r := range path {
+		switch {
+		case r == '[':
+			skip = true
+		case r == ']':
+			skip = false
+		case !skip:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package mirror
+
+import (
+	"testing"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+func TestDiffMessages(t *testing.T) {
+	item := func(id string, price float64, status pb.ItemStatus) *pb.Item {
+		return &pb.Item{Id: id, Name: "Widget", Price: price, Status: status}
+	}
+
+	tests := []struct {
+		name   string
+		stable *pb.ListItemsResponse
+		canary *pb.ListItemsResponse
+		want   []FieldDiff
+	}{
+		{
+			name:   "identical",
+			stable: &pb.ListItemsResponse{Items: []*pb.Item{item("a", 1, pb.ItemStatus_ITEM_STATUS_ACTIVE)}, TotalCount: 1},
+			canary: &pb.ListItemsResponse{Items: []*pb.Item{item("a", 1, pb.ItemStatus_ITEM_STATUS_ACTIVE)}, TotalCount: 1},
+		},
+		{
+			name:   "field of a list element",
+			stable: &pb.ListItemsResponse{Items: []*pb.Item{item("a", 1, pb.ItemStatus_ITEM_STATUS_ACTIVE), item("b", 2, pb.ItemStatus_ITEM_STATUS_ACTIVE)}},
+			canary: &pb.ListItemsResponse{Items: []*pb.Item{item("a", 1, pb.ItemStatus_ITEM_STATUS_ACTIVE), item("b", 2.5, pb.ItemStatus_ITEM_STATUS_ACTIVE)}},
+			want:   []FieldDiff{{Path: "items[1].price", Stable: "2", Canary: "2.5"}},
+		},
+		{
+			name:   "enum values by name",
+			stable: &pb.ListItemsResponse{Items: []*pb.Item{item("a", 1, pb.ItemStatus_ITEM_STATUS_ACTIVE)}},
+			canary: &pb.ListItemsResponse{Items: []*pb.Item{item("a", 1, pb.ItemStatus_ITEM_STATUS_OUT_OF_STOCK)}},
+			want:   []FieldDiff{{Path: "items[0].status", Stable: "ITEM_STATUS_ACTIVE", Canary: "ITEM_STATUS_OUT_OF_STOCK"}},
+		},
+		{
+			name:   "list length and scalar",
+			stable: &pb.ListItemsResponse{Items: []*pb.Item{item("a", 1, pb.ItemStatus_ITEM_STATUS_ACTIVE)}, TotalCount: 1},
+			canary: &pb.ListItemsResponse{TotalCount: 0},
+			want: []FieldDiff{
+				{Path: "items.length", Stable: "1", Canary: "0"},
+				{Path: "total_count", Stable: "1", Canary: "0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffMessages(tt.stable, tt.canary)
+			if len(got) != len(tt.want) {
+				t.Fatalf("diffMessages() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("diff %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiffMessagesPresence(t *testing.T) {
+	got := diffMessages(&pb.GetItemResponse{Item: &pb.Item{Id: "a"}}, &pb.GetItemResponse{})
+	want := FieldDiff{Path: "item", Stable: "<set>", Canary: "<unset>"}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("diffMessages() = %+v, want [%+v]", got, want)
+	}
+}
+
+func TestDiffMessagesCapped(t *testing.T) {
+	stable := &pb.ListItemsResponse{}
+	canary := &pb.ListItemsResponse{}
+	for i := range maxFieldDiffs + 5 {
+		stable.Items = append(stable.Items, &pb.Item{Price: float64(i)})
+		canary.Items = append(canary.Items, &pb.Item{Price: float64(i) + 1})
+	}
+
+	if got := diffMessages(stable, canary); len(got) != maxFieldDiffs {
+		t.Errorf("diffMessages() returned %d diffs, want the cap of %d", len(got), maxFieldDiffs)
+	}
+}
+
+func TestFieldLabel(t *testing.T) {
+	tests := map[string]string{
+		"items[12].price": "items.price",
+		"items.length":    "items.length",
+		"item.tags[0]":    "item.tags",
+		"next_page_token": "next_page_token",
+	}
+	for path, want := range tests {
+		if got := fieldLabel(path); got != want {
+			t.Errorf("fieldLabel(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+	"github.com/rinsecrm/store-service/internal/metrics"
+)
+
+// MirroredHeader marks calls sent by the mirror, so the canary can tell them
+// apart from real traffic
+const MirroredHeader = "x-mirrored"
+
+// Mirror results, as reported in mirror_requests_total
+const (
+	ResultMatch       = "match"
+	ResultMismatch    = "mismatch"
+	ResultCanaryError = "canary_error"
+	ResultDropped     = "dropped"
+)
+
+// Config holds traffic mirroring settings
+type Config struct {
+	Endpoint   string        // Canary gRPC address
+	SampleRate float64       // Fraction of eligible calls mirrored, 0 to 1
+	Methods    []string      // Full method names to mirror; must be read-only
+	Timeout    time.Duration // Deadline for each mirrored call
+	Workers    int           // Concurrent mirrored calls
+	QueueSize  int           // Calls waiting for a worker before new ones are dropped
+	KeepDiffs  int           // Recent mismatches kept for DiffsHandler
+}
+
+// Diff records one mirrored call whose canary response did not match stable
+type Diff struct {
+	Time         time.Time   `json:"time"`
+	Method       string      `json:"method"`
+	TraceID      string      `json:"trace_id,omitempty"`
+	StableStatus string      `json:"stable_status"`
+	CanaryStatus string      `json:"canary_status"`
+	Fields       []FieldDiff `json:"fields,omitempty"`
+}
+
+// call is a primary call queued for mirroring
+type call struct {
+	method      string
+	req         proto.Message
+	resp        proto.Message
+	code        codes.Code
+	md          metadata.MD
+	spanContext trace.SpanContext
+}
+
+// Mirror replays a sample of read-only calls against a canary and compares
+// the responses. Mirroring happens on background workers after the primary
+// response is ready, so it never changes the primary response or delays it.
+type Mirror struct {
+	config  Config
+	conn    *grpc.ClientConn
+	methods map[string]protoreflect.MessageType
+	queue   chan call
+	done    chan struct{}
+	wg      sync.WaitGroup
+	logRate *rate.Limiter
+
+	mu    sync.Mutex
+	diffs []Diff
+}
+
+// New creates a mirror and starts its workers
+func New(config Config) (*Mirror, error) {
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("mirror endpoint is required")
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1
+	}
+
+	conn, err := grpc.NewClient(config.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror client: %w", err)
+	}
+
+	m := &Mirror{
+		config:  config,
+		conn:    conn,
+		methods: make(map[string]protoreflect.MessageType, len(config.Methods)),
+		queue:   make(chan call, config.QueueSize),
+		done:    make(chan struct{}),
+		logRate: rate.NewLimiter(rate.Every(time.Second), 5),
+	}
+	for _, method := range config.Methods {
+		replyType, err := responseType(method)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		m.methods[method] = replyType
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+	return m, nil
+}
+
+// Close stops the workers, dropping queued calls, and closes the canary connection
+func (m *Mirror) Close() error {
+	close(m.done)
+	m.wg.Wait()
+	return m.conn.Close()
+}
+
+// UnaryServerInterceptor queues a sample of calls to the configured methods
+// for mirroring once the primary handler has returned. Calls that are
+// themselves canary or mirrored traffic are never mirrored.
+func (m *Mirror) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+
+		if _, ok := m.methods[info.FullMethod]; ok && m.eligible(ctx) && rand.Float64() < m.config.SampleRate {
+			m.enqueue(ctx, info.FullMethod, req, resp, err)
+		}
+		return resp, err
+	}
+}
+
+// responseType looks up the response message of a full method name such as
+// "/store.v1.StoreService/GetItem" in the registered proto descriptors
+func responseType(fullMethod string) (protoreflect.MessageType, error) {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown mirror method %s: %w", fullMethod, err)
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok || method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("mirror method %s is not a unary RPC", fullMethod)
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+}
+
+func (m *Mirror) eligible(ctx context.Context) bool {
+	if _, ok := canaryctx.FromContext(ctx); ok {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md.Get(MirroredHeader)) == 0
+}
+
+// enqueue hands a call to the workers without blocking; calls are dropped
+// when the queue is full
+func (m *Mirror) enqueue(ctx context.Context, method string, req, resp interface{}, err error) {
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		return
+	}
+	c := call{
+		method:      method,
+		req:         proto.Clone(reqMsg),
+		code:        status.Code(err),
+		md:          outgoingMetadata(ctx),
+		spanContext: trace.SpanContextFromContext(ctx),
+	}
+	if respMsg, ok := resp.(proto.Message); ok && err == nil {
+		c.resp = proto.Clone(respMsg)
+	}
+
+	service, name := metrics.SplitFullMethod(method)
+	select {
+	case <-m.done:
+	case m.queue <- c:
+	default:
+		metrics.RecordMirrorResult(service, name, ResultDropped)
+	}
+}
+
+// outgoingMetadata forwards the caller's metadata, such as authorization, to
+// the canary. The canary header is removed so the canary reads the same data
+// as stable, and transport and tracing headers are set afresh by the client.
+func outgoingMetadata(ctx context.Context) metadata.MD {
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	md := metadata.MD{}
+	for key, values := range incoming {
+		switch {
+		case strings.HasPrefix(key, ":"), strings.HasPrefix(key, "grpc-"):
+		case key == strings.ToLower(canaryctx.CanaryHeader):
+		case key == "content-type", key == "user-agent", key == "traceparent", key == "tracestate", key == "baggage":
+		default:
+			md[key] = append([]string(nil), values...)
+		}
+	}
+	md.Set(MirroredHeader, "true")
+	return md
+}
+
+func (m *Mirror) worker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.done:
+			return
+		case c := <-m.queue:
+			m.mirror(c)
+		}
+	}
+}
+
+// mirror replays one call against the canary and records how it compared
+func (m *Mirror) mirror(c call) {
+	// Parent the mirrored call on the primary span so both show in one trace
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), c.spanContext)
+	ctx = metadata.NewOutgoingContext(ctx, c.md)
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+
+	reply := m.methods[c.method].New().Interface()
+	err := m.conn.Invoke(ctx, c.method, c.req, reply)
+	canaryCode := status.Code(err)
+
+	service, name := metrics.SplitFullMethod(c.method)
+
+	// Canary transport failures say nothing about correctness
+	if canaryCode == codes.Unavailable || canaryCode == codes.DeadlineExceeded {
+		metrics.RecordMirrorResult(service, name, ResultCanaryError)
+		if m.logRate.Allow() {
+			logging.WithError(err).WithField("method", c.method).Warn("Mirrored call to canary failed")
+		}
+		return
+	}
+
+	diff := Diff{
+		Time:         time.Now(),
+		Method:       c.method,
+		StableStatus: c.code.String(),
+		CanaryStatus: canaryCode.String(),
+	}
+	if c.spanContext.HasTraceID() {
+		diff.TraceID = c.spanContext.TraceID().String()
+	}
+	if c.code == codes.OK && canaryCode == codes.OK && c.resp != nil {
+		diff.Fields = diffMessages(c.resp, reply)
+	}
+
+	if c.code == canaryCode && len(diff.Fields) == 0 {
+		metrics.RecordMirrorResult(service, name, ResultMatch)
+		return
+	}
+
+	metrics.RecordMirrorResult(service, name, ResultMismatch)
+	for _, field := range diff.Fields {
+		metrics.RecordMirrorFieldMismatch(service, name, fieldLabel(field.Path))
+	}
+	m.keep(diff)
+
+	if m.logRate.Allow() {
+		logging.WithFields(logrus.Fields{
+			"method":        c.method,
+			"trace_id":      diff.TraceID,
+			"stable_status": diff.StableStatus,
+			"canary_status": diff.CanaryStatus,
+			"fields":        diff.Fields,
+		}).Warn("Canary response differs from stable")
+	}
+}
+
+func (m *Mirror) keep(diff Diff) {
+	if m.config.KeepDiffs <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.diffs = append(m.diffs, diff)
+	if len(m.diffs) > m.config.KeepDiffs {
+		m.diffs = m.diffs[len(m.diffs)-m.config.KeepDiffs:]
+	}
+}
+
+// DiffsHandler serves the most recent mismatches as JSON, newest first
+func (m *Mirror) DiffsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		diffs := make([]Diff, len(m.diffs))
+		for i, diff := range m.diffs {
+			diffs[len(m.diffs)-1-i] = diff
+		}
+		m.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(diffs)
+	})
+}
@@ -0,0 +1,230 @@
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+const getItemMethod = "/store.v1.StoreService/GetItem"
+
+// canaryServer answers GetItem with item or err and records the metadata
+type canaryServer struct {
+	pb.UnimplementedStoreServiceServer
+	item *pb.Item
+	err  error
+	md   metadata.MD
+}
+
+func (s *canaryServer) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.GetItemResponse, error) {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &pb.GetItemResponse{Item: s.item}, nil
+}
+
+// newTestMirror mirrors GetItem to a canary served by srv
+func newTestMirror(t *testing.T, srv pb.StoreServiceServer) *Mirror {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterStoreServiceServer(grpcServer, srv)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	m, err := New(Config{
+		Endpoint:  lis.Addr().String(),
+		Methods:   []string{getItemMethod},
+		Timeout:   5 * time.Second,
+		QueueSize: 1,
+		KeepDiffs: 10,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+// diffs returns what DiffsHandler serves
+func diffs(t *testing.T, m *Mirror) []Diff {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.DiffsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/mirror/diffs", nil))
+	var got []Diff
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("diffs are not JSON: %v", err)
+	}
+	return got
+}
+
+func TestMirrorCompare(t *testing.T) {
+	stable := &pb.GetItemResponse{Item: &pb.Item{Id: "widget", Name: "Widget"}}
+
+	tests := []struct {
+		name       string
+		stableCode codes.Code
+		canary     *canaryServer
+		wantDiff   *Diff
+	}{
+		{
+			name:   "matching responses",
+			canary: &canaryServer{item: &pb.Item{Id: "widget", Name: "Widget"}},
+		},
+		{
+			name:   "different field",
+			canary: &canaryServer{item: &pb.Item{Id: "widget", Name: "Gadget"}},
+			wantDiff: &Diff{StableStatus: "OK", CanaryStatus: "OK", Fields: []FieldDiff{
+				{Path: "item.name", Stable: "Widget", Canary: "Gadget"},
+			}},
+		},
+		{
+			name:     "different status",
+			canary:   &canaryServer{err: status.Error(codes.NotFound, "item not found")},
+			wantDiff: &Diff{StableStatus: "OK", CanaryStatus: "NotFound"},
+		},
+		{
+			name:       "matching errors",
+			stableCode: codes.NotFound,
+			canary:     &canaryServer{err: status.Error(codes.NotFound, "item not found")},
+		},
+		{
+			name:   "unavailable canary is not a mismatch",
+			canary: &canaryServer{err: status.Error(codes.Unavailable, "draining")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMirror(t, tt.canary)
+			c := call{
+				method: getItemMethod,
+				req:    &pb.GetItemRequest{TenantId: 1, Id: "widget"},
+				code:   tt.stableCode,
+				md:     metadata.Pairs(MirroredHeader, "true"),
+			}
+			if tt.stableCode == codes.OK {
+				c.resp = stable
+			}
+
+			m.mirror(c)
+
+			got := diffs(t, m)
+			if tt.wantDiff == nil {
+				if len(got) != 0 {
+					t.Errorf("diffs = %+v, want none", got)
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("diffs = %+v, want one", got)
+			}
+			if got[0].Method != getItemMethod || got[0].StableStatus != tt.wantDiff.StableStatus || got[0].CanaryStatus != tt.wantDiff.CanaryStatus {
+				t.Errorf("diff = %+v, want %+v", got[0], *tt.wantDiff)
+			}
+			if len(got[0].Fields) != len(tt.wantDiff.Fields) || (len(got[0].Fields) > 0 && got[0].Fields[0] != tt.wantDiff.Fields[0]) {
+				t.Errorf("diff fields = %+v, want %+v", got[0].Fields, tt.wantDiff.Fields)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	incoming := metadata.Pairs("authorization", "Bearer token", "x-canary", "7")
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		method     string
+		queued     int // calls already waiting for a worker
+		wantQueued bool
+	}{
+		{
+			name:       "sampled read",
+			ctx:        metadata.NewIncomingContext(context.Background(), incoming),
+			method:     getItemMethod,
+			wantQueued: true,
+		},
+		{
+			name:   "method not mirrored",
+			ctx:    context.Background(),
+			method: "/store.v1.StoreService/ListItems",
+		},
+		{
+			name:   "canary traffic",
+			ctx:    canaryctx.WithCanary(context.Background(), "7"),
+			method: getItemMethod,
+		},
+		{
+			name:   "mirrored traffic",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs(MirroredHeader, "true")),
+			method: getItemMethod,
+		},
+		{
+			name:   "full queue drops the call",
+			ctx:    context.Background(),
+			method: getItemMethod,
+			queued: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No workers, so queued calls stay put
+			m := &Mirror{
+				config:  Config{SampleRate: 1},
+				methods: map[string]protoreflect.MessageType{getItemMethod: (&pb.GetItemResponse{}).ProtoReflect().Type()},
+				queue:   make(chan call, 1),
+				done:    make(chan struct{}),
+			}
+			for range tt.queued {
+				m.queue <- call{}
+			}
+			resp := &pb.GetItemResponse{Item: &pb.Item{Id: "widget"}}
+			handler := func(ctx context.Context, req any) (any, error) { return resp, nil }
+
+			got, err := m.UnaryServerInterceptor()(tt.ctx, &pb.GetItemRequest{TenantId: 1, Id: "widget"}, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			if err != nil || got != resp {
+				t.Fatalf("interceptor = %v, %v, want the primary response", got, err)
+			}
+			if queued := len(m.queue) > tt.queued; queued != tt.wantQueued {
+				t.Fatalf("call queued = %t, want %t", queued, tt.wantQueued)
+			}
+			if !tt.wantQueued {
+				return
+			}
+
+			c := <-m.queue
+			// Caller credentials go to the canary, but not the canary
+			// header: the canary reads the same data as stable
+			if c.md.Get("authorization")[0] != "Bearer token" || len(c.md.Get("x-canary")) != 0 || c.md.Get(MirroredHeader)[0] != "true" {
+				t.Errorf("mirrored metadata = %v", c.md)
+			}
+		})
+	}
+}
+
+func TestNewRejectsUnknownMethods(t *testing.T) {
+	_, err := New(Config{Endpoint: "localhost:0", Methods: []string{"/store.v1.StoreService/Missing"}})
+	if err == nil {
+		t.Error("New() error = nil, want an error for an unknown method")
+	}
+}
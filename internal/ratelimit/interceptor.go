@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/metrics"
+)
+
+// RetryAfterHeader carries the suggested retry delay in whole seconds
+const RetryAfterHeader = "retry-after"
+
+// tenantRequest is implemented by every request message with a tenant_id field
+type tenantRequest interface {
+	GetTenantId() int64
+}
+
+// UnaryServerInterceptor rejects unary calls over the tenant's limit for the method
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx, req, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects each received stream message over the
+// tenant's limit for the method
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &limitedServerStream{
+			ServerStream: ss,
+			limiter:      l,
+			method:       info.FullMethod,
+		})
+	}
+}
+
+type limitedServerStream struct {
+	grpc.ServerStream
+	limiter *Limiter
+	method  string
+}
+
+func (s *limitedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.limiter.check(s.Context(), m, s.method)
+}
+
+// check applies the limit to requests that name a tenant; calls without one
+// (health checks, reflection) are not limited
+func (l *Limiter) check(ctx context.Context, req interface{}, fullMethod string) error {
+	r, ok := req.(tenantRequest)
+	if !ok || r.GetTenantId() <= 0 {
+		return nil
+	}
+	tenant := strconv.FormatInt(r.GetTenantId(), 10)
+
+	allowed, retryAfter := l.Allow(tenant, fullMethod)
+	if allowed {
+		return nil
+	}
+
+	service, method := metrics.SplitFullMethod(fullMethod)
+	metrics.RecordRateLimited(service, method, metrics.CanaryLabel(ctx))
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":   tenant,
+		"method":      fullMethod,
+		"retry_after": retryAfter,
+	}).Warn("Rate limit exceeded")
+
+	return rateLimitError(ctx, tenant, fullMethod, retryAfter)
+}
+
+// rateLimitError builds a ResourceExhausted status with RetryInfo and
+// QuotaFailure details, and sets the retry-after response header
+func rateLimitError(ctx context.Context, tenant, fullMethod string, retryAfter time.Duration) error {
+	if retryAfter > 0 {
+		seconds := int(math.Ceil(retryAfter.Seconds()))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.Itoa(seconds)))
+	}
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded for tenant %s on %s", tenant, fullMethod))
+	details := []protoadapt.MessageV1{
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     "tenant:" + tenant,
+				Description: "request rate limit for " + fullMethod,
+			}},
+		},
+	}
+	if retryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	limiter := NewLimiter(Config{Default: Limit{Rate: 1, Burst: 1}})
+	interceptor := limiter.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: getItemMethod}
+	handler := func(ctx context.Context, req any) (any, error) { return req, nil }
+
+	tests := []struct {
+		name     string
+		req      any
+		wantCode codes.Code
+	}{
+		{name: "first call", req: &pb.GetItemRequest{TenantId: 1, Id: "widget"}},
+		{name: "over the limit", req: &pb.GetItemRequest{TenantId: 1, Id: "widget"}, wantCode: codes.ResourceExhausted},
+		{name: "request without a tenant is not limited", req: &pb.GetLoggingRequest{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(context.Background(), tt.req, info, handler)
+
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("interceptor code = %s, want %s (%v)", st.Code(), tt.wantCode, err)
+			}
+			if err == nil {
+				return
+			}
+
+			var quota *errdetails.QuotaFailure
+			var retry *errdetails.RetryInfo
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.QuotaFailure:
+					quota = d
+				case *errdetails.RetryInfo:
+					retry = d
+				}
+			}
+			if quota == nil || quota.Violations[0].Subject != "tenant:1" {
+				t.Errorf("QuotaFailure = %v, want a violation for tenant:1", quota)
+			}
+			if retry == nil || retry.RetryDelay.AsDuration() <= 0 {
+				t.Errorf("RetryInfo = %v, want a retry delay", retry)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/rinsecrm/store-service/internal/metrics"
+)
+
+// Limit is a token bucket definition: Rate tokens per second, up to Burst
+type Limit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// TenantOverride replaces the global limits for a single tenant
+type TenantOverride struct {
+	Default *Limit           `json:"default,omitempty"`
+	Methods map[string]Limit `json:"methods,omitempty"`
+}
+
+// Config holds rate limiting configuration. Limits are resolved from the most
+// specific match: tenant method, global method, tenant default, global default.
+type Config struct {
+	Default     Limit                     `json:"default"`
+	Methods     map[string]Limit          `json:"methods,omitempty"` // Keyed by gRPC full method name
+	Tenants     map[string]TenantOverride `json:"tenants,omitempty"` // Keyed by tenant ID
+	IdleTimeout time.Duration             `json:"-"`                 // Buckets unused this long are dropped
+}
+
+// LoadConfig reads per-method and per-tenant overrides from a JSON file on top
+// of the given default limit
+func LoadConfig(path string, defaultLimit Limit) (Config, error) {
+	config := Config{Default: defaultLimit}
+	if path == "" {
+		return config, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read rate limit config: %w", err)
+	}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to parse rate limit config: %w", err)
+	}
+
+	for tenant := range config.Tenants {
+		if _, err := strconv.ParseInt(tenant, 10, 64); err != nil {
+			return Config{}, fmt.Errorf("invalid tenant ID %q in rate limit config", tenant)
+		}
+	}
+	return config, nil
+}
+
+// limitFor resolves the limit that applies to a tenant and method
+func (c Config) limitFor(tenant, fullMethod string) Limit {
+	override, hasOverride := c.Tenants[tenant]
+	if hasOverride {
+		if limit, ok := override.Methods[fullMethod]; ok {
+			return limit
+		}
+	}
+	if limit, ok := c.Methods[fullMethod]; ok {
+		return limit
+	}
+	if hasOverride && override.Default != nil {
+		return *override.Default
+	}
+	return c.Default
+}
+
+type bucketKey struct {
+	tenant string
+	method string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter holds one token bucket per tenant and method
+type Limiter struct {
+	config Config
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+// NewLimiter creates a limiter for the given configuration
+func NewLimiter(config Config) *Limiter {
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = 10 * time.Minute
+	}
+	return &Limiter{
+		config:    config,
+		buckets:   make(map[bucketKey]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token from the tenant's bucket for the method. When the bucket
+// is empty it returns false and how long until a token becomes available.
+func (l *Limiter) Allow(tenant, fullMethod string) (bool, time.Duration) {
+	now := time.Now()
+	key := bucketKey{tenant: tenant, method: fullMethod}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		limit := l.config.limitFor(tenant, fullMethod)
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	if now.Sub(l.lastSweep) > l.config.IdleTimeout {
+		l.sweep(now)
+	}
+	metrics.SetRateLimitActiveBuckets(len(l.buckets))
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		// Burst of zero: the method is disabled for this tenant
+		metrics.SetRateLimitTokensRemaining(tenant, fullMethod, 0)
+		return false, 0
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		metrics.SetRateLimitTokensRemaining(tenant, fullMethod, b.limiter.TokensAt(now))
+		return false, delay
+	}
+
+	metrics.SetRateLimitTokensRemaining(tenant, fullMethod, b.limiter.TokensAt(now))
+	return true, 0
+}
+
+// sweep drops buckets that have been idle longer than the idle timeout. A
+// dropped bucket is recreated full, which is what an idle bucket would be anyway.
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.config.IdleTimeout {
+			delete(l.buckets, key)
+			metrics.DeleteRateLimitBucket(key.tenant, key.method)
+		}
+	}
+	l.lastSweep = now
+}
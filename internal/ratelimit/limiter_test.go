@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	getItemMethod    = "/store.v1.StoreService/GetItem"
+	createItemMethod = "/store.v1.StoreService/CreateItem"
+)
+
+func TestLimitFor(t *testing.T) {
+	config := Config{
+		Default: Limit{Rate: 10, Burst: 10},
+		Methods: map[string]Limit{createItemMethod: {Rate: 2, Burst: 2}},
+		Tenants: map[string]TenantOverride{
+			"7": {
+				Default: &Limit{Rate: 100, Burst: 100},
+				Methods: map[string]Limit{createItemMethod: {Rate: 20, Burst: 20}},
+			},
+			"8": {Methods: map[string]Limit{getItemMethod: {Rate: 1, Burst: 1}}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		tenant string
+		method string
+		want   Limit
+	}{
+		{name: "global default", tenant: "1", method: getItemMethod, want: Limit{Rate: 10, Burst: 10}},
+		{name: "global method", tenant: "1", method: createItemMethod, want: Limit{Rate: 2, Burst: 2}},
+		{name: "tenant method", tenant: "7", method: createItemMethod, want: Limit{Rate: 20, Burst: 20}},
+		{name: "tenant default", tenant: "7", method: getItemMethod, want: Limit{Rate: 100, Burst: 100}},
+		{name: "global method over a tenant without it", tenant: "8", method: createItemMethod, want: Limit{Rate: 2, Burst: 2}},
+		{name: "global default for a tenant without a default", tenant: "8", method: "/store.v1.StoreService/ListItems", want: Limit{Rate: 10, Burst: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.limitFor(tt.tenant, tt.method); got != tt.want {
+				t.Errorf("limitFor(%s, %s) = %+v, want %+v", tt.tenant, tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	limiter := NewLimiter(Config{
+		Default: Limit{Rate: 1, Burst: 2},
+		Tenants: map[string]TenantOverride{
+			"9": {Methods: map[string]Limit{createItemMethod: {Rate: 0, Burst: 0}}},
+		},
+	})
+
+	for i := range 2 {
+		if allowed, _ := limiter.Allow("1", getItemMethod); !allowed {
+			t.Fatalf("call %d within the burst was rejected", i+1)
+		}
+	}
+	allowed, retryAfter := limiter.Allow("1", getItemMethod)
+	if allowed {
+		t.Fatal("call over the burst was allowed")
+	}
+	if retryAfter <= 0 {
+		t.Errorf("retry after = %s, want a delay until the next token", retryAfter)
+	}
+
+	// Buckets are per tenant and per method
+	if allowed, _ := limiter.Allow("2", getItemMethod); !allowed {
+		t.Error("another tenant shared the exhausted bucket")
+	}
+	if allowed, _ := limiter.Allow("1", createItemMethod); !allowed {
+		t.Error("another method shared the exhausted bucket")
+	}
+
+	// A burst of zero disables the method outright
+	allowed, retryAfter = limiter.Allow("9", createItemMethod)
+	if allowed || retryAfter != 0 {
+		t.Errorf("Allow() on a disabled method = %t, %s, want false with no retry delay", allowed, retryAfter)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "method and tenant overrides", content: `{"methods": {"/store.v1.StoreService/CreateItem": {"rate": 2, "burst": 4}}, "tenants": {"42": {"default": {"rate": 50, "burst": 100}}}}`},
+		{name: "tenant key that is not an ID", content: `{"tenants": {"acme": {"default": {"rate": 1, "burst": 1}}}}`, wantErr: true},
+		{name: "not JSON", content: `rate: 1`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ratelimit.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			config, err := LoadConfig(path, Limit{Rate: 10, Burst: 20})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if config.Default != (Limit{Rate: 10, Burst: 20}) {
+				t.Errorf("default = %+v, want the flag default", config.Default)
+			}
+			if got := config.limitFor("42", createItemMethod); got != (Limit{Rate: 2, Burst: 4}) {
+				t.Errorf("limitFor(42, CreateItem) = %+v, want the method override", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/data"
+	"github.com/rinsecrm/store-service/internal/tracing"
+	"github.com/rinsecrm/store-service/internal/validate"
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// errorDomain is the ErrorInfo domain of every reason below
+var errorDomain = pb.StoreService_ServiceDesc.ServiceName
+
+// ErrorInfo reasons. They are part of the API: clients may branch on them,
+// so existing values must not change.
+const (
+	ReasonInvalidFields         = validate.ReasonInvalidFields
+	ReasonItemNotFound          = "ITEM_NOT_FOUND"
+	ReasonQuotaExceeded         = "QUOTA_EXCEEDED"
+	ReasonInsufficientInventory = "INSUFFICIENT_INVENTORY"
+	ReasonIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	ReasonConcurrentUpdate      = "CONCURRENT_UPDATE"
+	ReasonStorageThrottled      = "STORAGE_THROTTLED"
+	ReasonStorageTimeout        = "STORAGE_TIMEOUT"
+	ReasonStorageUnavailable    = "STORAGE_UNAVAILABLE"
+	ReasonRequestCanceled       = "REQUEST_CANCELED"
+	ReasonDeadlineExceeded      = "DEADLINE_EXCEEDED"
+	ReasonInternal              = "INTERNAL"
+)
+
+// throttleRetryDelay is the RetryInfo delay suggested after DynamoDB throttling
+const throttleRetryDelay = time.Second
+
+// storeError converts an error returned by the store into a gRPC status.
+// action names the failed operation, e.g. "get item", for the log line and
+// the Internal message; fields are added to the log line.
+func storeError(ctx context.Context, span trace.Span, err error, tenantID int64, action string, fields logrus.Fields) error {
+	var quotaErr *data.QuotaExceededError
+	switch {
+	case errors.Is(err, data.ErrItemNotFound):
+		return statusWithDetails(codes.NotFound, "item not found", errorInfo(ReasonItemNotFound, nil))
+	case errors.As(err, &quotaErr):
+		return quotaExceededError(tenantID, quotaErr)
+	case errors.Is(err, data.ErrInsufficientInventory):
+		return statusWithDetails(codes.FailedPrecondition, err.Error(),
+			&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{{
+					Type:        "INVENTORY",
+					Subject:     "inventory_count",
+					Description: "inventory cannot go negative",
+				}},
+			},
+			errorInfo(ReasonInsufficientInventory, nil),
+		)
+	case errors.Is(err, data.ErrIdempotencyKeyReused):
+		return statusWithDetails(codes.FailedPrecondition, err.Error(),
+			&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{{
+					Type:        "IDEMPOTENCY",
+					Subject:     "idempotency_key",
+					Description: "key was first used with a different request",
+				}},
+			},
+			errorInfo(ReasonIdempotencyKeyReused, nil),
+		)
+	case errors.Is(err, data.ErrConcurrentUpdate):
+		return statusWithDetails(codes.Aborted, err.Error(), errorInfo(ReasonConcurrentUpdate, nil))
+	}
+
+	tracing.RecordError(span, err)
+	logger := logging.FromContext(ctx).WithError(err).WithFields(fields)
+
+	var circuitErr *data.CircuitOpenError
+	switch {
+	case errors.As(err, &circuitErr):
+		logger.Warn("Storage circuit open during " + action)
+		return statusWithDetails(codes.Unavailable, "storage is unavailable, retry later",
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(circuitErr.RetryAfter)},
+			errorInfo(ReasonStorageUnavailable, nil),
+		)
+	case errors.Is(err, data.ErrStorageTimeout):
+		logger.Warn("Storage timed out during " + action)
+		return statusWithDetails(codes.Unavailable, "storage timed out, retry later",
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(throttleRetryDelay)},
+			errorInfo(ReasonStorageTimeout, nil),
+		)
+	case errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled):
+		logger.Info("Request canceled during " + action)
+		return statusWithDetails(codes.Canceled, "request canceled", errorInfo(ReasonRequestCanceled, nil))
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		logger.Warn("Deadline exceeded during " + action)
+		return statusWithDetails(codes.DeadlineExceeded, "deadline exceeded", errorInfo(ReasonDeadlineExceeded, nil))
+	}
+
+	if code, ok := throttleCode(err); ok {
+		logger.WithField("aws_error_code", code).Warn("Storage throttled during " + action)
+		// Account-level request limits last longer than a table's burst capacity
+		grpcCode := codes.Unavailable
+		if code == "RequestLimitExceeded" {
+			grpcCode = codes.ResourceExhausted
+		}
+		return statusWithDetails(grpcCode, "storage is throttling requests, retry later",
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(throttleRetryDelay)},
+			errorInfo(ReasonStorageThrottled, map[string]string{"aws_error_code": code}),
+		)
+	}
+
+	logger.Error("Failed to " + action)
+	return statusWithDetails(codes.Internal, "failed to "+action, errorInfo(ReasonInternal, nil))
+}
+
+// throttleCode reports the DynamoDB error code if err is throttling, including
+// transactions cancelled because one of their items was throttled
+func throttleCode(err error) (string, bool) {
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		for _, reason := range canceled.CancellationReasons {
+			if reason.Code != nil && *reason.Code == "ThrottlingError" {
+				return *reason.Code, true
+			}
+		}
+		return "", false
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch code := apiErr.ErrorCode(); code {
+		case "ProvisionedThroughputExceededException", "ThrottlingException", "RequestLimitExceeded":
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// quotaExceededError builds a ResourceExhausted status carrying a QuotaFailure
+// detail that names the violated quota
+func quotaExceededError(tenantID int64, quotaErr *data.QuotaExceededError) error {
+	return statusWithDetails(codes.ResourceExhausted, quotaErr.Error(),
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     fmt.Sprintf("tenant:%d", tenantID),
+				Description: fmt.Sprintf("%s limit of %d exceeded", quotaErr.Quota, quotaErr.Limit),
+			}},
+		},
+		errorInfo(ReasonQuotaExceeded, map[string]string{
+			"quota": quotaErr.Quota,
+			"limit": strconv.FormatInt(quotaErr.Limit, 10),
+		}),
+	)
+}
+
+func errorInfo(reason string, metadata map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain, Metadata: metadata}
+}
+
+// statusWithDetails builds a status error with details, falling back to the
+// bare status if the details cannot be attached
+func statusWithDetails(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
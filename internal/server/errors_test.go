@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rinsecrm/store-service/internal/data"
+)
+
+// details returns the ErrorInfo, RetryInfo and QuotaFailure details of err
+func details(err error) (*errdetails.ErrorInfo, *errdetails.RetryInfo, *errdetails.QuotaFailure) {
+	var info *errdetails.ErrorInfo
+	var retry *errdetails.RetryInfo
+	var quota *errdetails.QuotaFailure
+	for _, detail := range status.Convert(err).Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.RetryInfo:
+			retry = d
+		case *errdetails.QuotaFailure:
+			quota = d
+		}
+	}
+	return info, retry, quota
+}
+
+func TestStoreError(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		err        error
+		wantCode   codes.Code
+		wantReason string
+		wantRetry  time.Duration
+	}{
+		{
+			name:       "missing item",
+			err:        fmt.Errorf("failed to get item: %w", data.ErrItemNotFound),
+			wantCode:   codes.NotFound,
+			wantReason: ReasonItemNotFound,
+		},
+		{
+			name:       "insufficient inventory",
+			err:        data.ErrInsufficientInventory,
+			wantCode:   codes.FailedPrecondition,
+			wantReason: ReasonInsufficientInventory,
+		},
+		{
+			name:       "concurrent update",
+			err:        data.ErrConcurrentUpdate,
+			wantCode:   codes.Aborted,
+			wantReason: ReasonConcurrentUpdate,
+		},
+		{
+			name:       "open circuit",
+			err:        &data.CircuitOpenError{RetryAfter: 3 * time.Second},
+			wantCode:   codes.Unavailable,
+			wantReason: ReasonStorageUnavailable,
+			wantRetry:  3 * time.Second,
+		},
+		{
+			name:       "storage timeout",
+			err:        fmt.Errorf("%w: get_item after 5s", data.ErrStorageTimeout),
+			wantCode:   codes.Unavailable,
+			wantReason: ReasonStorageTimeout,
+			wantRetry:  throttleRetryDelay,
+		},
+		{
+			name:       "caller canceled",
+			ctx:        canceled,
+			err:        errors.New("operation error DynamoDB: GetItem, canceled"),
+			wantCode:   codes.Canceled,
+			wantReason: ReasonRequestCanceled,
+		},
+		{
+			name:       "deadline exceeded",
+			err:        fmt.Errorf("failed to query: %w", context.DeadlineExceeded),
+			wantCode:   codes.DeadlineExceeded,
+			wantReason: ReasonDeadlineExceeded,
+		},
+		{
+			name:       "table throttling",
+			err:        &smithy.GenericAPIError{Code: "ProvisionedThroughputExceededException"},
+			wantCode:   codes.Unavailable,
+			wantReason: ReasonStorageThrottled,
+			wantRetry:  throttleRetryDelay,
+		},
+		{
+			name:       "account request limit",
+			err:        &smithy.GenericAPIError{Code: "RequestLimitExceeded"},
+			wantCode:   codes.ResourceExhausted,
+			wantReason: ReasonStorageThrottled,
+			wantRetry:  throttleRetryDelay,
+		},
+		{
+			name: "transaction with a throttled item",
+			err: &types.TransactionCanceledException{CancellationReasons: []types.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ThrottlingError")},
+			}},
+			wantCode:   codes.Unavailable,
+			wantReason: ReasonStorageThrottled,
+			wantRetry:  throttleRetryDelay,
+		},
+		{
+			name:       "unexpected failure",
+			err:        &smithy.GenericAPIError{Code: "InternalServerError"},
+			wantCode:   codes.Internal,
+			wantReason: ReasonInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			err := storeError(ctx, trace.SpanFromContext(ctx), tt.err, 1, "get item", nil)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %s, want %s", code, tt.wantCode)
+			}
+			info, retry, _ := details(err)
+			if info == nil || info.Reason != tt.wantReason || info.Domain != errorDomain {
+				t.Errorf("ErrorInfo = %v, want reason %s in %s", info, tt.wantReason, errorDomain)
+			}
+			var gotRetry time.Duration
+			if retry != nil {
+				gotRetry = retry.RetryDelay.AsDuration()
+			}
+			if gotRetry != tt.wantRetry {
+				t.Errorf("retry delay = %s, want %s", gotRetry, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestStoreErrorQuotaExceeded(t *testing.T) {
+	err := storeError(context.Background(), trace.SpanFromContext(context.Background()),
+		&data.QuotaExceededError{Quota: "max_items", Limit: 100, Value: 100}, 42, "create item", nil)
+
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Errorf("code = %s, want ResourceExhausted", code)
+	}
+	info, _, quota := details(err)
+	if info == nil || info.Reason != ReasonQuotaExceeded || info.Metadata["quota"] != "max_items" || info.Metadata["limit"] != "100" {
+		t.Errorf("ErrorInfo = %v, want the max_items quota of 100", info)
+	}
+	if quota == nil || len(quota.Violations) != 1 || quota.Violations[0].Subject != "tenant:42" {
+		t.Errorf("QuotaFailure = %v, want a violation for tenant:42", quota)
+	}
+}
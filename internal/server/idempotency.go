@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rinsecrm/store-service/internal/data"
+	"github.com/rinsecrm/store-service/internal/validate"
+)
+
+// IdempotencyKeyHeader is the metadata key clients may send an idempotency
+// key in instead of the request's idempotency_key field
+const IdempotencyKeyHeader = "idempotency-key"
+
+// maxIdempotencyKeyLength matches the max_len rule on idempotency_key fields
+const maxIdempotencyKeyLength = 128
+
+// withIdempotency attaches the request's idempotency key, from its field or
+// else from metadata, to ctx for the store. The request is fingerprinted
+// without the key so a retry matches the original call.
+func withIdempotency(ctx context.Context, req interface {
+	proto.Message
+	GetIdempotencyKey() string
+}) (context.Context, error) {
+	key := req.GetIdempotencyKey()
+	if key == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(IdempotencyKeyHeader); len(values) > 0 {
+				key = values[0]
+			}
+		}
+		if utf8.RuneCountInString(key) > maxIdempotencyKeyLength {
+			var invalid validate.Violations
+			invalid.Add(IdempotencyKeyHeader, fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLength))
+			return ctx, invalid.Err(errorDomain)
+		}
+	}
+	if key == "" {
+		return ctx, nil
+	}
+
+	fingerprint := proto.Clone(req)
+	fingerprint.ProtoReflect().Clear(fingerprint.ProtoReflect().Descriptor().Fields().ByName("idempotency_key"))
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(fingerprint)
+	if err != nil {
+		return ctx, statusWithDetails(codes.Internal, "failed to fingerprint request", errorInfo(ReasonInternal, nil))
+	}
+	hash := sha256.Sum256(b)
+
+	return data.WithIdempotency(ctx, data.Idempotency{
+		Key:         key,
+		RequestHash: hex.EncodeToString(hash[:]),
+	}), nil
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rinsecrm/store-service/internal/data"
+	"github.com/rinsecrm/store-service/internal/validate"
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+func TestWithIdempotency(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		req      *pb.CreateItemRequest
+		wantKey  string
+		wantCode codes.Code
+	}{
+		{
+			name:    "key in the request",
+			req:     &pb.CreateItemRequest{TenantId: 1, Name: "Widget", IdempotencyKey: "field-key"},
+			wantKey: "field-key",
+		},
+		{
+			name:    "key in metadata",
+			header:  "header-key",
+			req:     &pb.CreateItemRequest{TenantId: 1, Name: "Widget"},
+			wantKey: "header-key",
+		},
+		{
+			name:    "request field wins over metadata",
+			header:  "header-key",
+			req:     &pb.CreateItemRequest{TenantId: 1, Name: "Widget", IdempotencyKey: "field-key"},
+			wantKey: "field-key",
+		},
+		{
+			name:     "metadata key too long",
+			header:   strings.Repeat("k", maxIdempotencyKeyLength+1),
+			req:      &pb.CreateItemRequest{TenantId: 1, Name: "Widget"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "no key",
+			req:  &pb.CreateItemRequest{TenantId: 1, Name: "Widget"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyKeyHeader, tt.header))
+			}
+
+			ctx, err := withIdempotency(ctx, tt.req)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("withIdempotency() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				if info, _, _ := details(err); info == nil || info.Reason != validate.ReasonInvalidFields {
+					t.Errorf("ErrorInfo = %v, want reason %s", info, validate.ReasonInvalidFields)
+				}
+				return
+			}
+			idempotency, ok := data.IdempotencyFromContext(ctx)
+			if ok != (tt.wantKey != "") || idempotency.Key != tt.wantKey {
+				t.Errorf("IdempotencyFromContext() = %+v, %t, want key %q", idempotency, ok, tt.wantKey)
+			}
+			if ok && idempotency.RequestHash == "" {
+				t.Error("request hash is empty")
+			}
+		})
+	}
+}
+
+func TestWithIdempotencyRequestHash(t *testing.T) {
+	hash := func(req *pb.CreateItemRequest) string {
+		t.Helper()
+		ctx, err := withIdempotency(context.Background(), req)
+		if err != nil {
+			t.Fatalf("withIdempotency() error = %v", err)
+		}
+		idempotency, _ := data.IdempotencyFromContext(ctx)
+		return idempotency.RequestHash
+	}
+
+	original := hash(&pb.CreateItemRequest{TenantId: 1, Name: "Widget", IdempotencyKey: "first"})
+
+	if retry := hash(&pb.CreateItemRequest{TenantId: 1, Name: "Widget", IdempotencyKey: "second"}); retry != original {
+		t.Errorf("hash with another key = %s, want %s", retry, original)
+	}
+	if changed := hash(&pb.CreateItemRequest{TenantId: 1, Name: "Gadget", IdempotencyKey: "first"}); changed == original {
+		t.Error("hash of a different request matches the original")
+	}
+}
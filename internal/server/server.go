@@ -2,17 +2,17 @@ package server
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/rinsecrm/store-service/core/logging"
 	"github.com/rinsecrm/store-service/internal/data"
 	"github.com/rinsecrm/store-service/internal/metrics"
 	"github.com/rinsecrm/store-service/internal/tracing"
+	"github.com/rinsecrm/store-service/internal/validate"
 	pb "github.com/rinsecrm/store-service/proto/go"
 )
 
@@ -30,22 +30,18 @@ func NewStoreServiceServer(store data.StoreInterface) *StoreServiceServer {
 }
 
 // CreateItem creates a new store item
-func (s *StoreServiceServer) CreateItem(ctx context.Context, req *pb.CreateItemRequest) (*pb.CreateItemResponse, error) {
+func (s *StoreServiceServer) CreateItem(ctx context.Context, req *pb.CreateItemRequest) (resp *pb.CreateItemResponse, err error) {
 	// Start custom span for business logic
 	ctx, span := tracing.StartSpan(ctx, "store.create_item")
 	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
 
 	start := time.Now()
+	defer func() { recordOperation(ctx, "create", req.TenantId, start, err) }()
 
-	// Validate request
-	if req.TenantId <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id must be positive")
-	}
-	if req.Name == "" {
-		return nil, status.Error(codes.InvalidArgument, "name is required")
-	}
-	if req.Price < 0 {
-		return nil, status.Error(codes.InvalidArgument, "price cannot be negative")
+	ctx, err = withIdempotency(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert proto enums to data types
@@ -60,27 +56,21 @@ func (s *StoreServiceServer) CreateItem(ctx context.Context, req *pb.CreateItemR
 		category,
 		req.Sku,
 		req.InventoryCount,
+		req.LowStockThreshold,
 		req.Tags,
 		req.CreatedBy,
 	)
 	if err != nil {
-		// Record error metrics
-		metrics.RecordStoreOperationError("create")
-
-		logging.WithError(err).WithFields(logrus.Fields{
+		return nil, storeError(ctx, span, err, req.TenantId, "create item", logrus.Fields{
 			"tenant_id": req.TenantId,
 			"name":      req.Name,
-		}).Error("Failed to create item")
-		return nil, status.Error(codes.Internal, "failed to create item")
+		})
 	}
 
 	duration := time.Since(start)
+	span.SetAttributes(tracing.ItemIDKey.String(item.ItemID))
 
-	// Record business metrics
-	metrics.RecordStoreOperation("create")
-	metrics.RecordStoreOperationDuration("create", float64(duration)/float64(time.Second))
-
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id": req.TenantId,
 		"item_id":   item.ItemID,
 		"duration":  duration,
@@ -92,33 +82,28 @@ func (s *StoreServiceServer) CreateItem(ctx context.Context, req *pb.CreateItemR
 }
 
 // GetItem retrieves an item by ID
-func (s *StoreServiceServer) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.GetItemResponse, error) {
+func (s *StoreServiceServer) GetItem(ctx context.Context, req *pb.GetItemRequest) (resp *pb.GetItemResponse, err error) {
 	// Start custom span for business logic
 	ctx, span := tracing.StartSpan(ctx, "store.get_item")
 	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId), tracing.ItemIDKey.String(req.Id))
 
 	start := time.Now()
+	defer func() { recordOperation(ctx, "get", req.TenantId, start, err) }()
 
-	if req.TenantId <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id must be positive")
-	}
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "id is required")
+	if req.ConsistentRead {
+		ctx = data.WithConsistentRead(ctx)
 	}
 
 	item, err := s.store.GetItem(ctx, req.TenantId, req.Id)
 	if err != nil {
-		if err == data.ErrItemNotFound {
-			return nil, status.Error(codes.NotFound, "item not found")
-		}
-		logging.WithError(err).WithFields(logrus.Fields{
+		return nil, storeError(ctx, span, err, req.TenantId, "get item", logrus.Fields{
 			"tenant_id": req.TenantId,
 			"item_id":   req.Id,
-		}).Error("Failed to get item")
-		return nil, status.Error(codes.Internal, "failed to get item")
+		})
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id": req.TenantId,
 		"item_id":   req.Id,
 		"duration":  time.Since(start),
@@ -130,25 +115,14 @@ func (s *StoreServiceServer) GetItem(ctx context.Context, req *pb.GetItemRequest
 }
 
 // UpdateItem updates an existing item
-func (s *StoreServiceServer) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+func (s *StoreServiceServer) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (resp *pb.UpdateItemResponse, err error) {
 	// Start custom span for business logic
 	ctx, span := tracing.StartSpan(ctx, "store.update_item")
 	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId), tracing.ItemIDKey.String(req.Id))
 
 	start := time.Now()
-
-	if req.TenantId <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id must be positive")
-	}
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "id is required")
-	}
-	if req.Name == "" {
-		return nil, status.Error(codes.InvalidArgument, "name is required")
-	}
-	if req.Price < 0 {
-		return nil, status.Error(codes.InvalidArgument, "price cannot be negative")
-	}
+	defer func() { recordOperation(ctx, "update", req.TenantId, start, err) }()
 
 	category := protoToDataCategory(req.Category)
 	itemStatus := protoToDataStatus(req.Status)
@@ -164,21 +138,18 @@ func (s *StoreServiceServer) UpdateItem(ctx context.Context, req *pb.UpdateItemR
 		itemStatus,
 		req.Sku,
 		req.InventoryCount,
+		req.LowStockThreshold,
 		req.Tags,
 		req.UpdatedBy,
 	)
 	if err != nil {
-		if err == data.ErrItemNotFound {
-			return nil, status.Error(codes.NotFound, "item not found")
-		}
-		logging.WithError(err).WithFields(logrus.Fields{
+		return nil, storeError(ctx, span, err, req.TenantId, "update item", logrus.Fields{
 			"tenant_id": req.TenantId,
 			"item_id":   req.Id,
-		}).Error("Failed to update item")
-		return nil, status.Error(codes.Internal, "failed to update item")
+		})
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id": req.TenantId,
 		"item_id":   req.Id,
 		"duration":  time.Since(start),
@@ -190,33 +161,24 @@ func (s *StoreServiceServer) UpdateItem(ctx context.Context, req *pb.UpdateItemR
 }
 
 // DeleteItem removes an item (soft delete)
-func (s *StoreServiceServer) DeleteItem(ctx context.Context, req *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
+func (s *StoreServiceServer) DeleteItem(ctx context.Context, req *pb.DeleteItemRequest) (resp *pb.DeleteItemResponse, err error) {
 	// Start custom span for business logic
 	ctx, span := tracing.StartSpan(ctx, "store.delete_item")
 	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId), tracing.ItemIDKey.String(req.Id))
 
 	start := time.Now()
+	defer func() { recordOperation(ctx, "delete", req.TenantId, start, err) }()
 
-	if req.TenantId <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id must be positive")
-	}
-	if req.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "id is required")
-	}
-
-	err := s.store.DeleteItem(ctx, req.TenantId, req.Id)
+	err = s.store.DeleteItem(ctx, req.TenantId, req.Id, req.DeletedBy)
 	if err != nil {
-		if err == data.ErrItemNotFound {
-			return nil, status.Error(codes.NotFound, "item not found")
-		}
-		logging.WithError(err).WithFields(logrus.Fields{
+		return nil, storeError(ctx, span, err, req.TenantId, "delete item", logrus.Fields{
 			"tenant_id": req.TenantId,
 			"item_id":   req.Id,
-		}).Error("Failed to delete item")
-		return nil, status.Error(codes.Internal, "failed to delete item")
+		})
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id": req.TenantId,
 		"item_id":   req.Id,
 		"duration":  time.Since(start),
@@ -228,16 +190,14 @@ func (s *StoreServiceServer) DeleteItem(ctx context.Context, req *pb.DeleteItemR
 }
 
 // ListItems lists items with filtering and pagination
-func (s *StoreServiceServer) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+func (s *StoreServiceServer) ListItems(ctx context.Context, req *pb.ListItemsRequest) (resp *pb.ListItemsResponse, err error) {
 	// Start custom span for business logic
 	ctx, span := tracing.StartSpan(ctx, "store.list_items")
 	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
 
 	start := time.Now()
-
-	if req.TenantId <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id must be positive")
-	}
+	defer func() { recordOperation(ctx, "list", req.TenantId, start, err) }()
 
 	pageSize := req.PageSize
 	if pageSize <= 0 {
@@ -260,10 +220,9 @@ func (s *StoreServiceServer) ListItems(ctx context.Context, req *pb.ListItemsReq
 		req.PageToken,
 	)
 	if err != nil {
-		logging.WithError(err).WithFields(logrus.Fields{
+		return nil, storeError(ctx, span, err, req.TenantId, "list items", logrus.Fields{
 			"tenant_id": req.TenantId,
-		}).Error("Failed to list items")
-		return nil, status.Error(codes.Internal, "failed to list items")
+		})
 	}
 
 	var protoItems []*pb.Item
@@ -271,7 +230,7 @@ func (s *StoreServiceServer) ListItems(ctx context.Context, req *pb.ListItemsReq
 		protoItems = append(protoItems, dataToProtoItem(item))
 	}
 
-	logging.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id":   req.TenantId,
 		"items_count": len(items),
 		"duration":    time.Since(start),
@@ -285,18 +244,18 @@ func (s *StoreServiceServer) ListItems(ctx context.Context, req *pb.ListItemsReq
 }
 
 // UpdateInventory updates the inventory count for an item
-func (s *StoreServiceServer) UpdateInventory(ctx context.Context, req *pb.UpdateInventoryRequest) (*pb.UpdateInventoryResponse, error) {
+func (s *StoreServiceServer) UpdateInventory(ctx context.Context, req *pb.UpdateInventoryRequest) (resp *pb.UpdateInventoryResponse, err error) {
 	// Start custom span for business logic
 	ctx, span := tracing.StartSpan(ctx, "store.update_inventory")
 	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId), tracing.ItemIDKey.String(req.ItemId))
 
 	start := time.Now()
+	defer func() { recordOperation(ctx, "update_inventory", req.TenantId, start, err) }()
 
-	if req.TenantId <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id must be positive")
-	}
-	if req.ItemId == "" {
-		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	ctx, err = withIdempotency(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	item, previousCount, err := s.store.UpdateInventory(
@@ -308,21 +267,23 @@ func (s *StoreServiceServer) UpdateInventory(ctx context.Context, req *pb.Update
 		req.UpdatedBy,
 	)
 	if err != nil {
-		if err == data.ErrItemNotFound {
-			return nil, status.Error(codes.NotFound, "item not found")
+		if errors.Is(err, data.ErrInsufficientInventory) {
+			metrics.RecordInsufficientStock(req.Reason)
 		}
-		logging.WithError(err).WithFields(logrus.Fields{
+		return nil, storeError(ctx, span, err, req.TenantId, "update inventory", logrus.Fields{
 			"tenant_id": req.TenantId,
 			"item_id":   req.ItemId,
-		}).Error("Failed to update inventory")
-		return nil, status.Error(codes.Internal, "failed to update inventory")
+		})
 	}
 
-	logging.WithFields(logrus.Fields{
+	metrics.RecordInventoryChange(req.Reason, req.QuantityChange)
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"tenant_id":       req.TenantId,
 		"item_id":         req.ItemId,
 		"quantity_change": req.QuantityChange,
 		"previous_count":  previousCount,
+		"status":          item.Status,
 		"duration":        time.Since(start),
 	}).Info("Inventory updated via gRPC")
 
@@ -332,6 +293,251 @@ func (s *StoreServiceServer) UpdateInventory(ctx context.Context, req *pb.Update
 	}, nil
 }
 
+// ListLowStockItems lists items at or below their low stock threshold
+func (s *StoreServiceServer) ListLowStockItems(ctx context.Context, req *pb.ListLowStockItemsRequest) (resp *pb.ListLowStockItemsResponse, err error) {
+	// Start custom span for business logic
+	ctx, span := tracing.StartSpan(ctx, "store.list_low_stock_items")
+	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
+
+	start := time.Now()
+	defer func() { recordOperation(ctx, "list_low_stock", req.TenantId, start, err) }()
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = 100 // Default page size
+	}
+	if pageSize > 1000 {
+		pageSize = 1000 // Max page size
+	}
+
+	items, nextPageToken, err := s.store.ListLowStockItems(ctx, req.TenantId, pageSize, req.PageToken)
+	if err != nil {
+		return nil, storeError(ctx, span, err, req.TenantId, "list low stock items", logrus.Fields{
+			"tenant_id": req.TenantId,
+		})
+	}
+
+	var protoItems []*pb.Item
+	for _, item := range items {
+		protoItems = append(protoItems, dataToProtoItem(item))
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":   req.TenantId,
+		"items_count": len(items),
+		"duration":    time.Since(start),
+	}).Debug("Low stock items listed via gRPC")
+
+	return &pb.ListLowStockItemsResponse{
+		Items:         protoItems,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// GetTenantConfig retrieves the settings for a tenant
+func (s *StoreServiceServer) GetTenantConfig(ctx context.Context, req *pb.GetTenantConfigRequest) (resp *pb.GetTenantConfigResponse, err error) {
+	// Start custom span for business logic
+	ctx, span := tracing.StartSpan(ctx, "store.get_tenant_config")
+	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
+
+	start := time.Now()
+	defer func() { recordOperation(ctx, "get_tenant_config", req.TenantId, start, err) }()
+
+	config, err := s.store.GetTenantConfig(ctx, req.TenantId)
+	if err != nil {
+		return nil, storeError(ctx, span, err, req.TenantId, "get tenant config", logrus.Fields{
+			"tenant_id": req.TenantId,
+		})
+	}
+
+	return &pb.GetTenantConfigResponse{
+		Config: dataToProtoTenantConfig(config),
+	}, nil
+}
+
+// UpdateTenantConfig updates the settings for a tenant
+func (s *StoreServiceServer) UpdateTenantConfig(ctx context.Context, req *pb.UpdateTenantConfigRequest) (resp *pb.UpdateTenantConfigResponse, err error) {
+	// Start custom span for business logic
+	ctx, span := tracing.StartSpan(ctx, "store.update_tenant_config")
+	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
+
+	start := time.Now()
+	defer func() { recordOperation(ctx, "update_tenant_config", req.TenantId, start, err) }()
+
+	config, err := s.store.UpdateTenantConfig(ctx, req.TenantId, req.DefaultLowStockThreshold, req.UpdatedBy)
+	if err != nil {
+		return nil, storeError(ctx, span, err, req.TenantId, "update tenant config", logrus.Fields{
+			"tenant_id": req.TenantId,
+		})
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id": req.TenantId,
+		"duration":  time.Since(start),
+	}).Info("Tenant config updated via gRPC")
+
+	return &pb.UpdateTenantConfigResponse{
+		Config: dataToProtoTenantConfig(config),
+	}, nil
+}
+
+// SetTenantQuotas replaces the plan limits of a tenant
+func (s *StoreServiceServer) SetTenantQuotas(ctx context.Context, req *pb.SetTenantQuotasRequest) (resp *pb.SetTenantQuotasResponse, err error) {
+	// Start custom span for business logic
+	ctx, span := tracing.StartSpan(ctx, "store.set_tenant_quotas")
+	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
+
+	start := time.Now()
+	defer func() { recordOperation(ctx, "set_tenant_quotas", req.TenantId, start, err) }()
+
+	config, err := s.store.SetTenantQuotas(ctx, req.TenantId, protoToDataQuotas(req.Quotas), req.UpdatedBy)
+	if err != nil {
+		return nil, storeError(ctx, span, err, req.TenantId, "set tenant quotas", logrus.Fields{
+			"tenant_id": req.TenantId,
+		})
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id": req.TenantId,
+		"duration":  time.Since(start),
+	}).Info("Tenant quotas set via gRPC")
+
+	return &pb.SetTenantQuotasResponse{
+		Config: dataToProtoTenantConfig(config),
+	}, nil
+}
+
+// RecountItems recomputes a tenant's item count from its items
+func (s *StoreServiceServer) RecountItems(ctx context.Context, req *pb.RecountItemsRequest) (resp *pb.RecountItemsResponse, err error) {
+	// Start custom span for business logic
+	ctx, span := tracing.StartSpan(ctx, "store.recount_items")
+	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
+
+	start := time.Now()
+	defer func() { recordOperation(ctx, "recount_items", req.TenantId, start, err) }()
+
+	previous, count, err := s.store.RecountItems(ctx, req.TenantId)
+	if err != nil {
+		return nil, storeError(ctx, span, err, req.TenantId, "recount items", logrus.Fields{
+			"tenant_id": req.TenantId,
+		})
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":           req.TenantId,
+		"previous_item_count": previous,
+		"item_count":          count,
+		"duration":            time.Since(start),
+	}).Info("Items recounted via gRPC")
+
+	return &pb.RecountItemsResponse{
+		PreviousItemCount: previous,
+		ItemCount:         count,
+	}, nil
+}
+
+// GetTenantUsage reports a tenant's current usage against its quotas
+func (s *StoreServiceServer) GetTenantUsage(ctx context.Context, req *pb.GetTenantUsageRequest) (resp *pb.GetTenantUsageResponse, err error) {
+	// Start custom span for business logic
+	ctx, span := tracing.StartSpan(ctx, "store.get_tenant_usage")
+	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
+
+	start := time.Now()
+	defer func() { recordOperation(ctx, "get_tenant_usage", req.TenantId, start, err) }()
+
+	usage, err := s.store.GetTenantUsage(ctx, req.TenantId)
+	if err != nil {
+		return nil, storeError(ctx, span, err, req.TenantId, "get tenant usage", logrus.Fields{
+			"tenant_id": req.TenantId,
+		})
+	}
+
+	return &pb.GetTenantUsageResponse{
+		Usage: &pb.TenantUsage{
+			TenantId:    usage.TenantID,
+			ItemCount:   usage.ItemCount,
+			WritesToday: usage.WritesToday,
+			Quotas:      dataToProtoQuotas(usage.Quotas),
+		},
+	}, nil
+}
+
+// ListAuditEvents lists the audit trail of catalog mutations for a tenant
+func (s *StoreServiceServer) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (resp *pb.ListAuditEventsResponse, err error) {
+	// Start custom span for business logic
+	ctx, span := tracing.StartSpan(ctx, "store.list_audit_events")
+	defer span.End()
+	span.SetAttributes(tracing.TenantIDKey.Int64(req.TenantId))
+
+	start := time.Now()
+	defer func() { recordOperation(ctx, "list_audit_events", req.TenantId, start, err) }()
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = 100 // Default page size
+	}
+	if pageSize > 1000 {
+		pageSize = 1000 // Max page size
+	}
+
+	filter := data.AuditFilter{
+		ItemID: req.ItemId,
+		Actor:  req.Actor,
+		Method: req.Method,
+	}
+	if req.StartTime != nil {
+		filter.StartTime = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		filter.EndTime = req.EndTime.AsTime()
+	}
+	var invalid validate.Violations
+	if !filter.StartTime.IsZero() && !filter.EndTime.IsZero() && !filter.StartTime.Before(filter.EndTime) {
+		invalid.Add("start_time", "must be before end_time")
+	}
+	if err := invalid.Err(errorDomain); err != nil {
+		return nil, err
+	}
+
+	events, nextPageToken, err := s.store.ListAuditEvents(ctx, req.TenantId, filter, pageSize, req.PageToken)
+	if err != nil {
+		return nil, storeError(ctx, span, err, req.TenantId, "list audit events", logrus.Fields{
+			"tenant_id": req.TenantId,
+		})
+	}
+
+	var protoEvents []*pb.AuditEvent
+	for _, event := range events {
+		protoEvents = append(protoEvents, dataToProtoAuditEvent(event))
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"tenant_id":    req.TenantId,
+		"events_count": len(events),
+		"duration":     time.Since(start),
+	}).Debug("Audit events listed via gRPC")
+
+	return &pb.ListAuditEventsResponse{
+		Events:        protoEvents,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// recordOperation records the outcome and duration of a finished store
+// operation, overall and for the tenant it was made for
+func recordOperation(ctx context.Context, operation string, tenantID int64, start time.Time, err error) {
+	outcome := metrics.Outcome(err)
+	duration := time.Since(start)
+	metrics.RecordStoreOperation(ctx, operation, outcome, duration)
+	metrics.RecordTenantOperation(ctx, tenantID, operation, outcome, duration)
+}
+
 // Helper functions for converting between proto and data types
 
 func protoToDataCategory(category pb.ItemCategory) data.ItemCategory {
@@ -400,19 +606,72 @@ func dataToProtoStatus(status data.ItemStatus) pb.ItemStatus {
 
 func dataToProtoItem(item data.Item) *pb.Item {
 	return &pb.Item{
-		Id:             item.ItemID,
-		TenantId:       item.TenantID,
-		Name:           item.Name,
-		Description:    item.Description,
-		Price:          item.Price,
-		Category:       dataToProtoCategory(item.Category),
-		Status:         dataToProtoStatus(item.Status),
-		Sku:            item.SKU,
-		InventoryCount: item.InventoryCount,
-		Tags:           item.Tags,
-		CreatedAt:      timestamppb.New(item.CreatedAt),
-		UpdatedAt:      timestamppb.New(item.UpdatedAt),
-		CreatedBy:      item.CreatedBy,
-		UpdatedBy:      item.UpdatedBy,
+		Id:                item.ItemID,
+		TenantId:          item.TenantID,
+		Name:              item.Name,
+		Description:       item.Description,
+		Price:             item.Price,
+		Category:          dataToProtoCategory(item.Category),
+		Status:            dataToProtoStatus(item.Status),
+		Sku:               item.SKU,
+		InventoryCount:    item.InventoryCount,
+		LowStockThreshold: item.LowStockThreshold,
+		Tags:              item.Tags,
+		CreatedAt:         timestamppb.New(item.CreatedAt),
+		UpdatedAt:         timestamppb.New(item.UpdatedAt),
+		CreatedBy:         item.CreatedBy,
+		UpdatedBy:         item.UpdatedBy,
+	}
+}
+
+func dataToProtoTenantConfig(config data.TenantConfig) *pb.TenantConfig {
+	return &pb.TenantConfig{
+		TenantId:                 config.TenantID,
+		DefaultLowStockThreshold: config.DefaultLowStockThreshold,
+		UpdatedAt:                timestamppb.New(config.UpdatedAt),
+		UpdatedBy:                config.UpdatedBy,
+		Quotas:                   dataToProtoQuotas(config.TenantQuotas),
+	}
+}
+
+func dataToProtoAuditEvent(event data.AuditEvent) *pb.AuditEvent {
+	changes := make([]*pb.FieldChange, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		changes = append(changes, &pb.FieldChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	return &pb.AuditEvent{
+		Id:            event.EventID,
+		TenantId:      event.TenantID,
+		ItemId:        event.ItemID,
+		Method:        event.Method,
+		Actor:         event.Actor,
+		Changes:       changes,
+		Canary:        event.Canary,
+		TraceId:       event.TraceID,
+		ClientAddress: event.ClientAddress,
+		Timestamp:     timestamppb.New(event.Timestamp),
+	}
+}
+
+func protoToDataQuotas(quotas *pb.TenantQuotas) data.TenantQuotas {
+	return data.TenantQuotas{
+		MaxItems:             quotas.GetMaxItems(),
+		MaxWritesPerDay:      quotas.GetMaxWritesPerDay(),
+		MaxTagsPerItem:       quotas.GetMaxTagsPerItem(),
+		MaxDescriptionLength: quotas.GetMaxDescriptionLength(),
+	}
+}
+
+func dataToProtoQuotas(quotas data.TenantQuotas) *pb.TenantQuotas {
+	return &pb.TenantQuotas{
+		MaxItems:             quotas.MaxItems,
+		MaxWritesPerDay:      quotas.MaxWritesPerDay,
+		MaxTagsPerItem:       quotas.MaxTagsPerItem,
+		MaxDescriptionLength: quotas.MaxDescriptionLength,
 	}
 }
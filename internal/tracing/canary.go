@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/rinsecrm/store-service/internal/canaryctx"
+)
+
+// canaryAttribute marks spans that served a canary request
+var canaryAttribute = attribute.Key(canaryctx.LogField)
+
+// UnaryServerInterceptor tags the server span of canary calls with the canary
+// PR number and adds it to W3C baggage, so downstream services see it too.
+// It must run after the canary interceptor.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withCanary(ctx), req)
+	}
+}
+
+// StreamServerInterceptor tags the server span of canary streams and adds the
+// canary to W3C baggage
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &canaryServerStream{
+			ServerStream: ss,
+			ctx:          withCanary(ss.Context()),
+		})
+	}
+}
+
+type canaryServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *canaryServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func withCanary(ctx context.Context) context.Context {
+	canary, ok := canaryctx.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(canaryAttribute.String(canary))
+
+	member, err := baggage.NewMember(canaryctx.LogField, canary)
+	if err != nil {
+		return ctx
+	}
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// canaryStartOptions tags spans started for canary requests
+func canaryStartOptions(ctx context.Context, opts []trace.SpanStartOption) []trace.SpanStartOption {
+	if canary, ok := canaryctx.FromContext(ctx); ok {
+		return append(opts, trace.WithAttributes(canaryAttribute.String(canary)))
+	}
+	return opts
+}
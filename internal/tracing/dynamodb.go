@@ -0,0 +1,247 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RetryCountKey records how many times an AWS call was retried
+const RetryCountKey = attribute.Key("aws.retry_count")
+
+// InstrumentDynamoDB adds a client span around every DynamoDB operation made
+// within a traced request; background calls such as health checks are not
+// traced. Pass it to dynamodb.NewFromConfig. Operations that report consumed capacity are
+// asked for the TOTAL so it can be recorded.
+func InstrumentDynamoDB(o *dynamodb.Options) {
+	o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+		// The initialize step runs once per operation, outside the retry loop;
+		// adding last lets the SDK register the operation name first
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DynamoDBSpan", dynamoSpan), middleware.After)
+	})
+}
+
+func dynamoSpan(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	operation := awsmiddleware.GetOperationName(ctx)
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemDynamoDB,
+		semconv.DBOperation(operation),
+		semconv.RPCSystemKey.String("aws-api"),
+		semconv.RPCService("DynamoDB"),
+		semconv.RPCMethod(operation),
+	}
+	if tables := requestTables(in.Parameters); len(tables) > 0 {
+		attrs = append(attrs, semconv.AWSDynamoDBTableNames(tables...))
+	}
+
+	ctx, span := StartSpanWithOptions(ctx, "DynamoDB."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	requestConsumedCapacity(in.Parameters)
+	out, metadata, err := next.HandleInitialize(ctx, in)
+
+	if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+		span.SetAttributes(RetryCountKey.Int(len(results.Results) - 1))
+	}
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+		span.SetAttributes(semconv.AWSRequestID(requestID))
+	}
+	if err != nil {
+		RecordError(span, err)
+		return out, metadata, err
+	}
+	span.SetAttributes(responseAttributes(out.Result)...)
+	return out, metadata, err
+}
+
+// requestTables lists the tables an operation addresses
+func requestTables(params interface{}) []string {
+	var tables []string
+	add := func(name *string) {
+		if name != nil {
+			tables = append(tables, *name)
+		}
+	}
+
+	switch in := params.(type) {
+	case *dynamodb.GetItemInput:
+		add(in.TableName)
+	case *dynamodb.PutItemInput:
+		add(in.TableName)
+	case *dynamodb.UpdateItemInput:
+		add(in.TableName)
+	case *dynamodb.DeleteItemInput:
+		add(in.TableName)
+	case *dynamodb.QueryInput:
+		add(in.TableName)
+	case *dynamodb.ScanInput:
+		add(in.TableName)
+	case *dynamodb.DescribeTableInput:
+		add(in.TableName)
+	case *dynamodb.DeleteTableInput:
+		add(in.TableName)
+	case *dynamodb.BatchGetItemInput:
+		for name := range in.RequestItems {
+			tables = append(tables, name)
+		}
+	case *dynamodb.BatchWriteItemInput:
+		for name := range in.RequestItems {
+			tables = append(tables, name)
+		}
+	case *dynamodb.TransactWriteItemsInput:
+		for _, item := range in.TransactItems {
+			switch {
+			case item.Put != nil:
+				add(item.Put.TableName)
+			case item.Update != nil:
+				add(item.Update.TableName)
+			case item.Delete != nil:
+				add(item.Delete.TableName)
+			case item.ConditionCheck != nil:
+				add(item.ConditionCheck.TableName)
+			}
+		}
+	case *dynamodb.TransactGetItemsInput:
+		for _, item := range in.TransactItems {
+			if item.Get != nil {
+				add(item.Get.TableName)
+			}
+		}
+	}
+
+	sort.Strings(tables)
+	unique := tables[:0]
+	for i, name := range tables {
+		if i == 0 || name != tables[i-1] {
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// requestConsumedCapacity asks for the total consumed capacity unless the
+// caller already chose a level
+func requestConsumedCapacity(params interface{}) {
+	var level *types.ReturnConsumedCapacity
+	switch in := params.(type) {
+	case *dynamodb.GetItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.PutItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.UpdateItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.DeleteItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.QueryInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.ScanInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.BatchGetItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.BatchWriteItemInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.TransactWriteItemsInput:
+		level = &in.ReturnConsumedCapacity
+	case *dynamodb.TransactGetItemsInput:
+		level = &in.ReturnConsumedCapacity
+	}
+	if level != nil && *level == "" {
+		*level = types.ReturnConsumedCapacityTotal
+	}
+}
+
+// consumedCapacity is the JSON form of types.ConsumedCapacity, without the
+// per-index breakdown
+type consumedCapacity struct {
+	TableName          *string  `json:"TableName,omitempty"`
+	CapacityUnits      *float64 `json:"CapacityUnits,omitempty"`
+	ReadCapacityUnits  *float64 `json:"ReadCapacityUnits,omitempty"`
+	WriteCapacityUnits *float64 `json:"WriteCapacityUnits,omitempty"`
+}
+
+// responseAttributes records consumed capacity and item counts
+func responseAttributes(result interface{}) []attribute.KeyValue {
+	var capacity []types.ConsumedCapacity
+	var attrs []attribute.KeyValue
+	one := func(c *types.ConsumedCapacity) {
+		if c != nil {
+			capacity = append(capacity, *c)
+		}
+	}
+
+	switch out := result.(type) {
+	case *dynamodb.GetItemOutput:
+		one(out.ConsumedCapacity)
+		count := 0
+		if out.Item != nil {
+			count = 1
+		}
+		attrs = append(attrs, semconv.AWSDynamoDBCount(count))
+	case *dynamodb.PutItemOutput:
+		one(out.ConsumedCapacity)
+	case *dynamodb.UpdateItemOutput:
+		one(out.ConsumedCapacity)
+	case *dynamodb.DeleteItemOutput:
+		one(out.ConsumedCapacity)
+	case *dynamodb.QueryOutput:
+		one(out.ConsumedCapacity)
+		attrs = append(attrs,
+			semconv.AWSDynamoDBCount(int(out.Count)),
+			semconv.AWSDynamoDBScannedCount(int(out.ScannedCount)),
+		)
+	case *dynamodb.ScanOutput:
+		one(out.ConsumedCapacity)
+		attrs = append(attrs,
+			semconv.AWSDynamoDBCount(int(out.Count)),
+			semconv.AWSDynamoDBScannedCount(int(out.ScannedCount)),
+		)
+	case *dynamodb.BatchGetItemOutput:
+		capacity = out.ConsumedCapacity
+		count := 0
+		for _, items := range out.Responses {
+			count += len(items)
+		}
+		attrs = append(attrs, semconv.AWSDynamoDBCount(count))
+	case *dynamodb.BatchWriteItemOutput:
+		capacity = out.ConsumedCapacity
+	case *dynamodb.TransactWriteItemsOutput:
+		capacity = out.ConsumedCapacity
+	case *dynamodb.TransactGetItemsOutput:
+		capacity = out.ConsumedCapacity
+		attrs = append(attrs, semconv.AWSDynamoDBCount(len(out.Responses)))
+	}
+
+	// The semantic conventions record each ConsumedCapacity as a JSON string
+	if len(capacity) > 0 {
+		encoded := make([]string, 0, len(capacity))
+		for _, c := range capacity {
+			raw, err := json.Marshal(consumedCapacity{
+				TableName:          c.TableName,
+				CapacityUnits:      c.CapacityUnits,
+				ReadCapacityUnits:  c.ReadCapacityUnits,
+				WriteCapacityUnits: c.WriteCapacityUnits,
+			})
+			if err == nil {
+				encoded = append(encoded, string(raw))
+			}
+		}
+		attrs = append(attrs, semconv.AWSDynamoDBConsumedCapacity(encoded...))
+	}
+	return attrs
+}
@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/credentials"
+)
+
+// Exporters supported by Start
+const (
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+	ExporterMemory   = "memory"
+)
+
+// memoryExporter holds spans when the memory exporter is configured
+var memoryExporter *tracetest.InMemoryExporter
+
+// Spans returns the spans ended so far when tracing runs with the memory
+// exporter, or nil otherwise
+func Spans() tracetest.SpanStubs {
+	if memoryExporter == nil {
+		return nil
+	}
+	return memoryExporter.GetSpans()
+}
+
+// ResetSpans discards the spans held by the memory exporter
+func ResetSpans() {
+	if memoryExporter != nil {
+		memoryExporter.Reset()
+	}
+}
+
+// newExporter creates the span exporter for config.Exporter. The OTLP
+// exporters connect lazily, so an unreachable collector never blocks startup.
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, error) {
+	var tlsConfig *tls.Config
+	if !config.Insecure && config.Exporter != ExporterMemory {
+		var err error
+		if tlsConfig, err = newTLSConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	switch config.Exporter {
+	case ExporterMemory:
+		memoryExporter = tracetest.NewInMemoryExporter()
+		return memoryExporter, nil
+
+	case ExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithHeaders(config.Headers)}
+		if strings.Contains(config.TempoHost, "://") {
+			opts = append(opts, otlptracehttp.WithEndpointURL(config.TempoHost))
+		} else {
+			opts = append(opts, otlptracehttp.WithEndpoint(config.TempoHost))
+		}
+		if tlsConfig == nil {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		}
+		return otlptrace.New(ctx, otlptracehttp.NewClient(opts...))
+
+	case ExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithHeaders(config.Headers)}
+		if strings.Contains(config.TempoHost, "://") {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(config.TempoHost))
+		} else {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.TempoHost))
+		}
+		if tlsConfig == nil {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+		return otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", config.Exporter)
+	}
+}
+
+// newTLSConfig trusts the system roots plus CAFile, and presents a client
+// certificate when CertFile and KeyFile are set
+func newTLSConfig(config Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.CAFile != "" {
+		pem, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trace exporter CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trace exporter client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
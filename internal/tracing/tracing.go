@@ -5,15 +5,15 @@ import (
 	"fmt"
 
 	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"go.opentelemetry.io/otel/trace"
-	"google.golang.org/grpc"
 )
 
 var (
@@ -24,55 +24,81 @@ var (
 // Config holds tracing configuration
 type Config struct {
 	ServiceName string
-	TempoHost   string
 	Version     string
+	Environment string // deployment.environment resource attribute
+	Canary      string // PR number when this deployment is a canary
+
+	Exporter    string            // ExporterOTLPGRPC (default), ExporterOTLPHTTP or ExporterMemory
+	TempoHost   string            // Collector host:port or URL; tracing is off if empty, except for the memory exporter
+	Headers     map[string]string // Sent with every export, e.g. for authentication
+	Insecure    bool              // Export without TLS
+	CAFile      string            // Extra CA certificates to trust
+	CertFile    string            // Client certificate for mutual TLS
+	KeyFile     string            // Client key for mutual TLS
+	SampleRatio float64           // Fraction of new traces sampled; callers' sampling decisions are kept
 }
 
 // Start initializes the tracing system
 func Start(config Config) error {
 	tracer = otel.Tracer(config.ServiceName)
+	if config.Exporter == "" {
+		config.Exporter = ExporterOTLPGRPC
+	}
 
-	if config.TempoHost == "" {
+	if config.TempoHost == "" && config.Exporter != ExporterMemory {
 		logging.Info("No Tempo host configured, tracing will be no-op")
 		return nil
 	}
 
 	ctx := context.Background()
-	client := otlptracegrpc.NewClient(
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithEndpoint(config.TempoHost),
-		otlptracegrpc.WithDialOption(grpc.WithBlock()),
-	)
-	exp, err := otlptrace.New(ctx, client)
+	exp, err := newExporter(ctx, config)
 	if err != nil {
 		return fmt.Errorf("failed to create exporter: %w", err)
 	}
-	logging.Info("Created OTLP Exporter")
-
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			// the service name used to display traces in backends
-			semconv.ServiceNameKey.String(config.ServiceName),
-			semconv.ServiceVersionKey.String(config.Version),
-		),
-	)
+	logging.WithFields(logrus.Fields{
+		"exporter": config.Exporter,
+		"endpoint": config.TempoHost,
+		"insecure": config.Insecure,
+	}).Info("Created trace exporter")
+
+	attrs := []attribute.KeyValue{
+		// the service name used to display traces in backends
+		semconv.ServiceNameKey.String(config.ServiceName),
+		semconv.ServiceVersionKey.String(config.Version),
+	}
+	if config.Environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironmentKey.String(config.Environment))
+	}
+	if config.Canary != "" {
+		attrs = append(attrs, canaryAttribute.String(config.Canary))
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(attrs...))
 	if err != nil {
 		return fmt.Errorf("failed to create resource: %w", err)
 	}
 	logging.Info("Created OTLP Resource")
 
-	bsp := sdktrace.NewBatchSpanProcessor(exp)
+	// Spans go straight to the memory exporter so tests see them as soon as
+	// they end
+	processor := sdktrace.NewBatchSpanProcessor(exp)
+	if config.Exporter == ExporterMemory {
+		processor = sdktrace.NewSimpleSpanProcessor(exp)
+	}
 	tracerProvider = sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
 		sdktrace.WithResource(res),
-		sdktrace.WithSpanProcessor(bsp),
+		sdktrace.WithSpanProcessor(processor),
 	)
 
-	// set global propagator to tracecontext (the default is no-op).
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	// set global propagator to tracecontext and baggage (the default is no-op).
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
 	otel.SetTracerProvider(tracerProvider)
+	tracer = tracerProvider.Tracer(config.ServiceName)
 
-	logging.Info("Tracing initialized successfully")
+	logging.WithField("sample_ratio", config.SampleRatio).Info("Tracing initialized successfully")
 	return nil
 }
 
@@ -88,13 +114,25 @@ func Stop(ctx context.Context) error {
 	return nil
 }
 
+// Span attributes identifying the tenant and item a request addresses
+const (
+	TenantIDKey = attribute.Key("tenant_id")
+	ItemIDKey   = attribute.Key("item_id")
+)
+
+// RecordError records err as a span event and marks the span failed
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
 // StartSpan starts a new span
 func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
 	if tracer == nil {
 		// Return no-op span if tracing is not configured
 		return ctx, trace.SpanFromContext(ctx)
 	}
-	return tracer.Start(ctx, name)
+	return tracer.Start(ctx, name, canaryStartOptions(ctx, nil)...)
 }
 
 // StartSpanWithOptions starts a new span with additional options
@@ -103,7 +141,7 @@ func StartSpanWithOptions(ctx context.Context, name string, opts ...trace.SpanSt
 		// Return no-op span if tracing is not configured
 		return ctx, trace.SpanFromContext(ctx)
 	}
-	return tracer.Start(ctx, name, opts...)
+	return tracer.Start(ctx, name, canaryStartOptions(ctx, opts)...)
 }
 
 // GetTracer returns the current tracer instance
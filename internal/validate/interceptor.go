@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor rejects requests that break the field rules declared
+// in the proto with InvalidArgument, before they reach the handler
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(req, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor checks every message received on a stream
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingServerStream{ServerStream: ss, method: info.FullMethod})
+	}
+}
+
+type validatingServerStream struct {
+	grpc.ServerStream
+	method string
+}
+
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return check(m, s.method)
+}
+
+func check(req interface{}, fullMethod string) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	return Message(msg).Err(serviceName(fullMethod))
+}
+
+// serviceName extracts the service from "/package.Service/Method"; it is the
+// ErrorInfo domain, matching the errors handlers return
+func serviceName(fullMethod string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service
+}
@@ -0,0 +1,220 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// ReasonInvalidFields is the ErrorInfo reason of a request rejected for
+// breaking one or more field rules
+const ReasonInvalidFields = "INVALID_FIELDS"
+
+// Violations collects every invalid field of a request so they can be
+// reported together
+type Violations []*errdetails.BadRequest_FieldViolation
+
+// Add records that field breaks a rule. The description completes a sentence
+// that starts with the field name, e.g. "is required".
+func (v *Violations) Add(field, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+// Err returns an InvalidArgument status with a BadRequest detail listing every
+// violation and an ErrorInfo in domain, or nil if there are none
+func (v Violations) Err(domain string) error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(v))
+	fields := make([]string, 0, len(v))
+	for _, violation := range v {
+		messages = append(messages, violation.Field+" "+violation.Description)
+		fields = append(fields, violation.Field)
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(messages, "; "))
+	withDetails, err := st.WithDetails(
+		&errdetails.BadRequest{FieldViolations: v},
+		&errdetails.ErrorInfo{
+			Reason:   ReasonInvalidFields,
+			Domain:   domain,
+			Metadata: map[string]string{"fields": strings.Join(fields, ",")},
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// Message checks msg against the (store.v1.rules) options on its fields,
+// descending into set message fields, and returns every violation found
+func Message(msg proto.Message) Violations {
+	var v Violations
+	checkMessage("", msg.ProtoReflect(), &v)
+	return v
+}
+
+func checkMessage(path string, m protoreflect.Message, v *Violations) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		rules := fieldRules(fd)
+		switch {
+		case fd.IsList():
+			list := m.Get(fd).List()
+			if rules != nil {
+				if rules.GetMaxItems() > 0 && list.Len() > int(rules.GetMaxItems()) {
+					v.Add(fieldPath, fmt.Sprintf("must have at most %d items", rules.GetMaxItems()))
+				}
+				if items := rules.GetItems(); items != nil {
+					for j := 0; j < list.Len(); j++ {
+						checkValue(fmt.Sprintf("%s[%d]", fieldPath, j), fd, list.Get(j), items, v)
+					}
+				}
+			}
+			if fd.Message() != nil {
+				for j := 0; j < list.Len(); j++ {
+					checkMessage(fmt.Sprintf("%s[%d]", fieldPath, j), list.Get(j).Message(), v)
+				}
+			}
+		case fd.IsMap():
+			// Maps carry no rules
+		case fd.Message() != nil:
+			if !m.Has(fd) {
+				if rules.GetRequired() {
+					v.Add(fieldPath, "is required")
+				}
+				continue
+			}
+			checkMessage(fieldPath, m.Get(fd).Message(), v)
+		case rules != nil:
+			checkValue(fieldPath, fd, m.Get(fd), rules, v)
+		}
+	}
+}
+
+// checkValue checks a single scalar value, either a field or a list element
+func checkValue(path string, fd protoreflect.FieldDescriptor, value protoreflect.Value, rules *pb.FieldRules, v *Violations) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		checkString(path, value.String(), rules, v)
+	case protoreflect.BytesKind:
+		if rules.GetRequired() && len(value.Bytes()) == 0 {
+			v.Add(path, "is required")
+		}
+	case protoreflect.EnumKind:
+		number := value.Enum()
+		if rules.GetDefinedOnly() && fd.Enum().Values().ByNumber(number) == nil {
+			v.Add(path, "must be a defined "+string(fd.Enum().Name())+" value")
+		} else if rules.GetRequired() && number == 0 {
+			v.Add(path, "is required")
+		}
+	case protoreflect.BoolKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		// No scalar rules apply
+	default:
+		checkNumber(path, number(fd.Kind(), value), rules, v)
+	}
+}
+
+func checkString(path, s string, rules *pb.FieldRules, v *Violations) {
+	if s == "" {
+		if rules.GetRequired() || rules.GetMinLen() > 0 {
+			v.Add(path, "is required")
+		}
+		return
+	}
+
+	length := utf8.RuneCountInString(s)
+	if rules.GetMinLen() > 0 && length < int(rules.GetMinLen()) {
+		v.Add(path, fmt.Sprintf("must be at least %d characters", rules.GetMinLen()))
+	}
+	if rules.GetMaxLen() > 0 && length > int(rules.GetMaxLen()) {
+		v.Add(path, fmt.Sprintf("must be at most %d characters", rules.GetMaxLen()))
+	}
+	if rules.GetPattern() != "" {
+		re, err := pattern(rules.GetPattern())
+		if err != nil || !re.MatchString(s) {
+			v.Add(path, "must match "+rules.GetPattern())
+		}
+	}
+}
+
+func checkNumber(path string, n float64, rules *pb.FieldRules, v *Violations) {
+	switch {
+	case rules.GetRequired() && n == 0:
+		v.Add(path, "must not be zero")
+	case rules.Gt != nil && !(n > *rules.Gt):
+		if *rules.Gt == 0 {
+			v.Add(path, "must be positive")
+		} else {
+			v.Add(path, "must be greater than "+formatNumber(*rules.Gt))
+		}
+	case rules.Gte != nil && !(n >= *rules.Gte):
+		if *rules.Gte == 0 {
+			v.Add(path, "cannot be negative")
+		} else {
+			v.Add(path, "must be at least "+formatNumber(*rules.Gte))
+		}
+	case rules.Lt != nil && !(n < *rules.Lt):
+		v.Add(path, "must be less than "+formatNumber(*rules.Lt))
+	case rules.Lte != nil && !(n <= *rules.Lte):
+		v.Add(path, "must be at most "+formatNumber(*rules.Lte))
+	}
+}
+
+func number(kind protoreflect.Kind, value protoreflect.Value) float64 {
+	switch kind {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(value.Uint())
+	default:
+		return float64(value.Int())
+	}
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *pb.FieldRules {
+	opts := fd.Options()
+	if opts == nil || !proto.HasExtension(opts, pb.E_Rules) {
+		return nil
+	}
+	return proto.GetExtension(opts, pb.E_Rules).(*pb.FieldRules)
+}
+
+// patterns caches compiled rule patterns by source
+var patterns sync.Map
+
+func pattern(source string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(source); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(source)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(source, re)
+	return re, nil
+}
@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/rinsecrm/store-service/proto/go"
+)
+
+// violatedFields returns the fields listed in err's BadRequest detail
+func violatedFields(err error) []string {
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	return fields
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        proto.Message
+		wantFields []string
+	}{
+		{
+			name: "valid create",
+			req: &pb.CreateItemRequest{
+				TenantId: 1,
+				Name:     "Widget",
+				Sku:      "WID-001",
+				Tags:     []string{"blue", "small"},
+			},
+		},
+		{
+			name:       "tenant must be positive",
+			req:        &pb.GetItemRequest{TenantId: 0, Id: "widget"},
+			wantFields: []string{"tenant_id"},
+		},
+		{
+			name:       "required string",
+			req:        &pb.GetItemRequest{TenantId: 1},
+			wantFields: []string{"id"},
+		},
+		{
+			name: "every violation is reported",
+			req: &pb.CreateItemRequest{
+				TenantId: 1,
+				Name:     strings.Repeat("n", 201),
+				Price:    -1,
+				Sku:      "-bad",
+				Category: pb.ItemCategory(99),
+			},
+			wantFields: []string{"name", "price", "category", "sku"},
+		},
+		{
+			name:       "too many tags",
+			req:        &pb.CreateItemRequest{TenantId: 1, Name: "Widget", Tags: strings.Split(strings.Repeat("tag,", 50)+"tag", ",")},
+			wantFields: []string{"tags"},
+		},
+		{
+			name:       "tag rules apply to each item",
+			req:        &pb.CreateItemRequest{TenantId: 1, Name: "Widget", Tags: []string{"ok", "", strings.Repeat("t", 51)}},
+			wantFields: []string{"tags[1]", "tags[2]"},
+		},
+		{
+			name:       "non-zero quantity change",
+			req:        &pb.UpdateInventoryRequest{TenantId: 1, ItemId: "widget"},
+			wantFields: []string{"quantity_change"},
+		},
+		{
+			name:       "nested message fields",
+			req:        &pb.SetTenantQuotasRequest{TenantId: 1, Quotas: &pb.TenantQuotas{MaxItems: -1}},
+			wantFields: []string{"quotas.max_items"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, violation := range Message(tt.req) {
+				got = append(got, violation.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("Message() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      any
+		wantCode codes.Code
+	}{
+		{name: "valid request", req: &pb.UpdateInventoryRequest{TenantId: 1, ItemId: "widget", QuantityChange: -2}},
+		{name: "invalid request", req: &pb.UpdateInventoryRequest{TenantId: 1, QuantityChange: -2}, wantCode: codes.InvalidArgument},
+		{name: "not a proto message", req: "raw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				return req, nil
+			}
+
+			_, err := UnaryServerInterceptor()(context.Background(), tt.req,
+				&grpc.UnaryServerInfo{FullMethod: pb.StoreService_UpdateInventory_FullMethodName}, handler)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if called != (err == nil) {
+				t.Errorf("handler called = %t for error %v", called, err)
+			}
+			if err == nil {
+				return
+			}
+			if fields := violatedFields(err); len(fields) != 1 || fields[0] != "item_id" {
+				t.Errorf("violated fields = %v, want [item_id]", fields)
+			}
+			var info *errdetails.ErrorInfo
+			for _, detail := range status.Convert(err).Details() {
+				if d, ok := detail.(*errdetails.ErrorInfo); ok {
+					info = d
+				}
+			}
+			if info == nil || info.Reason != ReasonInvalidFields || info.Domain != "store.v1.StoreService" {
+				t.Errorf("ErrorInfo = %v, want %s in store.v1.StoreService", info, ReasonInvalidFields)
+			}
+		})
+	}
+}
+
+func TestViolationsErrEmpty(t *testing.T) {
+	var v Violations
+	if err := v.Err("store.v1.StoreService"); err != nil {
+		t.Errorf("Err() = %v, want nil without violations", err)
+	}
+}
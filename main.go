@@ -12,17 +12,28 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/rinsecrm/store-service/core/logging"
+	"github.com/rinsecrm/store-service/internal/admin"
+	"github.com/rinsecrm/store-service/internal/audit"
+	"github.com/rinsecrm/store-service/internal/auth"
 	"github.com/rinsecrm/store-service/internal/canaryctx"
 	"github.com/rinsecrm/store-service/internal/data"
+	"github.com/rinsecrm/store-service/internal/gateway"
+	"github.com/rinsecrm/store-service/internal/health"
 	"github.com/rinsecrm/store-service/internal/metrics"
+	"github.com/rinsecrm/store-service/internal/mirror"
+	"github.com/rinsecrm/store-service/internal/ratelimit"
 	"github.com/rinsecrm/store-service/internal/server"
 	"github.com/rinsecrm/store-service/internal/tracing"
+	"github.com/rinsecrm/store-service/internal/validate"
 	pb "github.com/rinsecrm/store-service/proto/go"
 )
 
@@ -31,6 +42,10 @@ var (
 	version = "dev"
 )
 
+// storageHealthService is the gRPC health service name reporting whether the
+// storage circuit breaker lets calls through
+const storageHealthService = "store.v1.StoreService.storage"
+
 // Config holds the application configuration
 type Config struct {
 	Port            int    `envconfig:"PORT" default:"8080"`
@@ -40,11 +55,110 @@ type Config struct {
 	LocalDebug      bool   `envconfig:"LOCAL_DEBUG" default:"false"`
 	DynamoEndpoint  string `envconfig:"DYNAMODB_ENDPOINT" default:""`
 	TempoHost       string `envconfig:"TEMPO_HOST" default:""`
+
+	// Deployment identity, recorded as trace resource attributes
+	Environment string `envconfig:"DEPLOYMENT_ENVIRONMENT" default:""`
+	CanaryPR    string `envconfig:"CANARY_PR" default:""`
+
+	// Trace export and sampling
+	TracingExporter    string            `envconfig:"TRACING_EXPORTER" default:"otlp-grpc"`
+	TracingHeaders     map[string]string `envconfig:"TRACING_HEADERS" default:""`
+	TracingInsecure    bool              `envconfig:"TRACING_INSECURE" default:"true"`
+	TracingCAFile      string            `envconfig:"TRACING_CA_FILE" default:""`
+	TracingCertFile    string            `envconfig:"TRACING_CERT_FILE" default:""`
+	TracingKeyFile     string            `envconfig:"TRACING_KEY_FILE" default:""`
+	TracingSampleRatio float64           `envconfig:"TRACING_SAMPLE_RATIO" default:"1.0"`
+
+	// Canary data isolation policy per table, e.g. "store-items:prefix".
+	// Tables not listed share production data with canaries.
+	CanaryIsolation map[string]string `envconfig:"CANARY_ISOLATION" default:""`
+
+	// REST/JSON gateway
+	GatewayEnabled bool `envconfig:"GATEWAY_ENABLED" default:"true"`
+	GatewayPort    int  `envconfig:"GATEWAY_PORT" default:"8081"`
+
+	// Server reflection for grpcurl and storectl discovery
+	ReflectionEnabled bool `envconfig:"REFLECTION_ENABLED" default:"false"`
+
+	// Log redaction and sampling
+	LogRedactFields          []string      `envconfig:"LOG_REDACT_FIELDS" default:"name,description,created_by,updated_by"`
+	LogRedactPatterns        bool          `envconfig:"LOG_REDACT_PATTERNS" default:"true"`
+	LogSamplePerSecond       int           `envconfig:"LOG_SAMPLE_PER_SECOND" default:"20"`
+	LogSampleSummaryInterval time.Duration `envconfig:"LOG_SAMPLE_SUMMARY_INTERVAL" default:"10s"`
+
+	// Longest time a single debug logging rule may stay active
+	LogDebugMaxDuration time.Duration `envconfig:"LOG_DEBUG_MAX_DURATION" default:"1h"`
+
+	// Distinct canary PR numbers labelled in metrics before falling back to "other"
+	MetricsMaxCanaryLabels int `envconfig:"METRICS_MAX_CANARY_LABELS" default:"20"`
+
+	// Tenant metric labels: the allowlisted tenants if set, otherwise the
+	// busiest tenants re-ranked every interval; everyone else is "other"
+	MetricsTenantTopN         int           `envconfig:"METRICS_TENANT_TOP_N" default:"20"`
+	MetricsTenantRankInterval time.Duration `envconfig:"METRICS_TENANT_RANK_INTERVAL" default:"1m"`
+	MetricsTenantAllowlist    []string      `envconfig:"METRICS_TENANT_ALLOWLIST" default:""`
+
+	// How often the per-tenant item count and stock value gauges are refreshed
+	MetricsInventoryInterval time.Duration `envconfig:"METRICS_INVENTORY_INTERVAL" default:"5m"`
+
+	// Store resilience: each DynamoDB HTTP request and each store operation
+	// attempt is bounded, retry-safe operations are retried with jittered
+	// backoff, and consecutive failures open a circuit breaker
+	DynamoRequestTimeout   time.Duration            `envconfig:"DYNAMODB_REQUEST_TIMEOUT" default:"2s"`
+	StoreTimeout           time.Duration            `envconfig:"STORE_TIMEOUT" default:"5s"`
+	StoreOperationTimeouts map[string]time.Duration `envconfig:"STORE_OPERATION_TIMEOUTS" default:"list_items:15s,list_low_stock_items:15s,list_audit_events:15s,inventory_stats:2m"`
+	StoreRetryMaxAttempts  int                      `envconfig:"STORE_RETRY_MAX_ATTEMPTS" default:"3"`
+	StoreRetryBaseDelay    time.Duration            `envconfig:"STORE_RETRY_BASE_DELAY" default:"50ms"`
+	StoreRetryMaxDelay     time.Duration            `envconfig:"STORE_RETRY_MAX_DELAY" default:"1s"`
+	StoreBreakerThreshold  int                      `envconfig:"STORE_BREAKER_THRESHOLD" default:"5"`
+	StoreBreakerCooldown   time.Duration            `envconfig:"STORE_BREAKER_COOLDOWN" default:"10s"`
+
+	// GetItem read-through cache; a size of 0 disables it. The TTL bounds
+	// staleness after writes made through other instances.
+	ItemCacheSize int           `envconfig:"ITEM_CACHE_SIZE" default:"10000"`
+	ItemCacheTTL  time.Duration `envconfig:"ITEM_CACHE_TTL" default:"10s"`
+
+	// Readiness checks
+	HealthCheckInterval time.Duration `envconfig:"HEALTH_CHECK_INTERVAL" default:"10s"`
+	HealthCheckTimeout  time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+
+	// Authentication
+	AuthEnabled        bool     `envconfig:"AUTH_ENABLED" default:"false"`
+	AuthJWKSFile       string   `envconfig:"AUTH_JWKS_FILE" default:""`
+	AuthPublicKeyFiles []string `envconfig:"AUTH_PUBLIC_KEY_FILES" default:""`
+	AuthIssuer         string   `envconfig:"AUTH_ISSUER" default:""`
+	AuthAudience       string   `envconfig:"AUTH_AUDIENCE" default:""`
+	AuthTenantClaim    string   `envconfig:"AUTH_TENANT_CLAIM" default:"tenant_id"`
+	AuthRolesClaim     string   `envconfig:"AUTH_ROLES_CLAIM" default:"roles"`
+	AuthExemptMethods  []string `envconfig:"AUTH_EXEMPT_METHODS" default:"/grpc.health.v1.Health/"`
+	// Operator tokens may act on any tenant; only they may call the operator methods
+	AuthOperatorRole    string   `envconfig:"AUTH_OPERATOR_ROLE" default:"operator"`
+	AuthOperatorMethods []string `envconfig:"AUTH_OPERATOR_METHODS" default:"/store.v1.AdminService/,/store.v1.StoreService/SetTenantQuotas,/store.v1.StoreService/RecountItems"`
+
+	// Authorization (requires authentication)
+	AuthzEnabled    bool   `envconfig:"AUTHZ_ENABLED" default:"false"`
+	AuthzPolicyFile string `envconfig:"AUTHZ_POLICY_FILE" default:""`
+
+	// Per-tenant rate limiting
+	RateLimitEnabled    bool    `envconfig:"RATE_LIMIT_ENABLED" default:"false"`
+	RateLimitRate       float64 `envconfig:"RATE_LIMIT_RATE" default:"50"`
+	RateLimitBurst      int     `envconfig:"RATE_LIMIT_BURST" default:"100"`
+	RateLimitConfigFile string  `envconfig:"RATE_LIMIT_CONFIG_FILE" default:""`
+
+	// Mirroring of sampled read-only calls to a canary for response comparison
+	MirrorEnabled    bool          `envconfig:"MIRROR_ENABLED" default:"false"`
+	MirrorEndpoint   string        `envconfig:"MIRROR_ENDPOINT" default:""`
+	MirrorSampleRate float64       `envconfig:"MIRROR_SAMPLE_RATE" default:"0.1"`
+	MirrorMethods    []string      `envconfig:"MIRROR_METHODS" default:"/store.v1.StoreService/GetItem,/store.v1.StoreService/ListItems"`
+	MirrorTimeout    time.Duration `envconfig:"MIRROR_TIMEOUT" default:"2s"`
+	MirrorWorkers    int           `envconfig:"MIRROR_WORKERS" default:"4"`
+	MirrorQueueSize  int           `envconfig:"MIRROR_QUEUE_SIZE" default:"100"`
 }
 
 func main() {
-	// Initialize logging
+	// Initialize logging; request-scoped entries carry the canary PR number
 	logging.SetStandardFields(name, version)
+	logging.AddContextFields(canaryctx.LogFields)
 
 	// Load environment configuration
 	var cfg Config
@@ -63,11 +177,26 @@ func main() {
 		logging.Info("Debug logging enabled")
 	}
 
+	logging.SetRedaction(cfg.LogRedactFields, cfg.LogRedactPatterns)
+	logging.SetSampling(cfg.LogSamplePerSecond, cfg.LogSampleSummaryInterval)
+	logging.SetMaxDebugDuration(cfg.LogDebugMaxDuration)
+	metrics.SetMaxCanaryLabels(cfg.MetricsMaxCanaryLabels)
+	metrics.SetTenantLabels(cfg.MetricsTenantTopN, cfg.MetricsTenantRankInterval, cfg.MetricsTenantAllowlist)
+
 	// Initialize tracing
 	if err := tracing.Start(tracing.Config{
 		ServiceName: name,
-		TempoHost:   cfg.TempoHost,
 		Version:     version,
+		Environment: cfg.Environment,
+		Canary:      cfg.CanaryPR,
+		Exporter:    cfg.TracingExporter,
+		TempoHost:   cfg.TempoHost,
+		Headers:     cfg.TracingHeaders,
+		Insecure:    cfg.TracingInsecure,
+		CAFile:      cfg.TracingCAFile,
+		CertFile:    cfg.TracingCertFile,
+		KeyFile:     cfg.TracingKeyFile,
+		SampleRatio: cfg.TracingSampleRatio,
 	}); err != nil {
 		logging.WithError(err).Error("Failed to initialize tracing")
 	}
@@ -79,6 +208,10 @@ func main() {
 		"region":          cfg.Region,
 		"local_debug":     cfg.LocalDebug,
 		"dynamo_endpoint": cfg.DynamoEndpoint,
+		"auth_enabled":    cfg.AuthEnabled,
+		"authz_enabled":   cfg.AuthzEnabled,
+		"rate_limit":      cfg.RateLimitEnabled,
+		"gateway_enabled": cfg.GatewayEnabled,
 	}).Info("Starting store service with configuration")
 
 	// Initialize AWS DynamoDB client
@@ -90,18 +223,30 @@ func main() {
 	// Override endpoint for local development
 	var dynamoClient *dynamodb.Client
 	if cfg.DynamoEndpoint != "" {
-		dynamoClient = dynamodb.NewFromConfig(awsConfig, func(o *dynamodb.Options) {
+		dynamoClient = dynamodb.NewFromConfig(awsConfig, tracing.InstrumentDynamoDB, metrics.InstrumentDynamoDB, data.ClientOptions(cfg.DynamoRequestTimeout), func(o *dynamodb.Options) {
 			o.BaseEndpoint = &cfg.DynamoEndpoint
 		})
 		logging.WithField("endpoint", cfg.DynamoEndpoint).Info("Using custom DynamoDB endpoint")
 	} else {
-		dynamoClient = dynamodb.NewFromConfig(awsConfig)
+		dynamoClient = dynamodb.NewFromConfig(awsConfig, tracing.InstrumentDynamoDB, metrics.InstrumentDynamoDB, data.ClientOptions(cfg.DynamoRequestTimeout))
 	}
 
-	// Start metrics server
+	// Readiness follows DynamoDB reachability; the service reports NOT_SERVING
+	// until the first DescribeTable succeeds
+	healthChecker := health.NewChecker(cfg.HealthCheckInterval, cfg.HealthCheckTimeout, pb.StoreService_ServiceDesc.ServiceName)
+	healthChecker.AddCheck("dynamodb", health.DynamoTableCheck(dynamoClient, cfg.DynamoTableName))
+	healthCtx, stopHealthChecks := context.WithCancel(context.Background())
+	go healthChecker.Run(healthCtx)
+
+	// Start metrics server with liveness and readiness probes; the logging
+	// admin endpoints are added below once operators can be authenticated
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", metrics.MetricsHandler())
+	metricsMux.Handle("/healthz", healthChecker.LivenessHandler())
+	metricsMux.Handle("/readyz", healthChecker.ReadinessHandler())
 	metricsServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.MetricsPort),
-		Handler: metrics.MetricsHandler(),
+		Handler: metricsMux,
 	}
 	go func() {
 		logging.WithField("port", cfg.MetricsPort).Info("Starting metrics server")
@@ -111,19 +256,182 @@ func main() {
 	}()
 
 	// Initialize store
-	storeService := data.NewDynamoStore(dynamoClient, cfg.DynamoTableName)
+	canaryIsolation, err := data.ParseCanaryIsolation(cfg.CanaryIsolation[cfg.DynamoTableName])
+	if err != nil {
+		logging.WithError(err).Fatal("Invalid CANARY_ISOLATION")
+	}
+	logging.WithFields(logrus.Fields{
+		"table":     cfg.DynamoTableName,
+		"isolation": canaryIsolation,
+	}).Info("Canary data isolation configured")
+	dynamoStore := data.NewDynamoStore(dynamoClient, cfg.DynamoTableName, canaryIsolation)
+	// Store decorators, innermost first: a span per attempt, resilience, and
+	// the item cache outermost so hits are served even while the circuit is open
+	var storeService data.StoreInterface = data.NewTracedStore(dynamoStore)
+	resilientStore := data.NewResilientStore(storeService, data.ResilienceConfig{
+		Timeout:           cfg.StoreTimeout,
+		OperationTimeouts: cfg.StoreOperationTimeouts,
+		MaxAttempts:       cfg.StoreRetryMaxAttempts,
+		BaseDelay:         cfg.StoreRetryBaseDelay,
+		MaxDelay:          cfg.StoreRetryMaxDelay,
+		BreakerThreshold:  cfg.StoreBreakerThreshold,
+		BreakerCooldown:   cfg.StoreBreakerCooldown,
+	})
+	metrics.RegisterResilienceStats(resilientStore.Stats)
+	// Reported on its own health service name rather than gating readiness:
+	// an open breaker on every replica at once must not drain them all
+	healthChecker.AddStatusCheck(storageHealthService, resilientStore.BreakerCheck)
+	storeService = resilientStore
+	if cfg.ItemCacheSize > 0 {
+		cachedStore := data.NewCachedStore(storeService, data.CacheConfig{
+			Size:            cfg.ItemCacheSize,
+			TTL:             cfg.ItemCacheTTL,
+			CanaryIsolation: canaryIsolation,
+		})
+		metrics.RegisterCacheStats(cachedStore.Stats)
+		storeService = cachedStore
+		logging.WithFields(logrus.Fields{
+			"size": cfg.ItemCacheSize,
+			"ttl":  cfg.ItemCacheTTL,
+		}).Info("Item cache enabled")
+	}
+
+	// Per-tenant inventory gauges are collected in the background so scrapes
+	// never scan the table, by whichever replica holds the collector lease
+	hostname, _ := os.Hostname()
+	collectorHolder := hostname + "/" + uuid.New().String()
+	collectorCtx, stopCollector := context.WithCancel(context.Background())
+	go metrics.RunInventoryCollector(collectorCtx, cfg.MetricsInventoryInterval, storeService, collectorHolder)
+
+	// Build the interceptor chains; authentication runs after metrics so
+	// rejected calls are still counted
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		canaryctx.UnaryServerInterceptor(),
+		tracing.UnaryServerInterceptor(),
+		logging.UnaryServerInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		canaryctx.StreamServerInterceptor(),
+		tracing.StreamServerInterceptor(),
+		logging.StreamServerInterceptor(),
+		metrics.StreamServerInterceptor(),
+	}
+
+	if cfg.AuthEnabled {
+		authenticator, err := auth.NewAuthenticator(auth.Config{
+			JWKSFile:        cfg.AuthJWKSFile,
+			PublicKeyFiles:  cfg.AuthPublicKeyFiles,
+			Issuer:          cfg.AuthIssuer,
+			Audience:        cfg.AuthAudience,
+			TenantClaim:     cfg.AuthTenantClaim,
+			RolesClaim:      cfg.AuthRolesClaim,
+			ExemptMethods:   cfg.AuthExemptMethods,
+			OperatorRole:    cfg.AuthOperatorRole,
+			OperatorMethods: cfg.AuthOperatorMethods,
+		})
+		if err != nil {
+			logging.WithError(err).Fatal("Failed to initialize authentication")
+		}
+		unaryInterceptors = append(unaryInterceptors, authenticator.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, authenticator.StreamServerInterceptor())
+		metricsMux.Handle("/admin/logging", authenticator.RequireOperator(logging.LevelHandler()))
+		metricsMux.Handle("/admin/logging/debug", authenticator.RequireOperator(logging.DebugHandler()))
+		logging.Info("JWT authentication enabled")
 
-	// Create gRPC server with canary, metrics, and tracing interceptors
+		if cfg.AuthzEnabled {
+			policy := auth.DefaultPolicy()
+			if cfg.AuthzPolicyFile != "" {
+				policy, err = auth.LoadPolicy(cfg.AuthzPolicyFile)
+				if err != nil {
+					logging.WithError(err).Fatal("Failed to load authorization policy")
+				}
+			}
+			authorizer := auth.NewAuthorizer(policy, cfg.AuthExemptMethods)
+			unaryInterceptors = append(unaryInterceptors, authorizer.UnaryServerInterceptor())
+			streamInterceptors = append(streamInterceptors, authorizer.StreamServerInterceptor())
+			logging.WithField("policy_file", cfg.AuthzPolicyFile).Info("Role-based authorization enabled")
+		}
+	} else {
+		if cfg.AuthzEnabled {
+			logging.Fatal("AUTHZ_ENABLED requires AUTH_ENABLED")
+		}
+		logging.Warn("JWT authentication disabled, tenant_id is trusted from requests and the HTTP logging admin endpoints are not served")
+	}
+
+	// Audit caller details are read from the canary and auth interceptors
+	unaryInterceptors = append(unaryInterceptors, audit.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, audit.StreamServerInterceptor())
+
+	// Rate limiting runs after authentication so limits apply to the verified tenant
+	if cfg.RateLimitEnabled {
+		rateLimitConfig, err := ratelimit.LoadConfig(cfg.RateLimitConfigFile, ratelimit.Limit{
+			Rate:  cfg.RateLimitRate,
+			Burst: cfg.RateLimitBurst,
+		})
+		if err != nil {
+			logging.WithError(err).Fatal("Failed to load rate limit config")
+		}
+		limiter := ratelimit.NewLimiter(rateLimitConfig)
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+		logging.WithFields(logrus.Fields{
+			"rate":        cfg.RateLimitRate,
+			"burst":       cfg.RateLimitBurst,
+			"config_file": cfg.RateLimitConfigFile,
+		}).Info("Per-tenant rate limiting enabled")
+	}
+
+	// Validation of the proto field rules runs after rate limiting so
+	// rejected requests still count against the tenant's limit
+	unaryInterceptors = append(unaryInterceptors, validate.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, validate.StreamServerInterceptor())
+
+	// Mirroring runs last so it sees the same request the handler served
+	var trafficMirror *mirror.Mirror
+	if cfg.MirrorEnabled {
+		trafficMirror, err = mirror.New(mirror.Config{
+			Endpoint:   cfg.MirrorEndpoint,
+			SampleRate: cfg.MirrorSampleRate,
+			Methods:    cfg.MirrorMethods,
+			Timeout:    cfg.MirrorTimeout,
+			Workers:    cfg.MirrorWorkers,
+			QueueSize:  cfg.MirrorQueueSize,
+			KeepDiffs:  50,
+		})
+		if err != nil {
+			logging.WithError(err).Fatal("Failed to initialize traffic mirror")
+		}
+		unaryInterceptors = append(unaryInterceptors, trafficMirror.UnaryServerInterceptor())
+		metricsMux.Handle("/debug/mirror", trafficMirror.DiffsHandler())
+		logging.WithFields(logrus.Fields{
+			"endpoint":    cfg.MirrorEndpoint,
+			"sample_rate": cfg.MirrorSampleRate,
+			"methods":     cfg.MirrorMethods,
+		}).Info("Traffic mirroring enabled")
+	}
+
+	// Create gRPC server with canary, metrics, auth, and tracing interceptors
 	grpcServer := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
-		grpc.ChainUnaryInterceptor(
-			canaryctx.UnaryServerInterceptor(),
-			metrics.UnaryServerInterceptor(),
-		),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
 
-	// Register the store service
+	// Register the store and health services
 	pb.RegisterStoreServiceServer(grpcServer, server.NewStoreServiceServer(storeService))
+	healthpb.RegisterHealthServer(grpcServer, healthChecker.Server())
+
+	// Like the /admin/logging endpoints, the admin service affects every tenant
+	// and is only served when operators can be authenticated
+	if cfg.AuthEnabled {
+		pb.RegisterAdminServiceServer(grpcServer, admin.NewAdminServiceServer())
+	}
+
+	if cfg.ReflectionEnabled {
+		reflection.Register(grpcServer)
+		logging.Info("gRPC server reflection enabled")
+	}
 
 	// Start listening
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
@@ -136,6 +444,34 @@ func main() {
 		"dynamo_table": cfg.DynamoTableName,
 	}).Info("Store service listening")
 
+	// Start the REST/JSON gateway, which calls back into the gRPC server in
+	// process so REST requests pass through the same interceptors
+	var gatewayServer *http.Server
+	if cfg.GatewayEnabled {
+		gatewayListener := gateway.NewListener()
+		go func() {
+			if err := grpcServer.Serve(gatewayListener); err != nil {
+				logging.WithError(err).Error("Failed to serve REST gateway calls")
+			}
+		}()
+		gw, err := gateway.New(context.Background(), gatewayListener)
+		if err != nil {
+			logging.WithError(err).Fatal("Failed to initialize REST gateway")
+		}
+		defer gw.Close()
+
+		gatewayServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", cfg.GatewayPort),
+			Handler: gw.Handler(),
+		}
+		go func() {
+			logging.WithField("port", cfg.GatewayPort).Info("Starting REST gateway")
+			if err := gatewayServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logging.WithError(err).Error("REST gateway error")
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -144,9 +480,29 @@ func main() {
 
 		logging.Info("Shutting down store service...")
 
-		// Shutdown metrics server
+		// Report NOT_SERVING first so clients and load balancers drain
+		stopHealthChecks()
+		healthChecker.Shutdown()
+		stopCollector()
+
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
+
+		// Shutdown REST gateway
+		if gatewayServer != nil {
+			if err := gatewayServer.Shutdown(ctx); err != nil {
+				logging.WithError(err).Error("Failed to shutdown REST gateway")
+			}
+		}
+
+		// Stop mirroring; calls still queued are dropped
+		if trafficMirror != nil {
+			if err := trafficMirror.Close(); err != nil {
+				logging.WithError(err).Error("Failed to shutdown traffic mirror")
+			}
+		}
+
+		// Shutdown metrics server
 		if err := metricsServer.Shutdown(ctx); err != nil {
 			logging.WithError(err).Error("Failed to shutdown metrics server")
 		}
@@ -1,5 +1,13 @@
 package proto
 
-//go:generate protoc -I. --go_out=go --go_opt=paths=source_relative --go-grpc_out=go --go-grpc_opt=paths=source_relative store.proto
+import _ "embed"
 
-//go:generate sh -c "if command -v grpc_ruby_plugin >/dev/null 2>&1; then protoc -I. --ruby_out=ruby/lib --plugin=protoc-gen-grpc=/opt/homebrew/bin/grpc_ruby_plugin --grpc_out=ruby/lib store.proto; else echo 'Ruby gRPC plugin not found, skipping Ruby generation'; fi"
+//go:generate protoc -I. --go_out=go --go_opt=paths=source_relative validate.proto
+//go:generate protoc -I. --go_out=go --go_opt=paths=source_relative --go-grpc_out=go --go-grpc_opt=paths=source_relative --grpc-gateway_out=go --grpc-gateway_opt=paths=source_relative --openapiv2_out=openapi store.proto
+
+//go:generate sh -c "if command -v grpc_ruby_plugin >/dev/null 2>&1; then protoc -I. --ruby_out=ruby/lib --plugin=protoc-gen-grpc=/opt/homebrew/bin/grpc_ruby_plugin --grpc_out=ruby/lib store.proto validate.proto; else echo 'Ruby gRPC plugin not found, skipping Ruby generation'; fi"
+
+// OpenAPISpec is the OpenAPI v2 description of the REST/JSON gateway, generated from store.proto
+//
+//go:embed openapi/store.swagger.json
+var OpenAPISpec []byte
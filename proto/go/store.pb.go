@@ -7,8 +7,10 @@
 package storeproto
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -139,23 +141,24 @@ func (ItemStatus) EnumDescriptor() ([]byte, []int) {
 
 // Item represents a store item with enhanced fields
 type Item struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	TenantId       int64                  `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // Multi-tenancy support
-	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Price          float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Category       ItemCategory           `protobuf:"varint,6,opt,name=category,proto3,enum=store.v1.ItemCategory" json:"category,omitempty"`
-	Status         ItemStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=store.v1.ItemStatus" json:"status,omitempty"`
-	Sku            string                 `protobuf:"bytes,8,opt,name=sku,proto3" json:"sku,omitempty"`                                              // Stock Keeping Unit
-	InventoryCount int32                  `protobuf:"varint,9,opt,name=inventory_count,json=inventoryCount,proto3" json:"inventory_count,omitempty"` // Current inventory
-	Tags           []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`                                           // Item tags for categorization
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	CreatedBy      string                 `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // User who created the item
-	UpdatedBy      string                 `protobuf:"bytes,14,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"` // User who last updated the item
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId          int64                  `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // Multi-tenancy support
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Price             float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Category          ItemCategory           `protobuf:"varint,6,opt,name=category,proto3,enum=store.v1.ItemCategory" json:"category,omitempty"`
+	Status            ItemStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=store.v1.ItemStatus" json:"status,omitempty"`
+	Sku               string                 `protobuf:"bytes,8,opt,name=sku,proto3" json:"sku,omitempty"`                                              // Stock Keeping Unit
+	InventoryCount    int32                  `protobuf:"varint,9,opt,name=inventory_count,json=inventoryCount,proto3" json:"inventory_count,omitempty"` // Current inventory
+	Tags              []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`                                           // Item tags for categorization
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedBy         string                 `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`                            // User who created the item
+	UpdatedBy         string                 `protobuf:"bytes,14,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`                            // User who last updated the item
+	LowStockThreshold int32                  `protobuf:"varint,15,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"` // Reorder point (0 = use tenant default)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Item) Reset() {
@@ -286,25 +289,251 @@ func (x *Item) GetUpdatedBy() string {
 	return ""
 }
 
+func (x *Item) GetLowStockThreshold() int32 {
+	if x != nil {
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
+// TenantQuotas holds plan limits for a tenant (0 = unlimited)
+type TenantQuotas struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	MaxItems             int32                  `protobuf:"varint,1,opt,name=max_items,json=maxItems,proto3" json:"max_items,omitempty"`                          // Items in the catalog (deleted items excluded)
+	MaxWritesPerDay      int32                  `protobuf:"varint,2,opt,name=max_writes_per_day,json=maxWritesPerDay,proto3" json:"max_writes_per_day,omitempty"` // CreateItem/UpdateItem calls per UTC day
+	MaxTagsPerItem       int32                  `protobuf:"varint,3,opt,name=max_tags_per_item,json=maxTagsPerItem,proto3" json:"max_tags_per_item,omitempty"`
+	MaxDescriptionLength int32                  `protobuf:"varint,4,opt,name=max_description_length,json=maxDescriptionLength,proto3" json:"max_description_length,omitempty"` // In characters
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TenantQuotas) Reset() {
+	*x = TenantQuotas{}
+	mi := &file_store_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantQuotas) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantQuotas) ProtoMessage() {}
+
+func (x *TenantQuotas) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantQuotas.ProtoReflect.Descriptor instead.
+func (*TenantQuotas) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TenantQuotas) GetMaxItems() int32 {
+	if x != nil {
+		return x.MaxItems
+	}
+	return 0
+}
+
+func (x *TenantQuotas) GetMaxWritesPerDay() int32 {
+	if x != nil {
+		return x.MaxWritesPerDay
+	}
+	return 0
+}
+
+func (x *TenantQuotas) GetMaxTagsPerItem() int32 {
+	if x != nil {
+		return x.MaxTagsPerItem
+	}
+	return 0
+}
+
+func (x *TenantQuotas) GetMaxDescriptionLength() int32 {
+	if x != nil {
+		return x.MaxDescriptionLength
+	}
+	return 0
+}
+
+// TenantConfig holds per-tenant settings
+type TenantConfig struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId                 int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	DefaultLowStockThreshold int32                  `protobuf:"varint,2,opt,name=default_low_stock_threshold,json=defaultLowStockThreshold,proto3" json:"default_low_stock_threshold,omitempty"` // Reorder point for items without their own threshold
+	UpdatedAt                *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy                string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	Quotas                   *TenantQuotas          `protobuf:"bytes,5,opt,name=quotas,proto3" json:"quotas,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *TenantConfig) Reset() {
+	*x = TenantConfig{}
+	mi := &file_store_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantConfig) ProtoMessage() {}
+
+func (x *TenantConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantConfig.ProtoReflect.Descriptor instead.
+func (*TenantConfig) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TenantConfig) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *TenantConfig) GetDefaultLowStockThreshold() int32 {
+	if x != nil {
+		return x.DefaultLowStockThreshold
+	}
+	return 0
+}
+
+func (x *TenantConfig) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *TenantConfig) GetQuotas() *TenantQuotas {
+	if x != nil {
+		return x.Quotas
+	}
+	return nil
+}
+
+// TenantUsage reports a tenant's current usage against its quotas
+type TenantUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ItemCount     int64                  `protobuf:"varint,2,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	WritesToday   int64                  `protobuf:"varint,3,opt,name=writes_today,json=writesToday,proto3" json:"writes_today,omitempty"`
+	Quotas        *TenantQuotas          `protobuf:"bytes,4,opt,name=quotas,proto3" json:"quotas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantUsage) Reset() {
+	*x = TenantUsage{}
+	mi := &file_store_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantUsage) ProtoMessage() {}
+
+func (x *TenantUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantUsage.ProtoReflect.Descriptor instead.
+func (*TenantUsage) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TenantUsage) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetItemCount() int64 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetWritesToday() int64 {
+	if x != nil {
+		return x.WritesToday
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetQuotas() *TenantQuotas {
+	if x != nil {
+		return x.Quotas
+	}
+	return nil
+}
+
 // CreateItemRequest for creating a new item
 type CreateItemRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	TenantId       int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price          float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Category       ItemCategory           `protobuf:"varint,5,opt,name=category,proto3,enum=store.v1.ItemCategory" json:"category,omitempty"`
-	Sku            string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku,omitempty"`
-	InventoryCount int32                  `protobuf:"varint,7,opt,name=inventory_count,json=inventoryCount,proto3" json:"inventory_count,omitempty"`
-	Tags           []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
-	CreatedBy      string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TenantId          int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price             float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Category          ItemCategory           `protobuf:"varint,5,opt,name=category,proto3,enum=store.v1.ItemCategory" json:"category,omitempty"`
+	Sku               string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku,omitempty"` // Optional: letters, digits, '.', '_' and '-'
+	InventoryCount    int32                  `protobuf:"varint,7,opt,name=inventory_count,json=inventoryCount,proto3" json:"inventory_count,omitempty"`
+	Tags              []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedBy         string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	LowStockThreshold int32                  `protobuf:"varint,10,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"` // Optional: 0 = use tenant default
+	// Optional: retries with the same key return the original item instead of
+	// creating another. May also be sent as "idempotency-key" metadata.
+	IdempotencyKey string `protobuf:"bytes,11,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateItemRequest) Reset() {
 	*x = CreateItemRequest{}
-	mi := &file_store_proto_msgTypes[1]
+	mi := &file_store_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -316,7 +545,7 @@ func (x *CreateItemRequest) String() string {
 func (*CreateItemRequest) ProtoMessage() {}
 
 func (x *CreateItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[1]
+	mi := &file_store_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,7 +558,7 @@ func (x *CreateItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateItemRequest.ProtoReflect.Descriptor instead.
 func (*CreateItemRequest) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{1}
+	return file_store_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateItemRequest) GetTenantId() int64 {
@@ -395,6 +624,20 @@ func (x *CreateItemRequest) GetCreatedBy() string {
 	return ""
 }
 
+func (x *CreateItemRequest) GetLowStockThreshold() int32 {
+	if x != nil {
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
+func (x *CreateItemRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type CreateItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
@@ -404,7 +647,7 @@ type CreateItemResponse struct {
 
 func (x *CreateItemResponse) Reset() {
 	*x = CreateItemResponse{}
-	mi := &file_store_proto_msgTypes[2]
+	mi := &file_store_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +659,7 @@ func (x *CreateItemResponse) String() string {
 func (*CreateItemResponse) ProtoMessage() {}
 
 func (x *CreateItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[2]
+	mi := &file_store_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +672,7 @@ func (x *CreateItemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateItemResponse.ProtoReflect.Descriptor instead.
 func (*CreateItemResponse) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{2}
+	return file_store_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateItemResponse) GetItem() *Item {
@@ -441,16 +684,17 @@ func (x *CreateItemResponse) GetItem() *Item {
 
 // GetItemRequest for retrieving an item
 type GetItemRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TenantId       int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Id             string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	ConsistentRead bool                   `protobuf:"varint,3,opt,name=consistent_read,json=consistentRead,proto3" json:"consistent_read,omitempty"` // Skip the server's item cache and read the latest write
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetItemRequest) Reset() {
 	*x = GetItemRequest{}
-	mi := &file_store_proto_msgTypes[3]
+	mi := &file_store_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -462,7 +706,7 @@ func (x *GetItemRequest) String() string {
 func (*GetItemRequest) ProtoMessage() {}
 
 func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[3]
+	mi := &file_store_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,7 +719,7 @@ func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
 func (*GetItemRequest) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{3}
+	return file_store_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetItemRequest) GetTenantId() int64 {
@@ -492,6 +736,13 @@ func (x *GetItemRequest) GetId() string {
 	return ""
 }
 
+func (x *GetItemRequest) GetConsistentRead() bool {
+	if x != nil {
+		return x.ConsistentRead
+	}
+	return false
+}
+
 type GetItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
@@ -501,7 +752,7 @@ type GetItemResponse struct {
 
 func (x *GetItemResponse) Reset() {
 	*x = GetItemResponse{}
-	mi := &file_store_proto_msgTypes[4]
+	mi := &file_store_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -513,7 +764,7 @@ func (x *GetItemResponse) String() string {
 func (*GetItemResponse) ProtoMessage() {}
 
 func (x *GetItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[4]
+	mi := &file_store_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,7 +777,7 @@ func (x *GetItemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetItemResponse.ProtoReflect.Descriptor instead.
 func (*GetItemResponse) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{4}
+	return file_store_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetItemResponse) GetItem() *Item {
@@ -538,25 +789,26 @@ func (x *GetItemResponse) GetItem() *Item {
 
 // UpdateItemRequest for updating an existing item
 type UpdateItemRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	TenantId       int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	Id             string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Price          float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Category       ItemCategory           `protobuf:"varint,6,opt,name=category,proto3,enum=store.v1.ItemCategory" json:"category,omitempty"`
-	Status         ItemStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=store.v1.ItemStatus" json:"status,omitempty"`
-	Sku            string                 `protobuf:"bytes,8,opt,name=sku,proto3" json:"sku,omitempty"`
-	InventoryCount int32                  `protobuf:"varint,9,opt,name=inventory_count,json=inventoryCount,proto3" json:"inventory_count,omitempty"`
-	Tags           []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
-	UpdatedBy      string                 `protobuf:"bytes,11,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TenantId          int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Id                string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Price             float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Category          ItemCategory           `protobuf:"varint,6,opt,name=category,proto3,enum=store.v1.ItemCategory" json:"category,omitempty"`
+	Status            ItemStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=store.v1.ItemStatus" json:"status,omitempty"` // ACTIVE and OUT_OF_STOCK follow a changed inventory_count
+	Sku               string                 `protobuf:"bytes,8,opt,name=sku,proto3" json:"sku,omitempty"`                                 // Optional: letters, digits, '.', '_' and '-'
+	InventoryCount    int32                  `protobuf:"varint,9,opt,name=inventory_count,json=inventoryCount,proto3" json:"inventory_count,omitempty"`
+	Tags              []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	UpdatedBy         string                 `protobuf:"bytes,11,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	LowStockThreshold int32                  `protobuf:"varint,12,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"` // Optional: 0 = use tenant default
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *UpdateItemRequest) Reset() {
 	*x = UpdateItemRequest{}
-	mi := &file_store_proto_msgTypes[5]
+	mi := &file_store_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -568,7 +820,7 @@ func (x *UpdateItemRequest) String() string {
 func (*UpdateItemRequest) ProtoMessage() {}
 
 func (x *UpdateItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[5]
+	mi := &file_store_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +833,7 @@ func (x *UpdateItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateItemRequest.ProtoReflect.Descriptor instead.
 func (*UpdateItemRequest) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{5}
+	return file_store_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateItemRequest) GetTenantId() int64 {
@@ -661,6 +913,13 @@ func (x *UpdateItemRequest) GetUpdatedBy() string {
 	return ""
 }
 
+func (x *UpdateItemRequest) GetLowStockThreshold() int32 {
+	if x != nil {
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
 type UpdateItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
@@ -670,7 +929,7 @@ type UpdateItemResponse struct {
 
 func (x *UpdateItemResponse) Reset() {
 	*x = UpdateItemResponse{}
-	mi := &file_store_proto_msgTypes[6]
+	mi := &file_store_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -682,7 +941,7 @@ func (x *UpdateItemResponse) String() string {
 func (*UpdateItemResponse) ProtoMessage() {}
 
 func (x *UpdateItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[6]
+	mi := &file_store_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,7 +954,7 @@ func (x *UpdateItemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateItemResponse.ProtoReflect.Descriptor instead.
 func (*UpdateItemResponse) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{6}
+	return file_store_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateItemResponse) GetItem() *Item {
@@ -710,13 +969,14 @@ type DeleteItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
 	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	DeletedBy     string                 `protobuf:"bytes,3,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteItemRequest) Reset() {
 	*x = DeleteItemRequest{}
-	mi := &file_store_proto_msgTypes[7]
+	mi := &file_store_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -728,7 +988,7 @@ func (x *DeleteItemRequest) String() string {
 func (*DeleteItemRequest) ProtoMessage() {}
 
 func (x *DeleteItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[7]
+	mi := &file_store_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -741,7 +1001,7 @@ func (x *DeleteItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteItemRequest.ProtoReflect.Descriptor instead.
 func (*DeleteItemRequest) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{7}
+	return file_store_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteItemRequest) GetTenantId() int64 {
@@ -758,6 +1018,13 @@ func (x *DeleteItemRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteItemRequest) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
 type DeleteItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -767,7 +1034,7 @@ type DeleteItemResponse struct {
 
 func (x *DeleteItemResponse) Reset() {
 	*x = DeleteItemResponse{}
-	mi := &file_store_proto_msgTypes[8]
+	mi := &file_store_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -779,7 +1046,7 @@ func (x *DeleteItemResponse) String() string {
 func (*DeleteItemResponse) ProtoMessage() {}
 
 func (x *DeleteItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[8]
+	mi := &file_store_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -792,7 +1059,7 @@ func (x *DeleteItemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteItemResponse.ProtoReflect.Descriptor instead.
 func (*DeleteItemResponse) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{8}
+	return file_store_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DeleteItemResponse) GetSuccess() bool {
@@ -817,7 +1084,7 @@ type ListItemsRequest struct {
 
 func (x *ListItemsRequest) Reset() {
 	*x = ListItemsRequest{}
-	mi := &file_store_proto_msgTypes[9]
+	mi := &file_store_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -829,7 +1096,7 @@ func (x *ListItemsRequest) String() string {
 func (*ListItemsRequest) ProtoMessage() {}
 
 func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[9]
+	mi := &file_store_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -842,7 +1109,7 @@ func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListItemsRequest.ProtoReflect.Descriptor instead.
 func (*ListItemsRequest) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{9}
+	return file_store_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListItemsRequest) GetTenantId() int64 {
@@ -898,7 +1165,7 @@ type ListItemsResponse struct {
 
 func (x *ListItemsResponse) Reset() {
 	*x = ListItemsResponse{}
-	mi := &file_store_proto_msgTypes[10]
+	mi := &file_store_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -910,7 +1177,7 @@ func (x *ListItemsResponse) String() string {
 func (*ListItemsResponse) ProtoMessage() {}
 
 func (x *ListItemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[10]
+	mi := &file_store_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -923,7 +1190,7 @@ func (x *ListItemsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListItemsResponse.ProtoReflect.Descriptor instead.
 func (*ListItemsResponse) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{10}
+	return file_store_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListItemsResponse) GetItems() []*Item {
@@ -952,16 +1219,19 @@ type UpdateInventoryRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	TenantId       int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
 	ItemId         string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
-	QuantityChange int32                  `protobuf:"varint,3,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"` // Can be positive (add) or negative (subtract)
+	QuantityChange int32                  `protobuf:"varint,3,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"` // Can be positive (add) or negative (subtract), not zero
 	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                        // Reason for inventory change
 	UpdatedBy      string                 `protobuf:"bytes,5,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// Optional: retries with the same key return the original result instead
+	// of applying the change again. May also be sent as "idempotency-key" metadata.
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *UpdateInventoryRequest) Reset() {
 	*x = UpdateInventoryRequest{}
-	mi := &file_store_proto_msgTypes[11]
+	mi := &file_store_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -973,7 +1243,1136 @@ func (x *UpdateInventoryRequest) String() string {
 func (*UpdateInventoryRequest) ProtoMessage() {}
 
 func (x *UpdateInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[11]
+	mi := &file_store_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInventoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateInventoryRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *UpdateInventoryRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *UpdateInventoryRequest) GetQuantityChange() int32 {
+	if x != nil {
+		return x.QuantityChange
+	}
+	return 0
+}
+
+func (x *UpdateInventoryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateInventoryRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *UpdateInventoryRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type UpdateInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	PreviousCount int32                  `protobuf:"varint,2,opt,name=previous_count,json=previousCount,proto3" json:"previous_count,omitempty"` // Previous inventory count
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInventoryResponse) Reset() {
+	*x = UpdateInventoryResponse{}
+	mi := &file_store_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInventoryResponse) ProtoMessage() {}
+
+func (x *UpdateInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInventoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateInventoryResponse) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *UpdateInventoryResponse) GetPreviousCount() int32 {
+	if x != nil {
+		return x.PreviousCount
+	}
+	return 0
+}
+
+// ListLowStockItemsRequest for listing items at or below their reorder point
+type ListLowStockItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Page size (default 100)
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Pagination token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowStockItemsRequest) Reset() {
+	*x = ListLowStockItemsRequest{}
+	mi := &file_store_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowStockItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowStockItemsRequest) ProtoMessage() {}
+
+func (x *ListLowStockItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowStockItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListLowStockItemsRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListLowStockItemsRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ListLowStockItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLowStockItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListLowStockItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowStockItemsResponse) Reset() {
+	*x = ListLowStockItemsResponse{}
+	mi := &file_store_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowStockItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowStockItemsResponse) ProtoMessage() {}
+
+func (x *ListLowStockItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowStockItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListLowStockItemsResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListLowStockItemsResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListLowStockItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetTenantConfigRequest for retrieving tenant settings
+type GetTenantConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantConfigRequest) Reset() {
+	*x = GetTenantConfigRequest{}
+	mi := &file_store_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantConfigRequest) ProtoMessage() {}
+
+func (x *GetTenantConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantConfigRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetTenantConfigRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+type GetTenantConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *TenantConfig          `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantConfigResponse) Reset() {
+	*x = GetTenantConfigResponse{}
+	mi := &file_store_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantConfigResponse) ProtoMessage() {}
+
+func (x *GetTenantConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetTenantConfigResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetTenantConfigResponse) GetConfig() *TenantConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// UpdateTenantConfigRequest for updating tenant settings
+type UpdateTenantConfigRequest struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId                 int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	DefaultLowStockThreshold int32                  `protobuf:"varint,2,opt,name=default_low_stock_threshold,json=defaultLowStockThreshold,proto3" json:"default_low_stock_threshold,omitempty"`
+	UpdatedBy                string                 `protobuf:"bytes,3,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *UpdateTenantConfigRequest) Reset() {
+	*x = UpdateTenantConfigRequest{}
+	mi := &file_store_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantConfigRequest) ProtoMessage() {}
+
+func (x *UpdateTenantConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTenantConfigRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateTenantConfigRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *UpdateTenantConfigRequest) GetDefaultLowStockThreshold() int32 {
+	if x != nil {
+		return x.DefaultLowStockThreshold
+	}
+	return 0
+}
+
+func (x *UpdateTenantConfigRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type UpdateTenantConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *TenantConfig          `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTenantConfigResponse) Reset() {
+	*x = UpdateTenantConfigResponse{}
+	mi := &file_store_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantConfigResponse) ProtoMessage() {}
+
+func (x *UpdateTenantConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantConfigResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTenantConfigResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateTenantConfigResponse) GetConfig() *TenantConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// SetTenantQuotasRequest for replacing a tenant's plan limits
+type SetTenantQuotasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Quotas        *TenantQuotas          `protobuf:"bytes,2,opt,name=quotas,proto3" json:"quotas,omitempty"` // Replaces the current quotas (unset = unlimited)
+	UpdatedBy     string                 `protobuf:"bytes,3,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTenantQuotasRequest) Reset() {
+	*x = SetTenantQuotasRequest{}
+	mi := &file_store_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTenantQuotasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTenantQuotasRequest) ProtoMessage() {}
+
+func (x *SetTenantQuotasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTenantQuotasRequest.ProtoReflect.Descriptor instead.
+func (*SetTenantQuotasRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetTenantQuotasRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *SetTenantQuotasRequest) GetQuotas() *TenantQuotas {
+	if x != nil {
+		return x.Quotas
+	}
+	return nil
+}
+
+func (x *SetTenantQuotasRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type SetTenantQuotasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *TenantConfig          `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTenantQuotasResponse) Reset() {
+	*x = SetTenantQuotasResponse{}
+	mi := &file_store_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTenantQuotasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTenantQuotasResponse) ProtoMessage() {}
+
+func (x *SetTenantQuotasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTenantQuotasResponse.ProtoReflect.Descriptor instead.
+func (*SetTenantQuotasResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetTenantQuotasResponse) GetConfig() *TenantConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// GetTenantUsageRequest for retrieving tenant quota usage
+type GetTenantUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantUsageRequest) Reset() {
+	*x = GetTenantUsageRequest{}
+	mi := &file_store_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantUsageRequest) ProtoMessage() {}
+
+func (x *GetTenantUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantUsageRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTenantUsageRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+type GetTenantUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         *TenantUsage           `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantUsageResponse) Reset() {
+	*x = GetTenantUsageResponse{}
+	mi := &file_store_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantUsageResponse) ProtoMessage() {}
+
+func (x *GetTenantUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetTenantUsageResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTenantUsageResponse) GetUsage() *TenantUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// RecountItemsRequest for recomputing a tenant's item count from its items
+type RecountItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecountItemsRequest) Reset() {
+	*x = RecountItemsRequest{}
+	mi := &file_store_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecountItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecountItemsRequest) ProtoMessage() {}
+
+func (x *RecountItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecountItemsRequest.ProtoReflect.Descriptor instead.
+func (*RecountItemsRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RecountItemsRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+type RecountItemsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PreviousItemCount int64                  `protobuf:"varint,1,opt,name=previous_item_count,json=previousItemCount,proto3" json:"previous_item_count,omitempty"`
+	ItemCount         int64                  `protobuf:"varint,2,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RecountItemsResponse) Reset() {
+	*x = RecountItemsResponse{}
+	mi := &file_store_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecountItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecountItemsResponse) ProtoMessage() {}
+
+func (x *RecountItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecountItemsResponse.ProtoReflect.Descriptor instead.
+func (*RecountItemsResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RecountItemsResponse) GetPreviousItemCount() int64 {
+	if x != nil {
+		return x.PreviousItemCount
+	}
+	return 0
+}
+
+func (x *RecountItemsResponse) GetItemCount() int64 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+// FieldChange records one field's value before and after a mutation
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before        string                 `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_store_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *FieldChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// AuditEvent records a single mutation of a tenant's catalog
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      int64                  `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"` // RPC that made the change, e.g. UpdateItem
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`   // Authenticated subject, client-supplied user or "anonymous"
+	Changes       []*FieldChange         `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	Canary        string                 `protobuf:"bytes,7,opt,name=canary,proto3" json:"canary,omitempty"` // X-Canary PR number, if the call was a canary
+	TraceId       string                 `protobuf:"bytes,8,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	ClientAddress string                 `protobuf:"bytes,9,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"` // For REST calls, the address the gateway saw the HTTP client connect from
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_store_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetCanary() string {
+	if x != nil {
+		return x.Canary
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetClientAddress() string {
+	if x != nil {
+		return x.ClientAddress
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// ListAuditEventsRequest for listing audit events, newest first
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      int64                  `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`          // Optional: filter by item
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`                          // Optional: filter by actor
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`                        // Optional: filter by method
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // Optional: inclusive lower bound
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Optional: exclusive upper bound
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Page size (default 100)
+	PageToken     string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Pagination token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_store_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAuditEventsRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_store_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// DebugLoggingRule enables debug logging for requests from one tenant, or
+// carrying one request header, until it expires
+type DebugLoggingRule struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetTenantId int64                  `protobuf:"varint,1,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	HeaderName     string                 `protobuf:"bytes,2,opt,name=header_name,json=headerName,proto3" json:"header_name,omitempty"`
+	HeaderValue    string                 `protobuf:"bytes,3,opt,name=header_value,json=headerValue,proto3" json:"header_value,omitempty"` // Empty matches any value of header_name
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DebugLoggingRule) Reset() {
+	*x = DebugLoggingRule{}
+	mi := &file_store_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugLoggingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugLoggingRule) ProtoMessage() {}
+
+func (x *DebugLoggingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugLoggingRule.ProtoReflect.Descriptor instead.
+func (*DebugLoggingRule) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DebugLoggingRule) GetTargetTenantId() int64 {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return 0
+}
+
+func (x *DebugLoggingRule) GetHeaderName() string {
+	if x != nil {
+		return x.HeaderName
+	}
+	return ""
+}
+
+func (x *DebugLoggingRule) GetHeaderValue() string {
+	if x != nil {
+		return x.HeaderValue
+	}
+	return ""
+}
+
+func (x *DebugLoggingRule) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type GetLoggingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoggingRequest) Reset() {
+	*x = GetLoggingRequest{}
+	mi := &file_store_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoggingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoggingRequest) ProtoMessage() {}
+
+func (x *GetLoggingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,69 +2383,250 @@ func (x *UpdateInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateInventoryRequest.ProtoReflect.Descriptor instead.
-func (*UpdateInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetLoggingRequest.ProtoReflect.Descriptor instead.
+func (*GetLoggingRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *UpdateInventoryRequest) GetTenantId() int64 {
+type GetLoggingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	DebugRules    []*DebugLoggingRule    `protobuf:"bytes,2,rep,name=debug_rules,json=debugRules,proto3" json:"debug_rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoggingResponse) Reset() {
+	*x = GetLoggingResponse{}
+	mi := &file_store_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoggingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoggingResponse) ProtoMessage() {}
+
+func (x *GetLoggingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[34]
 	if x != nil {
-		return x.TenantId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateInventoryRequest) GetItemId() string {
+// Deprecated: Use GetLoggingResponse.ProtoReflect.Descriptor instead.
+func (*GetLoggingResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetLoggingResponse) GetLevel() string {
 	if x != nil {
-		return x.ItemId
+		return x.Level
 	}
 	return ""
 }
 
-func (x *UpdateInventoryRequest) GetQuantityChange() int32 {
+func (x *GetLoggingResponse) GetDebugRules() []*DebugLoggingRule {
 	if x != nil {
-		return x.QuantityChange
+		return x.DebugRules
+	}
+	return nil
+}
+
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // trace, debug, info, warn, error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_store_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type SetLogLevelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	PreviousLevel string                 `protobuf:"bytes,2,opt,name=previous_level,json=previousLevel,proto3" json:"previous_level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	mi := &file_store_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelResponse) ProtoMessage() {}
+
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetLogLevelResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelResponse) GetPreviousLevel() string {
+	if x != nil {
+		return x.PreviousLevel
+	}
+	return ""
+}
+
+// EnableDebugLoggingRequest names either a tenant or a request header
+type EnableDebugLoggingRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TargetTenantId int64                  `protobuf:"varint,1,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	HeaderName     string                 `protobuf:"bytes,2,opt,name=header_name,json=headerName,proto3" json:"header_name,omitempty"`
+	HeaderValue    string                 `protobuf:"bytes,3,opt,name=header_value,json=headerValue,proto3" json:"header_value,omitempty"`
+	Duration       *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"` // Defaults to 15 minutes
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnableDebugLoggingRequest) Reset() {
+	*x = EnableDebugLoggingRequest{}
+	mi := &file_store_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableDebugLoggingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableDebugLoggingRequest) ProtoMessage() {}
+
+func (x *EnableDebugLoggingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableDebugLoggingRequest.ProtoReflect.Descriptor instead.
+func (*EnableDebugLoggingRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *EnableDebugLoggingRequest) GetTargetTenantId() int64 {
+	if x != nil {
+		return x.TargetTenantId
 	}
 	return 0
 }
 
-func (x *UpdateInventoryRequest) GetReason() string {
+func (x *EnableDebugLoggingRequest) GetHeaderName() string {
 	if x != nil {
-		return x.Reason
+		return x.HeaderName
 	}
 	return ""
 }
 
-func (x *UpdateInventoryRequest) GetUpdatedBy() string {
+func (x *EnableDebugLoggingRequest) GetHeaderValue() string {
 	if x != nil {
-		return x.UpdatedBy
+		return x.HeaderValue
 	}
 	return ""
 }
 
-type UpdateInventoryResponse struct {
+func (x *EnableDebugLoggingRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type EnableDebugLoggingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	PreviousCount int32                  `protobuf:"varint,2,opt,name=previous_count,json=previousCount,proto3" json:"previous_count,omitempty"` // Previous inventory count
+	Rule          *DebugLoggingRule      `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateInventoryResponse) Reset() {
-	*x = UpdateInventoryResponse{}
-	mi := &file_store_proto_msgTypes[12]
+func (x *EnableDebugLoggingResponse) Reset() {
+	*x = EnableDebugLoggingResponse{}
+	mi := &file_store_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateInventoryResponse) String() string {
+func (x *EnableDebugLoggingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateInventoryResponse) ProtoMessage() {}
+func (*EnableDebugLoggingResponse) ProtoMessage() {}
 
-func (x *UpdateInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_store_proto_msgTypes[12]
+func (x *EnableDebugLoggingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1057,21 +2637,94 @@ func (x *UpdateInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateInventoryResponse.ProtoReflect.Descriptor instead.
-func (*UpdateInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_store_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use EnableDebugLoggingResponse.ProtoReflect.Descriptor instead.
+func (*EnableDebugLoggingResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *UpdateInventoryResponse) GetItem() *Item {
+func (x *EnableDebugLoggingResponse) GetRule() *DebugLoggingRule {
 	if x != nil {
-		return x.Item
+		return x.Rule
 	}
 	return nil
 }
 
-func (x *UpdateInventoryResponse) GetPreviousCount() int32 {
+type ClearDebugLoggingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearDebugLoggingRequest) Reset() {
+	*x = ClearDebugLoggingRequest{}
+	mi := &file_store_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearDebugLoggingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDebugLoggingRequest) ProtoMessage() {}
+
+func (x *ClearDebugLoggingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[39]
 	if x != nil {
-		return x.PreviousCount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDebugLoggingRequest.ProtoReflect.Descriptor instead.
+func (*ClearDebugLoggingRequest) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{39}
+}
+
+type ClearDebugLoggingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       int32                  `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearDebugLoggingResponse) Reset() {
+	*x = ClearDebugLoggingResponse{}
+	mi := &file_store_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearDebugLoggingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDebugLoggingResponse) ProtoMessage() {}
+
+func (x *ClearDebugLoggingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_store_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDebugLoggingResponse.ProtoReflect.Descriptor instead.
+func (*ClearDebugLoggingResponse) Descriptor() ([]byte, []int) {
+	return file_store_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ClearDebugLoggingResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
 	}
 	return 0
 }
@@ -1080,7 +2733,7 @@ var File_store_proto protoreflect.FileDescriptor
 
 const file_store_proto_rawDesc = "" +
 	"\n" +
-	"\vstore.proto\x12\bstore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe4\x03\n" +
+	"\vstore.proto\x12\bstore.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0evalidate.proto\"\x94\x04\n" +
 	"\x04Item\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\x03R\btenantId\x12\x12\n" +
@@ -1100,51 +2753,78 @@ const file_store_proto_rawDesc = "" +
 	"\n" +
 	"created_by\x18\r \x01(\tR\tcreatedBy\x12\x1d\n" +
 	"\n" +
-	"updated_by\x18\x0e \x01(\tR\tupdatedBy\"\x9e\x02\n" +
-	"\x11CreateItemRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x122\n" +
-	"\bcategory\x18\x05 \x01(\x0e2\x16.store.v1.ItemCategoryR\bcategory\x12\x10\n" +
-	"\x03sku\x18\x06 \x01(\tR\x03sku\x12'\n" +
-	"\x0finventory_count\x18\a \x01(\x05R\x0einventoryCount\x12\x12\n" +
-	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1d\n" +
+	"updated_by\x18\x0e \x01(\tR\tupdatedBy\x12.\n" +
+	"\x13low_stock_threshold\x18\x0f \x01(\x05R\x11lowStockThreshold\"\xf5\x01\n" +
+	"\fTenantQuotas\x12*\n" +
+	"\tmax_items\x18\x01 \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\bmaxItems\x12:\n" +
+	"\x12max_writes_per_day\x18\x02 \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x0fmaxWritesPerDay\x128\n" +
+	"\x11max_tags_per_item\x18\x03 \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x0emaxTagsPerItem\x12C\n" +
+	"\x16max_description_length\x18\x04 \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x14maxDescriptionLength\"\xf4\x01\n" +
+	"\fTenantConfig\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12=\n" +
+	"\x1bdefault_low_stock_threshold\x18\x02 \x01(\x05R\x18defaultLowStockThreshold\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\x12.\n" +
+	"\x06quotas\x18\x05 \x01(\v2\x16.store.v1.TenantQuotasR\x06quotas\"\x9c\x01\n" +
+	"\vTenantUsage\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\t \x01(\tR\tcreatedBy\"8\n" +
+	"item_count\x18\x02 \x01(\x03R\titemCount\x12!\n" +
+	"\fwrites_today\x18\x03 \x01(\x03R\vwritesToday\x12.\n" +
+	"\x06quotas\x18\x04 \x01(\v2\x16.store.v1.TenantQuotasR\x06quotas\"\x98\x04\n" +
+	"\x11CreateItemRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\x8a\xb2\x19\x05\b\x018\xc8\x01R\x04name\x12)\n" +
+	"\vdescription\x18\x03 \x01(\tB\a\x8a\xb2\x19\x038\x88'R\vdescription\x12#\n" +
+	"\x05price\x18\x04 \x01(\x01B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x05price\x12:\n" +
+	"\bcategory\x18\x05 \x01(\x0e2\x16.store.v1.ItemCategoryB\x06\x8a\xb2\x19\x02H\x01R\bcategory\x129\n" +
+	"\x03sku\x18\x06 \x01(\tB'\x8a\xb2\x19#B!^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$R\x03sku\x126\n" +
+	"\x0finventory_count\x18\a \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x0einventoryCount\x12 \n" +
+	"\x04tags\x18\b \x03(\tB\f\x8a\xb2\x19\bP2Z\x04\b\x0182R\x04tags\x12&\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\tcreatedBy\x12=\n" +
+	"\x13low_stock_threshold\x18\n" +
+	" \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x11lowStockThreshold\x120\n" +
+	"\x0fidempotency_key\x18\v \x01(\tB\a\x8a\xb2\x19\x038\x80\x01R\x0eidempotencyKey\"8\n" +
 	"\x12CreateItemResponse\x12\"\n" +
-	"\x04item\x18\x01 \x01(\v2\x0e.store.v1.ItemR\x04item\"=\n" +
-	"\x0eGetItemRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\"5\n" +
+	"\x04item\x18\x01 \x01(\v2\x0e.store.v1.ItemR\x04item\"}\n" +
+	"\x0eGetItemRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12\x16\n" +
+	"\x02id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\b\x01R\x02id\x12'\n" +
+	"\x0fconsistent_read\x18\x03 \x01(\bR\x0econsistentRead\"5\n" +
 	"\x0fGetItemResponse\x12\"\n" +
-	"\x04item\x18\x01 \x01(\v2\x0e.store.v1.ItemR\x04item\"\xdc\x02\n" +
-	"\x11UpdateItemRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x122\n" +
-	"\bcategory\x18\x06 \x01(\x0e2\x16.store.v1.ItemCategoryR\bcategory\x12,\n" +
-	"\x06status\x18\a \x01(\x0e2\x14.store.v1.ItemStatusR\x06status\x12\x10\n" +
-	"\x03sku\x18\b \x01(\tR\x03sku\x12'\n" +
-	"\x0finventory_count\x18\t \x01(\x05R\x0einventoryCount\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\v2\x0e.store.v1.ItemR\x04item\"\xb4\x04\n" +
+	"\x11UpdateItemRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12\x16\n" +
+	"\x02id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\b\x01R\x02id\x12\x1d\n" +
+	"\x04name\x18\x03 \x01(\tB\t\x8a\xb2\x19\x05\b\x018\xc8\x01R\x04name\x12)\n" +
+	"\vdescription\x18\x04 \x01(\tB\a\x8a\xb2\x19\x038\x88'R\vdescription\x12#\n" +
+	"\x05price\x18\x05 \x01(\x01B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x05price\x12:\n" +
+	"\bcategory\x18\x06 \x01(\x0e2\x16.store.v1.ItemCategoryB\x06\x8a\xb2\x19\x02H\x01R\bcategory\x124\n" +
+	"\x06status\x18\a \x01(\x0e2\x14.store.v1.ItemStatusB\x06\x8a\xb2\x19\x02H\x01R\x06status\x129\n" +
+	"\x03sku\x18\b \x01(\tB'\x8a\xb2\x19#B!^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$R\x03sku\x126\n" +
+	"\x0finventory_count\x18\t \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x0einventoryCount\x12 \n" +
 	"\x04tags\x18\n" +
-	" \x03(\tR\x04tags\x12\x1d\n" +
+	" \x03(\tB\f\x8a\xb2\x19\bP2Z\x04\b\x0182R\x04tags\x12&\n" +
 	"\n" +
-	"updated_by\x18\v \x01(\tR\tupdatedBy\"8\n" +
+	"updated_by\x18\v \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\tupdatedBy\x12=\n" +
+	"\x13low_stock_threshold\x18\f \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x11lowStockThreshold\"8\n" +
 	"\x12UpdateItemResponse\x12\"\n" +
-	"\x04item\x18\x01 \x01(\v2\x0e.store.v1.ItemR\x04item\"@\n" +
-	"\x11DeleteItemRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\".\n" +
+	"\x04item\x18\x01 \x01(\v2\x0e.store.v1.ItemR\x04item\"\x7f\n" +
+	"\x11DeleteItemRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12\x16\n" +
+	"\x02id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\b\x01R\x02id\x12&\n" +
+	"\n" +
+	"deleted_by\x18\x03 \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\tdeletedBy\".\n" +
 	"\x12DeleteItemResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xf0\x01\n" +
-	"\x10ListItemsRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x122\n" +
-	"\bcategory\x18\x02 \x01(\x0e2\x16.store.v1.ItemCategoryR\bcategory\x12,\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x14.store.v1.ItemStatusR\x06status\x12!\n" +
-	"\fsearch_query\x18\x04 \x01(\tR\vsearchQuery\x12\x1b\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x98\x02\n" +
+	"\x10ListItemsRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12:\n" +
+	"\bcategory\x18\x02 \x01(\x0e2\x16.store.v1.ItemCategoryB\x06\x8a\xb2\x19\x02H\x01R\bcategory\x124\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.store.v1.ItemStatusB\x06\x8a\xb2\x19\x02H\x01R\x06status\x12*\n" +
+	"\fsearch_query\x18\x04 \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\vsearchQuery\x12\x1b\n" +
 	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x06 \x01(\tR\tpageToken\"\x82\x01\n" +
@@ -1152,17 +2832,113 @@ const file_store_proto_rawDesc = "" +
 	"\x05items\x18\x01 \x03(\v2\x0e.store.v1.ItemR\x05items\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"\xae\x01\n" +
-	"\x16UpdateInventoryRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\x03R\btenantId\x12\x17\n" +
-	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12'\n" +
-	"\x0fquantity_change\x18\x03 \x01(\x05R\x0equantityChange\x12\x16\n" +
-	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"totalCount\"\x91\x02\n" +
+	"\x16UpdateInventoryRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12\x1f\n" +
+	"\aitem_id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\b\x01R\x06itemId\x12/\n" +
+	"\x0fquantity_change\x18\x03 \x01(\x05B\x06\x8a\xb2\x19\x02\b\x01R\x0equantityChange\x12\x1f\n" +
+	"\x06reason\x18\x04 \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\x06reason\x12&\n" +
 	"\n" +
-	"updated_by\x18\x05 \x01(\tR\tupdatedBy\"d\n" +
+	"updated_by\x18\x05 \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\tupdatedBy\x120\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tB\a\x8a\xb2\x19\x038\x80\x01R\x0eidempotencyKey\"d\n" +
 	"\x17UpdateInventoryResponse\x12\"\n" +
 	"\x04item\x18\x01 \x01(\v2\x0e.store.v1.ItemR\x04item\x12%\n" +
-	"\x0eprevious_count\x18\x02 \x01(\x05R\rpreviousCount*\xb3\x01\n" +
+	"\x0eprevious_count\x18\x02 \x01(\x05R\rpreviousCount\"\x82\x01\n" +
+	"\x18ListLowStockItemsRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"i\n" +
+	"\x19ListLowStockItemsResponse\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.store.v1.ItemR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"D\n" +
+	"\x16GetTenantConfigRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\"I\n" +
+	"\x17GetTenantConfigResponse\x12.\n" +
+	"\x06config\x18\x01 \x01(\v2\x16.store.v1.TenantConfigR\x06config\"\xcb\x01\n" +
+	"\x19UpdateTenantConfigRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12L\n" +
+	"\x1bdefault_low_stock_threshold\x18\x02 \x01(\x05B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x18defaultLowStockThreshold\x12&\n" +
+	"\n" +
+	"updated_by\x18\x03 \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\tupdatedByJ\x04\b\x04\x10\x05R\x06quotas\"L\n" +
+	"\x1aUpdateTenantConfigResponse\x12.\n" +
+	"\x06config\x18\x01 \x01(\v2\x16.store.v1.TenantConfigR\x06config\"\x9c\x01\n" +
+	"\x16SetTenantQuotasRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12.\n" +
+	"\x06quotas\x18\x02 \x01(\v2\x16.store.v1.TenantQuotasR\x06quotas\x12&\n" +
+	"\n" +
+	"updated_by\x18\x03 \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\tupdatedBy\"I\n" +
+	"\x17SetTenantQuotasResponse\x12.\n" +
+	"\x06config\x18\x01 \x01(\v2\x16.store.v1.TenantConfigR\x06config\"C\n" +
+	"\x15GetTenantUsageRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\"E\n" +
+	"\x16GetTenantUsageResponse\x12+\n" +
+	"\x05usage\x18\x01 \x01(\v2\x15.store.v1.TenantUsageR\x05usage\"A\n" +
+	"\x13RecountItemsRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\"e\n" +
+	"\x14RecountItemsResponse\x12.\n" +
+	"\x13previous_item_count\x18\x01 \x01(\x03R\x11previousItemCount\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x02 \x01(\x03R\titemCount\"Q\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\xc5\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\x03R\btenantId\x12\x17\n" +
+	"\aitem_id\x18\x03 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12/\n" +
+	"\achanges\x18\x06 \x03(\v2\x15.store.v1.FieldChangeR\achanges\x12\x16\n" +
+	"\x06canary\x18\a \x01(\tR\x06canary\x12\x19\n" +
+	"\btrace_id\x18\b \x01(\tR\atraceId\x12%\n" +
+	"\x0eclient_address\x18\t \x01(\tR\rclientAddress\x128\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xca\x02\n" +
+	"\x16ListAuditEventsRequest\x12*\n" +
+	"\ttenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00R\btenantId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1d\n" +
+	"\x05actor\x18\x03 \x01(\tB\a\x8a\xb2\x19\x038\xc8\x01R\x05actor\x12\x1e\n" +
+	"\x06method\x18\x04 \x01(\tB\x06\x8a\xb2\x19\x028dR\x06method\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"o\n" +
+	"\x17ListAuditEventsResponse\x12,\n" +
+	"\x06events\x18\x01 \x03(\v2\x14.store.v1.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbb\x01\n" +
+	"\x10DebugLoggingRule\x12(\n" +
+	"\x10target_tenant_id\x18\x01 \x01(\x03R\x0etargetTenantId\x12\x1f\n" +
+	"\vheader_name\x18\x02 \x01(\tR\n" +
+	"headerName\x12!\n" +
+	"\fheader_value\x18\x03 \x01(\tR\vheaderValue\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x13\n" +
+	"\x11GetLoggingRequest\"g\n" +
+	"\x12GetLoggingResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12;\n" +
+	"\vdebug_rules\x18\x02 \x03(\v2\x1a.store.v1.DebugLoggingRuleR\n" +
+	"debugRules\"2\n" +
+	"\x12SetLogLevelRequest\x12\x1c\n" +
+	"\x05level\x18\x01 \x01(\tB\x06\x8a\xb2\x19\x02\b\x01R\x05level\"R\n" +
+	"\x13SetLogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12%\n" +
+	"\x0eprevious_level\x18\x02 \x01(\tR\rpreviousLevel\"\xcf\x01\n" +
+	"\x19EnableDebugLoggingRequest\x127\n" +
+	"\x10target_tenant_id\x18\x01 \x01(\x03B\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00R\x0etargetTenantId\x12\x1f\n" +
+	"\vheader_name\x18\x02 \x01(\tR\n" +
+	"headerName\x12!\n" +
+	"\fheader_value\x18\x03 \x01(\tR\vheaderValue\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\"L\n" +
+	"\x1aEnableDebugLoggingResponse\x12.\n" +
+	"\x04rule\x18\x01 \x01(\v2\x1a.store.v1.DebugLoggingRuleR\x04rule\"\x1a\n" +
+	"\x18ClearDebugLoggingRequest\"5\n" +
+	"\x19ClearDebugLoggingResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\x05R\aremoved*\xb3\x01\n" +
 	"\fItemCategory\x12\x1d\n" +
 	"\x19ITEM_CATEGORY_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19ITEM_CATEGORY_ELECTRONICS\x10\x01\x12\x1a\n" +
@@ -1176,17 +2952,30 @@ const file_store_proto_rawDesc = "" +
 	"\x12ITEM_STATUS_ACTIVE\x10\x01\x12\x18\n" +
 	"\x14ITEM_STATUS_INACTIVE\x10\x02\x12\x1c\n" +
 	"\x18ITEM_STATUS_OUT_OF_STOCK\x10\x03\x12\x1c\n" +
-	"\x18ITEM_STATUS_DISCONTINUED\x10\x042\xc7\x03\n" +
-	"\fStoreService\x12G\n" +
+	"\x18ITEM_STATUS_DISCONTINUED\x10\x042\x83\r\n" +
+	"\fStoreService\x12q\n" +
+	"\n" +
+	"CreateItem\x12\x1b.store.v1.CreateItemRequest\x1a\x1c.store.v1.CreateItemResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/tenants/{tenant_id}/items\x12j\n" +
+	"\aGetItem\x12\x18.store.v1.GetItemRequest\x1a\x19.store.v1.GetItemResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/tenants/{tenant_id}/items/{id}\x12v\n" +
 	"\n" +
-	"CreateItem\x12\x1b.store.v1.CreateItemRequest\x1a\x1c.store.v1.CreateItemResponse\x12>\n" +
-	"\aGetItem\x12\x18.store.v1.GetItemRequest\x1a\x19.store.v1.GetItemResponse\x12G\n" +
+	"UpdateItem\x12\x1b.store.v1.UpdateItemRequest\x1a\x1c.store.v1.UpdateItemResponse\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/tenants/{tenant_id}/items/{id}\x12s\n" +
 	"\n" +
-	"UpdateItem\x12\x1b.store.v1.UpdateItemRequest\x1a\x1c.store.v1.UpdateItemResponse\x12G\n" +
+	"DeleteItem\x12\x1b.store.v1.DeleteItemRequest\x1a\x1c.store.v1.DeleteItemResponse\"*\x82\xd3\xe4\x93\x02$*\"/v1/tenants/{tenant_id}/items/{id}\x12k\n" +
+	"\tListItems\x12\x1a.store.v1.ListItemsRequest\x1a\x1b.store.v1.ListItemsResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/tenants/{tenant_id}/items\x12\x94\x01\n" +
+	"\x0fUpdateInventory\x12 .store.v1.UpdateInventoryRequest\x1a!.store.v1.UpdateInventoryResponse\"<\x82\xd3\xe4\x93\x026:\x01*\"1/v1/tenants/{tenant_id}/items/{item_id}/inventory\x12\x8d\x01\n" +
+	"\x11ListLowStockItems\x12\".store.v1.ListLowStockItemsRequest\x1a#.store.v1.ListLowStockItemsResponse\"/\x82\xd3\xe4\x93\x02)\x12'/v1/tenants/{tenant_id}/low-stock-items\x12~\n" +
+	"\x0fGetTenantConfig\x12 .store.v1.GetTenantConfigRequest\x1a!.store.v1.GetTenantConfigResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/v1/tenants/{tenant_id}/config\x12\x8a\x01\n" +
+	"\x12UpdateTenantConfig\x12#.store.v1.UpdateTenantConfigRequest\x1a$.store.v1.UpdateTenantConfigResponse\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/v1/tenants/{tenant_id}/config\x12\x81\x01\n" +
+	"\x0fSetTenantQuotas\x12 .store.v1.SetTenantQuotasRequest\x1a!.store.v1.SetTenantQuotasResponse\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/v1/tenants/{tenant_id}/quotas\x12z\n" +
+	"\x0eGetTenantUsage\x12\x1f.store.v1.GetTenantUsageRequest\x1a .store.v1.GetTenantUsageResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/tenants/{tenant_id}/usage\x12\x7f\n" +
+	"\fRecountItems\x12\x1d.store.v1.RecountItemsRequest\x1a\x1e.store.v1.RecountItemsResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/tenants/{tenant_id}/usage/recount\x12\x84\x01\n" +
+	"\x0fListAuditEvents\x12 .store.v1.ListAuditEventsRequest\x1a!.store.v1.ListAuditEventsResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/tenants/{tenant_id}/audit-events2\xe2\x02\n" +
+	"\fAdminService\x12G\n" +
 	"\n" +
-	"DeleteItem\x12\x1b.store.v1.DeleteItemRequest\x1a\x1c.store.v1.DeleteItemResponse\x12D\n" +
-	"\tListItems\x12\x1a.store.v1.ListItemsRequest\x1a\x1b.store.v1.ListItemsResponse\x12V\n" +
-	"\x0fUpdateInventory\x12 .store.v1.UpdateInventoryRequest\x1a!.store.v1.UpdateInventoryResponseB7Z5github.com/rinsecrm/store-service/proto/go;storeprotob\x06proto3"
+	"GetLogging\x12\x1b.store.v1.GetLoggingRequest\x1a\x1c.store.v1.GetLoggingResponse\x12J\n" +
+	"\vSetLogLevel\x12\x1c.store.v1.SetLogLevelRequest\x1a\x1d.store.v1.SetLogLevelResponse\x12_\n" +
+	"\x12EnableDebugLogging\x12#.store.v1.EnableDebugLoggingRequest\x1a$.store.v1.EnableDebugLoggingResponse\x12\\\n" +
+	"\x11ClearDebugLogging\x12\".store.v1.ClearDebugLoggingRequest\x1a#.store.v1.ClearDebugLoggingResponseB7Z5github.com/rinsecrm/store-service/proto/go;storeprotob\x06proto3"
 
 var (
 	file_store_proto_rawDescOnce sync.Once
@@ -1201,57 +2990,126 @@ func file_store_proto_rawDescGZIP() []byte {
 }
 
 var file_store_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_store_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_store_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_store_proto_goTypes = []any{
-	(ItemCategory)(0),               // 0: store.v1.ItemCategory
-	(ItemStatus)(0),                 // 1: store.v1.ItemStatus
-	(*Item)(nil),                    // 2: store.v1.Item
-	(*CreateItemRequest)(nil),       // 3: store.v1.CreateItemRequest
-	(*CreateItemResponse)(nil),      // 4: store.v1.CreateItemResponse
-	(*GetItemRequest)(nil),          // 5: store.v1.GetItemRequest
-	(*GetItemResponse)(nil),         // 6: store.v1.GetItemResponse
-	(*UpdateItemRequest)(nil),       // 7: store.v1.UpdateItemRequest
-	(*UpdateItemResponse)(nil),      // 8: store.v1.UpdateItemResponse
-	(*DeleteItemRequest)(nil),       // 9: store.v1.DeleteItemRequest
-	(*DeleteItemResponse)(nil),      // 10: store.v1.DeleteItemResponse
-	(*ListItemsRequest)(nil),        // 11: store.v1.ListItemsRequest
-	(*ListItemsResponse)(nil),       // 12: store.v1.ListItemsResponse
-	(*UpdateInventoryRequest)(nil),  // 13: store.v1.UpdateInventoryRequest
-	(*UpdateInventoryResponse)(nil), // 14: store.v1.UpdateInventoryResponse
-	(*timestamppb.Timestamp)(nil),   // 15: google.protobuf.Timestamp
+	(ItemCategory)(0),                  // 0: store.v1.ItemCategory
+	(ItemStatus)(0),                    // 1: store.v1.ItemStatus
+	(*Item)(nil),                       // 2: store.v1.Item
+	(*TenantQuotas)(nil),               // 3: store.v1.TenantQuotas
+	(*TenantConfig)(nil),               // 4: store.v1.TenantConfig
+	(*TenantUsage)(nil),                // 5: store.v1.TenantUsage
+	(*CreateItemRequest)(nil),          // 6: store.v1.CreateItemRequest
+	(*CreateItemResponse)(nil),         // 7: store.v1.CreateItemResponse
+	(*GetItemRequest)(nil),             // 8: store.v1.GetItemRequest
+	(*GetItemResponse)(nil),            // 9: store.v1.GetItemResponse
+	(*UpdateItemRequest)(nil),          // 10: store.v1.UpdateItemRequest
+	(*UpdateItemResponse)(nil),         // 11: store.v1.UpdateItemResponse
+	(*DeleteItemRequest)(nil),          // 12: store.v1.DeleteItemRequest
+	(*DeleteItemResponse)(nil),         // 13: store.v1.DeleteItemResponse
+	(*ListItemsRequest)(nil),           // 14: store.v1.ListItemsRequest
+	(*ListItemsResponse)(nil),          // 15: store.v1.ListItemsResponse
+	(*UpdateInventoryRequest)(nil),     // 16: store.v1.UpdateInventoryRequest
+	(*UpdateInventoryResponse)(nil),    // 17: store.v1.UpdateInventoryResponse
+	(*ListLowStockItemsRequest)(nil),   // 18: store.v1.ListLowStockItemsRequest
+	(*ListLowStockItemsResponse)(nil),  // 19: store.v1.ListLowStockItemsResponse
+	(*GetTenantConfigRequest)(nil),     // 20: store.v1.GetTenantConfigRequest
+	(*GetTenantConfigResponse)(nil),    // 21: store.v1.GetTenantConfigResponse
+	(*UpdateTenantConfigRequest)(nil),  // 22: store.v1.UpdateTenantConfigRequest
+	(*UpdateTenantConfigResponse)(nil), // 23: store.v1.UpdateTenantConfigResponse
+	(*SetTenantQuotasRequest)(nil),     // 24: store.v1.SetTenantQuotasRequest
+	(*SetTenantQuotasResponse)(nil),    // 25: store.v1.SetTenantQuotasResponse
+	(*GetTenantUsageRequest)(nil),      // 26: store.v1.GetTenantUsageRequest
+	(*GetTenantUsageResponse)(nil),     // 27: store.v1.GetTenantUsageResponse
+	(*RecountItemsRequest)(nil),        // 28: store.v1.RecountItemsRequest
+	(*RecountItemsResponse)(nil),       // 29: store.v1.RecountItemsResponse
+	(*FieldChange)(nil),                // 30: store.v1.FieldChange
+	(*AuditEvent)(nil),                 // 31: store.v1.AuditEvent
+	(*ListAuditEventsRequest)(nil),     // 32: store.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),    // 33: store.v1.ListAuditEventsResponse
+	(*DebugLoggingRule)(nil),           // 34: store.v1.DebugLoggingRule
+	(*GetLoggingRequest)(nil),          // 35: store.v1.GetLoggingRequest
+	(*GetLoggingResponse)(nil),         // 36: store.v1.GetLoggingResponse
+	(*SetLogLevelRequest)(nil),         // 37: store.v1.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),        // 38: store.v1.SetLogLevelResponse
+	(*EnableDebugLoggingRequest)(nil),  // 39: store.v1.EnableDebugLoggingRequest
+	(*EnableDebugLoggingResponse)(nil), // 40: store.v1.EnableDebugLoggingResponse
+	(*ClearDebugLoggingRequest)(nil),   // 41: store.v1.ClearDebugLoggingRequest
+	(*ClearDebugLoggingResponse)(nil),  // 42: store.v1.ClearDebugLoggingResponse
+	(*timestamppb.Timestamp)(nil),      // 43: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 44: google.protobuf.Duration
 }
 var file_store_proto_depIdxs = []int32{
 	0,  // 0: store.v1.Item.category:type_name -> store.v1.ItemCategory
 	1,  // 1: store.v1.Item.status:type_name -> store.v1.ItemStatus
-	15, // 2: store.v1.Item.created_at:type_name -> google.protobuf.Timestamp
-	15, // 3: store.v1.Item.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: store.v1.CreateItemRequest.category:type_name -> store.v1.ItemCategory
-	2,  // 5: store.v1.CreateItemResponse.item:type_name -> store.v1.Item
-	2,  // 6: store.v1.GetItemResponse.item:type_name -> store.v1.Item
-	0,  // 7: store.v1.UpdateItemRequest.category:type_name -> store.v1.ItemCategory
-	1,  // 8: store.v1.UpdateItemRequest.status:type_name -> store.v1.ItemStatus
-	2,  // 9: store.v1.UpdateItemResponse.item:type_name -> store.v1.Item
-	0,  // 10: store.v1.ListItemsRequest.category:type_name -> store.v1.ItemCategory
-	1,  // 11: store.v1.ListItemsRequest.status:type_name -> store.v1.ItemStatus
-	2,  // 12: store.v1.ListItemsResponse.items:type_name -> store.v1.Item
-	2,  // 13: store.v1.UpdateInventoryResponse.item:type_name -> store.v1.Item
-	3,  // 14: store.v1.StoreService.CreateItem:input_type -> store.v1.CreateItemRequest
-	5,  // 15: store.v1.StoreService.GetItem:input_type -> store.v1.GetItemRequest
-	7,  // 16: store.v1.StoreService.UpdateItem:input_type -> store.v1.UpdateItemRequest
-	9,  // 17: store.v1.StoreService.DeleteItem:input_type -> store.v1.DeleteItemRequest
-	11, // 18: store.v1.StoreService.ListItems:input_type -> store.v1.ListItemsRequest
-	13, // 19: store.v1.StoreService.UpdateInventory:input_type -> store.v1.UpdateInventoryRequest
-	4,  // 20: store.v1.StoreService.CreateItem:output_type -> store.v1.CreateItemResponse
-	6,  // 21: store.v1.StoreService.GetItem:output_type -> store.v1.GetItemResponse
-	8,  // 22: store.v1.StoreService.UpdateItem:output_type -> store.v1.UpdateItemResponse
-	10, // 23: store.v1.StoreService.DeleteItem:output_type -> store.v1.DeleteItemResponse
-	12, // 24: store.v1.StoreService.ListItems:output_type -> store.v1.ListItemsResponse
-	14, // 25: store.v1.StoreService.UpdateInventory:output_type -> store.v1.UpdateInventoryResponse
-	20, // [20:26] is the sub-list for method output_type
-	14, // [14:20] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	43, // 2: store.v1.Item.created_at:type_name -> google.protobuf.Timestamp
+	43, // 3: store.v1.Item.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 4: store.v1.TenantConfig.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 5: store.v1.TenantConfig.quotas:type_name -> store.v1.TenantQuotas
+	3,  // 6: store.v1.TenantUsage.quotas:type_name -> store.v1.TenantQuotas
+	0,  // 7: store.v1.CreateItemRequest.category:type_name -> store.v1.ItemCategory
+	2,  // 8: store.v1.CreateItemResponse.item:type_name -> store.v1.Item
+	2,  // 9: store.v1.GetItemResponse.item:type_name -> store.v1.Item
+	0,  // 10: store.v1.UpdateItemRequest.category:type_name -> store.v1.ItemCategory
+	1,  // 11: store.v1.UpdateItemRequest.status:type_name -> store.v1.ItemStatus
+	2,  // 12: store.v1.UpdateItemResponse.item:type_name -> store.v1.Item
+	0,  // 13: store.v1.ListItemsRequest.category:type_name -> store.v1.ItemCategory
+	1,  // 14: store.v1.ListItemsRequest.status:type_name -> store.v1.ItemStatus
+	2,  // 15: store.v1.ListItemsResponse.items:type_name -> store.v1.Item
+	2,  // 16: store.v1.UpdateInventoryResponse.item:type_name -> store.v1.Item
+	2,  // 17: store.v1.ListLowStockItemsResponse.items:type_name -> store.v1.Item
+	4,  // 18: store.v1.GetTenantConfigResponse.config:type_name -> store.v1.TenantConfig
+	4,  // 19: store.v1.UpdateTenantConfigResponse.config:type_name -> store.v1.TenantConfig
+	3,  // 20: store.v1.SetTenantQuotasRequest.quotas:type_name -> store.v1.TenantQuotas
+	4,  // 21: store.v1.SetTenantQuotasResponse.config:type_name -> store.v1.TenantConfig
+	5,  // 22: store.v1.GetTenantUsageResponse.usage:type_name -> store.v1.TenantUsage
+	30, // 23: store.v1.AuditEvent.changes:type_name -> store.v1.FieldChange
+	43, // 24: store.v1.AuditEvent.timestamp:type_name -> google.protobuf.Timestamp
+	43, // 25: store.v1.ListAuditEventsRequest.start_time:type_name -> google.protobuf.Timestamp
+	43, // 26: store.v1.ListAuditEventsRequest.end_time:type_name -> google.protobuf.Timestamp
+	31, // 27: store.v1.ListAuditEventsResponse.events:type_name -> store.v1.AuditEvent
+	43, // 28: store.v1.DebugLoggingRule.expires_at:type_name -> google.protobuf.Timestamp
+	34, // 29: store.v1.GetLoggingResponse.debug_rules:type_name -> store.v1.DebugLoggingRule
+	44, // 30: store.v1.EnableDebugLoggingRequest.duration:type_name -> google.protobuf.Duration
+	34, // 31: store.v1.EnableDebugLoggingResponse.rule:type_name -> store.v1.DebugLoggingRule
+	6,  // 32: store.v1.StoreService.CreateItem:input_type -> store.v1.CreateItemRequest
+	8,  // 33: store.v1.StoreService.GetItem:input_type -> store.v1.GetItemRequest
+	10, // 34: store.v1.StoreService.UpdateItem:input_type -> store.v1.UpdateItemRequest
+	12, // 35: store.v1.StoreService.DeleteItem:input_type -> store.v1.DeleteItemRequest
+	14, // 36: store.v1.StoreService.ListItems:input_type -> store.v1.ListItemsRequest
+	16, // 37: store.v1.StoreService.UpdateInventory:input_type -> store.v1.UpdateInventoryRequest
+	18, // 38: store.v1.StoreService.ListLowStockItems:input_type -> store.v1.ListLowStockItemsRequest
+	20, // 39: store.v1.StoreService.GetTenantConfig:input_type -> store.v1.GetTenantConfigRequest
+	22, // 40: store.v1.StoreService.UpdateTenantConfig:input_type -> store.v1.UpdateTenantConfigRequest
+	24, // 41: store.v1.StoreService.SetTenantQuotas:input_type -> store.v1.SetTenantQuotasRequest
+	26, // 42: store.v1.StoreService.GetTenantUsage:input_type -> store.v1.GetTenantUsageRequest
+	28, // 43: store.v1.StoreService.RecountItems:input_type -> store.v1.RecountItemsRequest
+	32, // 44: store.v1.StoreService.ListAuditEvents:input_type -> store.v1.ListAuditEventsRequest
+	35, // 45: store.v1.AdminService.GetLogging:input_type -> store.v1.GetLoggingRequest
+	37, // 46: store.v1.AdminService.SetLogLevel:input_type -> store.v1.SetLogLevelRequest
+	39, // 47: store.v1.AdminService.EnableDebugLogging:input_type -> store.v1.EnableDebugLoggingRequest
+	41, // 48: store.v1.AdminService.ClearDebugLogging:input_type -> store.v1.ClearDebugLoggingRequest
+	7,  // 49: store.v1.StoreService.CreateItem:output_type -> store.v1.CreateItemResponse
+	9,  // 50: store.v1.StoreService.GetItem:output_type -> store.v1.GetItemResponse
+	11, // 51: store.v1.StoreService.UpdateItem:output_type -> store.v1.UpdateItemResponse
+	13, // 52: store.v1.StoreService.DeleteItem:output_type -> store.v1.DeleteItemResponse
+	15, // 53: store.v1.StoreService.ListItems:output_type -> store.v1.ListItemsResponse
+	17, // 54: store.v1.StoreService.UpdateInventory:output_type -> store.v1.UpdateInventoryResponse
+	19, // 55: store.v1.StoreService.ListLowStockItems:output_type -> store.v1.ListLowStockItemsResponse
+	21, // 56: store.v1.StoreService.GetTenantConfig:output_type -> store.v1.GetTenantConfigResponse
+	23, // 57: store.v1.StoreService.UpdateTenantConfig:output_type -> store.v1.UpdateTenantConfigResponse
+	25, // 58: store.v1.StoreService.SetTenantQuotas:output_type -> store.v1.SetTenantQuotasResponse
+	27, // 59: store.v1.StoreService.GetTenantUsage:output_type -> store.v1.GetTenantUsageResponse
+	29, // 60: store.v1.StoreService.RecountItems:output_type -> store.v1.RecountItemsResponse
+	33, // 61: store.v1.StoreService.ListAuditEvents:output_type -> store.v1.ListAuditEventsResponse
+	36, // 62: store.v1.AdminService.GetLogging:output_type -> store.v1.GetLoggingResponse
+	38, // 63: store.v1.AdminService.SetLogLevel:output_type -> store.v1.SetLogLevelResponse
+	40, // 64: store.v1.AdminService.EnableDebugLogging:output_type -> store.v1.EnableDebugLoggingResponse
+	42, // 65: store.v1.AdminService.ClearDebugLogging:output_type -> store.v1.ClearDebugLoggingResponse
+	49, // [49:66] is the sub-list for method output_type
+	32, // [32:49] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_store_proto_init() }
@@ -1259,15 +3117,16 @@ func file_store_proto_init() {
 	if File_store_proto != nil {
 		return
 	}
+	file_validate_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_proto_rawDesc), len(file_store_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   13,
+			NumMessages:   41,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_store_proto_goTypes,
 		DependencyIndexes: file_store_proto_depIdxs,
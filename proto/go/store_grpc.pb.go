@@ -19,12 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StoreService_CreateItem_FullMethodName      = "/store.v1.StoreService/CreateItem"
-	StoreService_GetItem_FullMethodName         = "/store.v1.StoreService/GetItem"
-	StoreService_UpdateItem_FullMethodName      = "/store.v1.StoreService/UpdateItem"
-	StoreService_DeleteItem_FullMethodName      = "/store.v1.StoreService/DeleteItem"
-	StoreService_ListItems_FullMethodName       = "/store.v1.StoreService/ListItems"
-	StoreService_UpdateInventory_FullMethodName = "/store.v1.StoreService/UpdateInventory"
+	StoreService_CreateItem_FullMethodName         = "/store.v1.StoreService/CreateItem"
+	StoreService_GetItem_FullMethodName            = "/store.v1.StoreService/GetItem"
+	StoreService_UpdateItem_FullMethodName         = "/store.v1.StoreService/UpdateItem"
+	StoreService_DeleteItem_FullMethodName         = "/store.v1.StoreService/DeleteItem"
+	StoreService_ListItems_FullMethodName          = "/store.v1.StoreService/ListItems"
+	StoreService_UpdateInventory_FullMethodName    = "/store.v1.StoreService/UpdateInventory"
+	StoreService_ListLowStockItems_FullMethodName  = "/store.v1.StoreService/ListLowStockItems"
+	StoreService_GetTenantConfig_FullMethodName    = "/store.v1.StoreService/GetTenantConfig"
+	StoreService_UpdateTenantConfig_FullMethodName = "/store.v1.StoreService/UpdateTenantConfig"
+	StoreService_SetTenantQuotas_FullMethodName    = "/store.v1.StoreService/SetTenantQuotas"
+	StoreService_GetTenantUsage_FullMethodName     = "/store.v1.StoreService/GetTenantUsage"
+	StoreService_RecountItems_FullMethodName       = "/store.v1.StoreService/RecountItems"
+	StoreService_ListAuditEvents_FullMethodName    = "/store.v1.StoreService/ListAuditEvents"
 )
 
 // StoreServiceClient is the client API for StoreService service.
@@ -45,6 +52,21 @@ type StoreServiceClient interface {
 	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error)
 	// UpdateInventory updates the inventory count for an item
 	UpdateInventory(ctx context.Context, in *UpdateInventoryRequest, opts ...grpc.CallOption) (*UpdateInventoryResponse, error)
+	// ListLowStockItems lists items whose inventory is at or below their low stock threshold
+	ListLowStockItems(ctx context.Context, in *ListLowStockItemsRequest, opts ...grpc.CallOption) (*ListLowStockItemsResponse, error)
+	// GetTenantConfig retrieves the settings for a tenant
+	GetTenantConfig(ctx context.Context, in *GetTenantConfigRequest, opts ...grpc.CallOption) (*GetTenantConfigResponse, error)
+	// UpdateTenantConfig updates the settings for a tenant
+	UpdateTenantConfig(ctx context.Context, in *UpdateTenantConfigRequest, opts ...grpc.CallOption) (*UpdateTenantConfigResponse, error)
+	// SetTenantQuotas replaces the plan limits of a tenant; only operators may call it
+	SetTenantQuotas(ctx context.Context, in *SetTenantQuotasRequest, opts ...grpc.CallOption) (*SetTenantQuotasResponse, error)
+	// GetTenantUsage reports a tenant's current usage against each of its quotas
+	GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*GetTenantUsageResponse, error)
+	// RecountItems resets a tenant's item count to its items that are not
+	// discontinued, backfilling counts that predate quotas; only operators may call it
+	RecountItems(ctx context.Context, in *RecountItemsRequest, opts ...grpc.CallOption) (*RecountItemsResponse, error)
+	// ListAuditEvents lists the audit trail of catalog mutations for a tenant
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 }
 
 type storeServiceClient struct {
@@ -115,6 +137,76 @@ func (c *storeServiceClient) UpdateInventory(ctx context.Context, in *UpdateInve
 	return out, nil
 }
 
+func (c *storeServiceClient) ListLowStockItems(ctx context.Context, in *ListLowStockItemsRequest, opts ...grpc.CallOption) (*ListLowStockItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLowStockItemsResponse)
+	err := c.cc.Invoke(ctx, StoreService_ListLowStockItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) GetTenantConfig(ctx context.Context, in *GetTenantConfigRequest, opts ...grpc.CallOption) (*GetTenantConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTenantConfigResponse)
+	err := c.cc.Invoke(ctx, StoreService_GetTenantConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) UpdateTenantConfig(ctx context.Context, in *UpdateTenantConfigRequest, opts ...grpc.CallOption) (*UpdateTenantConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTenantConfigResponse)
+	err := c.cc.Invoke(ctx, StoreService_UpdateTenantConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) SetTenantQuotas(ctx context.Context, in *SetTenantQuotasRequest, opts ...grpc.CallOption) (*SetTenantQuotasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTenantQuotasResponse)
+	err := c.cc.Invoke(ctx, StoreService_SetTenantQuotas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*GetTenantUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTenantUsageResponse)
+	err := c.cc.Invoke(ctx, StoreService_GetTenantUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) RecountItems(ctx context.Context, in *RecountItemsRequest, opts ...grpc.CallOption) (*RecountItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecountItemsResponse)
+	err := c.cc.Invoke(ctx, StoreService_RecountItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, StoreService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StoreServiceServer is the server API for StoreService service.
 // All implementations must embed UnimplementedStoreServiceServer
 // for forward compatibility.
@@ -133,6 +225,21 @@ type StoreServiceServer interface {
 	ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error)
 	// UpdateInventory updates the inventory count for an item
 	UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryResponse, error)
+	// ListLowStockItems lists items whose inventory is at or below their low stock threshold
+	ListLowStockItems(context.Context, *ListLowStockItemsRequest) (*ListLowStockItemsResponse, error)
+	// GetTenantConfig retrieves the settings for a tenant
+	GetTenantConfig(context.Context, *GetTenantConfigRequest) (*GetTenantConfigResponse, error)
+	// UpdateTenantConfig updates the settings for a tenant
+	UpdateTenantConfig(context.Context, *UpdateTenantConfigRequest) (*UpdateTenantConfigResponse, error)
+	// SetTenantQuotas replaces the plan limits of a tenant; only operators may call it
+	SetTenantQuotas(context.Context, *SetTenantQuotasRequest) (*SetTenantQuotasResponse, error)
+	// GetTenantUsage reports a tenant's current usage against each of its quotas
+	GetTenantUsage(context.Context, *GetTenantUsageRequest) (*GetTenantUsageResponse, error)
+	// RecountItems resets a tenant's item count to its items that are not
+	// discontinued, backfilling counts that predate quotas; only operators may call it
+	RecountItems(context.Context, *RecountItemsRequest) (*RecountItemsResponse, error)
+	// ListAuditEvents lists the audit trail of catalog mutations for a tenant
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 	mustEmbedUnimplementedStoreServiceServer()
 }
 
@@ -161,6 +268,27 @@ func (UnimplementedStoreServiceServer) ListItems(context.Context, *ListItemsRequ
 func (UnimplementedStoreServiceServer) UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateInventory not implemented")
 }
+func (UnimplementedStoreServiceServer) ListLowStockItems(context.Context, *ListLowStockItemsRequest) (*ListLowStockItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLowStockItems not implemented")
+}
+func (UnimplementedStoreServiceServer) GetTenantConfig(context.Context, *GetTenantConfigRequest) (*GetTenantConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenantConfig not implemented")
+}
+func (UnimplementedStoreServiceServer) UpdateTenantConfig(context.Context, *UpdateTenantConfigRequest) (*UpdateTenantConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTenantConfig not implemented")
+}
+func (UnimplementedStoreServiceServer) SetTenantQuotas(context.Context, *SetTenantQuotasRequest) (*SetTenantQuotasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTenantQuotas not implemented")
+}
+func (UnimplementedStoreServiceServer) GetTenantUsage(context.Context, *GetTenantUsageRequest) (*GetTenantUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenantUsage not implemented")
+}
+func (UnimplementedStoreServiceServer) RecountItems(context.Context, *RecountItemsRequest) (*RecountItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecountItems not implemented")
+}
+func (UnimplementedStoreServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedStoreServiceServer) mustEmbedUnimplementedStoreServiceServer() {}
 func (UnimplementedStoreServiceServer) testEmbeddedByValue()                      {}
 
@@ -290,6 +418,132 @@ func _StoreService_UpdateInventory_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoreService_ListLowStockItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowStockItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).ListLowStockItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_ListLowStockItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).ListLowStockItems(ctx, req.(*ListLowStockItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_GetTenantConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).GetTenantConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_GetTenantConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).GetTenantConfig(ctx, req.(*GetTenantConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_UpdateTenantConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTenantConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).UpdateTenantConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_UpdateTenantConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).UpdateTenantConfig(ctx, req.(*UpdateTenantConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_SetTenantQuotas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTenantQuotasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).SetTenantQuotas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_SetTenantQuotas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).SetTenantQuotas(ctx, req.(*SetTenantQuotasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_GetTenantUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).GetTenantUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_GetTenantUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).GetTenantUsage(ctx, req.(*GetTenantUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_RecountItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecountItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).RecountItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_RecountItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).RecountItems(ctx, req.(*RecountItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoreService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StoreService_ServiceDesc is the grpc.ServiceDesc for StoreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -321,6 +575,262 @@ var StoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateInventory",
 			Handler:    _StoreService_UpdateInventory_Handler,
 		},
+		{
+			MethodName: "ListLowStockItems",
+			Handler:    _StoreService_ListLowStockItems_Handler,
+		},
+		{
+			MethodName: "GetTenantConfig",
+			Handler:    _StoreService_GetTenantConfig_Handler,
+		},
+		{
+			MethodName: "UpdateTenantConfig",
+			Handler:    _StoreService_UpdateTenantConfig_Handler,
+		},
+		{
+			MethodName: "SetTenantQuotas",
+			Handler:    _StoreService_SetTenantQuotas_Handler,
+		},
+		{
+			MethodName: "GetTenantUsage",
+			Handler:    _StoreService_GetTenantUsage_Handler,
+		},
+		{
+			MethodName: "RecountItems",
+			Handler:    _StoreService_RecountItems_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _StoreService_ListAuditEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "store.proto",
+}
+
+const (
+	AdminService_GetLogging_FullMethodName         = "/store.v1.AdminService/GetLogging"
+	AdminService_SetLogLevel_FullMethodName        = "/store.v1.AdminService/SetLogLevel"
+	AdminService_EnableDebugLogging_FullMethodName = "/store.v1.AdminService/EnableDebugLogging"
+	AdminService_ClearDebugLogging_FullMethodName  = "/store.v1.AdminService/ClearDebugLogging"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService controls operational settings of a running instance
+type AdminServiceClient interface {
+	// GetLogging reports the log level and active debug logging rules
+	GetLogging(ctx context.Context, in *GetLoggingRequest, opts ...grpc.CallOption) (*GetLoggingResponse, error)
+	// SetLogLevel changes the log level until the next restart
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// EnableDebugLogging logs at debug level for matching requests for a limited time
+	EnableDebugLogging(ctx context.Context, in *EnableDebugLoggingRequest, opts ...grpc.CallOption) (*EnableDebugLoggingResponse, error)
+	// ClearDebugLogging removes every debug logging rule
+	ClearDebugLogging(ctx context.Context, in *ClearDebugLoggingRequest, opts ...grpc.CallOption) (*ClearDebugLoggingResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) GetLogging(ctx context.Context, in *GetLoggingRequest, opts ...grpc.CallOption) (*GetLoggingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLoggingResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetLogging_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) EnableDebugLogging(ctx context.Context, in *EnableDebugLoggingRequest, opts ...grpc.CallOption) (*EnableDebugLoggingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableDebugLoggingResponse)
+	err := c.cc.Invoke(ctx, AdminService_EnableDebugLogging_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClearDebugLogging(ctx context.Context, in *ClearDebugLoggingRequest, opts ...grpc.CallOption) (*ClearDebugLoggingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearDebugLoggingResponse)
+	err := c.cc.Invoke(ctx, AdminService_ClearDebugLogging_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService controls operational settings of a running instance
+type AdminServiceServer interface {
+	// GetLogging reports the log level and active debug logging rules
+	GetLogging(context.Context, *GetLoggingRequest) (*GetLoggingResponse, error)
+	// SetLogLevel changes the log level until the next restart
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// EnableDebugLogging logs at debug level for matching requests for a limited time
+	EnableDebugLogging(context.Context, *EnableDebugLoggingRequest) (*EnableDebugLoggingResponse, error)
+	// ClearDebugLogging removes every debug logging rule
+	ClearDebugLogging(context.Context, *ClearDebugLoggingRequest) (*ClearDebugLoggingResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) GetLogging(context.Context, *GetLoggingRequest) (*GetLoggingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogging not implemented")
+}
+func (UnimplementedAdminServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAdminServiceServer) EnableDebugLogging(context.Context, *EnableDebugLoggingRequest) (*EnableDebugLoggingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableDebugLogging not implemented")
+}
+func (UnimplementedAdminServiceServer) ClearDebugLogging(context.Context, *ClearDebugLoggingRequest) (*ClearDebugLoggingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearDebugLogging not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_GetLogging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoggingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLogging(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetLogging_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLogging(ctx, req.(*GetLoggingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_EnableDebugLogging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableDebugLoggingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EnableDebugLogging(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_EnableDebugLogging_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EnableDebugLogging(ctx, req.(*EnableDebugLoggingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClearDebugLogging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearDebugLoggingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClearDebugLogging(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ClearDebugLogging_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClearDebugLogging(ctx, req.(*ClearDebugLoggingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "store.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLogging",
+			Handler:    _AdminService_GetLogging_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "EnableDebugLogging",
+			Handler:    _AdminService_EnableDebugLogging_Handler,
+		},
+		{
+			MethodName: "ClearDebugLogging",
+			Handler:    _AdminService_ClearDebugLogging_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "store.proto",
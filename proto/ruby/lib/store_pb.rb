@@ -4,10 +4,13 @@
 
 require 'google/protobuf'
 
+require 'google/api/annotations_pb'
+require 'google/protobuf/duration_pb'
 require 'google/protobuf/timestamp_pb'
+require 'validate_pb'
 
 
-descriptor_data = "\n\x0bstore.proto\x12\x08store.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe3\x02\n\x04Item\x12\n\n\x02id\x18\x01 \x01(\t\x12\x11\n\ttenant_id\x18\x02 \x01(\x03\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12\r\n\x05price\x18\x05 \x01(\x01\x12(\n\x08\x63\x61tegory\x18\x06 \x01(\x0e\x32\x16.store.v1.ItemCategory\x12$\n\x06status\x18\x07 \x01(\x0e\x32\x14.store.v1.ItemStatus\x12\x0b\n\x03sku\x18\x08 \x01(\t\x12\x17\n\x0finventory_count\x18\t \x01(\x05\x12\x0c\n\x04tags\x18\n \x03(\t\x12.\n\ncreated_at\x18\x0b \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nupdated_at\x18\x0c \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x12\n\ncreated_by\x18\r \x01(\t\x12\x12\n\nupdated_by\x18\x0e \x01(\t\"\xca\x01\n\x11\x43reateItemRequest\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x03 \x01(\t\x12\r\n\x05price\x18\x04 \x01(\x01\x12(\n\x08\x63\x61tegory\x18\x05 \x01(\x0e\x32\x16.store.v1.ItemCategory\x12\x0b\n\x03sku\x18\x06 \x01(\t\x12\x17\n\x0finventory_count\x18\x07 \x01(\x05\x12\x0c\n\x04tags\x18\x08 \x03(\t\x12\x12\n\ncreated_by\x18\t \x01(\t\"2\n\x12\x43reateItemResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\"/\n\x0eGetItemRequest\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12\n\n\x02id\x18\x02 \x01(\t\"/\n\x0fGetItemResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\"\xfc\x01\n\x11UpdateItemRequest\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12\n\n\x02id\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12\r\n\x05price\x18\x05 \x01(\x01\x12(\n\x08\x63\x61tegory\x18\x06 \x01(\x0e\x32\x16.store.v1.ItemCategory\x12$\n\x06status\x18\x07 \x01(\x0e\x32\x14.store.v1.ItemStatus\x12\x0b\n\x03sku\x18\x08 \x01(\t\x12\x17\n\x0finventory_count\x18\t \x01(\x05\x12\x0c\n\x04tags\x18\n \x03(\t\x12\x12\n\nupdated_by\x18\x0b \x01(\t\"2\n\x12UpdateItemResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\"2\n\x11\x44\x65leteItemRequest\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12\n\n\x02id\x18\x02 \x01(\t\"%\n\x12\x44\x65leteItemResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\"\xb2\x01\n\x10ListItemsRequest\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12(\n\x08\x63\x61tegory\x18\x02 \x01(\x0e\x32\x16.store.v1.ItemCategory\x12$\n\x06status\x18\x03 \x01(\x0e\x32\x14.store.v1.ItemStatus\x12\x14\n\x0csearch_query\x18\x04 \x01(\t\x12\x11\n\tpage_size\x18\x05 \x01(\x05\x12\x12\n\npage_token\x18\x06 \x01(\t\"`\n\x11ListItemsResponse\x12\x1d\n\x05items\x18\x01 \x03(\x0b\x32\x0e.store.v1.Item\x12\x17\n\x0fnext_page_token\x18\x02 \x01(\t\x12\x13\n\x0btotal_count\x18\x03 \x01(\x05\"y\n\x16UpdateInventoryRequest\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12\x0f\n\x07item_id\x18\x02 \x01(\t\x12\x17\n\x0fquantity_change\x18\x03 \x01(\x05\x12\x0e\n\x06reason\x18\x04 \x01(\t\x12\x12\n\nupdated_by\x18\x05 \x01(\t\"O\n\x17UpdateInventoryResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\x12\x16\n\x0eprevious_count\x18\x02 \x01(\x05*\xb3\x01\n\x0cItemCategory\x12\x1d\n\x19ITEM_CATEGORY_UNSPECIFIED\x10\x00\x12\x1d\n\x19ITEM_CATEGORY_ELECTRONICS\x10\x01\x12\x1a\n\x16ITEM_CATEGORY_CLOTHING\x10\x02\x12\x17\n\x13ITEM_CATEGORY_BOOKS\x10\x03\x12\x16\n\x12ITEM_CATEGORY_HOME\x10\x04\x12\x18\n\x14ITEM_CATEGORY_SPORTS\x10\x05*\x97\x01\n\nItemStatus\x12\x1b\n\x17ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x16\n\x12ITEM_STATUS_ACTIVE\x10\x01\x12\x18\n\x14ITEM_STATUS_INACTIVE\x10\x02\x12\x1c\n\x18ITEM_STATUS_OUT_OF_STOCK\x10\x03\x12\x1c\n\x18ITEM_STATUS_DISCONTINUED\x10\x04\x32\xc7\x03\n\x0cStoreService\x12G\n\nCreateItem\x12\x1b.store.v1.CreateItemRequest\x1a\x1c.store.v1.CreateItemResponse\x12>\n\x07GetItem\x12\x18.store.v1.GetItemRequest\x1a\x19.store.v1.GetItemResponse\x12G\n\nUpdateItem\x12\x1b.store.v1.UpdateItemRequest\x1a\x1c.store.v1.UpdateItemResponse\x12G\n\nDeleteItem\x12\x1b.store.v1.DeleteItemRequest\x1a\x1c.store.v1.DeleteItemResponse\x12\x44\n\tListItems\x12\x1a.store.v1.ListItemsRequest\x1a\x1b.store.v1.ListItemsResponse\x12V\n\x0fUpdateInventory\x12 .store.v1.UpdateInventoryRequest\x1a!.store.v1.UpdateInventoryResponseB7Z5github.com/rinsecrm/store-service/proto/go;storeprotob\x06proto3"
+descriptor_data = "\n\x0bstore.proto\x12\x08store.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0evalidate.proto\"\x80\x03\n\x04Item\x12\n\n\x02id\x18\x01 \x01(\t\x12\x11\n\ttenant_id\x18\x02 \x01(\x03\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12\r\n\x05price\x18\x05 \x01(\x01\x12(\n\x08\x63\x61tegory\x18\x06 \x01(\x0e\x32\x16.store.v1.ItemCategory\x12$\n\x06status\x18\x07 \x01(\x0e\x32\x14.store.v1.ItemStatus\x12\x0b\n\x03sku\x18\x08 \x01(\t\x12\x17\n\x0finventory_count\x18\t \x01(\x05\x12\x0c\n\x04tags\x18\n \x03(\t\x12.\n\ncreated_at\x18\x0b \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nupdated_at\x18\x0c \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x12\n\ncreated_by\x18\r \x01(\t\x12\x12\n\nupdated_by\x18\x0e \x01(\t\x12\x1b\n\x13low_stock_threshold\x18\x0f \x01(\x05\"\xb4\x01\n\x0cTenantQuotas\x12 \n\tmax_items\x18\x01 \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12)\n\x12max_writes_per_day\x18\x02 \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12(\n\x11max_tags_per_item\x18\x03 \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12-\n\x16max_description_length\x18\x04 \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\"\xb2\x01\n\x0cTenantConfig\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12#\n\x1b\x64\x65\x66\x61ult_low_stock_threshold\x18\x02 \x01(\x05\x12.\n\nupdated_at\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x12\n\nupdated_by\x18\x04 \x01(\t\x12&\n\x06quotas\x18\x05 \x01(\x0b\x32\x16.store.v1.TenantQuotas\"r\n\x0bTenantUsage\x12\x11\n\ttenant_id\x18\x01 \x01(\x03\x12\x12\n\nitem_count\x18\x02 \x01(\x03\x12\x14\n\x0cwrites_today\x18\x03 \x01(\x03\x12&\n\x06quotas\x18\x04 \x01(\x0b\x32\x16.store.v1.TenantQuotas\"\xa1\x03\n\x11\x43reateItemRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x17\n\x04name\x18\x02 \x01(\tB\t\x8a\xb2\x19\x05\x08\x01\x38\xc8\x01\x12\x1c\n\x0b\x64\x65scription\x18\x03 \x01(\tB\x07\x8a\xb2\x19\x03\x38\x88\'\x12\x1c\n\x05price\x18\x04 \x01(\x01\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12\x30\n\x08\x63\x61tegory\x18\x05 \x01(\x0e\x32\x16.store.v1.ItemCategoryB\x06\x8a\xb2\x19\x02H\x01\x12\x34\n\x03sku\x18\x06 \x01(\tB\'\x8a\xb2\x19#B!^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$\x12&\n\x0finventory_count\x18\x07 \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12\x1a\n\x04tags\x18\x08 \x03(\tB\x0c\x8a\xb2\x19\x08P2Z\x04\x08\x01\x38\x32\x12\x1b\n\ncreated_by\x18\t \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\x12*\n\x13low_stock_threshold\x18\n \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12 \n\x0fidempotency_key\x18\x0b \x01(\tB\x07\x8a\xb2\x19\x03\x38\x80\x01\"2\n\x12\x43reateItemResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\"_\n\x0eGetItemRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x12\n\x02id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\x08\x01\x12\x17\n\x0f\x63onsistent_read\x18\x03 \x01(\x08\"/\n\x0fGetItemResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\"\xc1\x03\n\x11UpdateItemRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x12\n\x02id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\x08\x01\x12\x17\n\x04name\x18\x03 \x01(\tB\t\x8a\xb2\x19\x05\x08\x01\x38\xc8\x01\x12\x1c\n\x0b\x64\x65scription\x18\x04 \x01(\tB\x07\x8a\xb2\x19\x03\x38\x88\'\x12\x1c\n\x05price\x18\x05 \x01(\x01\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12\x30\n\x08\x63\x61tegory\x18\x06 \x01(\x0e\x32\x16.store.v1.ItemCategoryB\x06\x8a\xb2\x19\x02H\x01\x12,\n\x06status\x18\x07 \x01(\x0e\x32\x14.store.v1.ItemStatusB\x06\x8a\xb2\x19\x02H\x01\x12\x34\n\x03sku\x18\x08 \x01(\tB\'\x8a\xb2\x19#B!^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$\x12&\n\x0finventory_count\x18\t \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12\x1a\n\x04tags\x18\n \x03(\tB\x0c\x8a\xb2\x19\x08P2Z\x04\x08\x01\x38\x32\x12\x1b\n\nupdated_by\x18\x0b \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\x12*\n\x13low_stock_threshold\x18\x0c \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\"2\n\x12UpdateItemResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\"f\n\x11\x44\x65leteItemRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x12\n\x02id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\x08\x01\x12\x1b\n\ndeleted_by\x18\x03 \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\"%\n\x12\x44\x65leteItemResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\"\xda\x01\n\x10ListItemsRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x30\n\x08\x63\x61tegory\x18\x02 \x01(\x0e\x32\x16.store.v1.ItemCategoryB\x06\x8a\xb2\x19\x02H\x01\x12,\n\x06status\x18\x03 \x01(\x0e\x32\x14.store.v1.ItemStatusB\x06\x8a\xb2\x19\x02H\x01\x12\x1d\n\x0csearch_query\x18\x04 \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\x12\x11\n\tpage_size\x18\x05 \x01(\x05\x12\x12\n\npage_token\x18\x06 \x01(\t\"`\n\x11ListItemsResponse\x12\x1d\n\x05items\x18\x01 \x03(\x0b\x32\x0e.store.v1.Item\x12\x17\n\x0fnext_page_token\x18\x02 \x01(\t\x12\x13\n\x0btotal_count\x18\x03 \x01(\x05\"\xcc\x01\n\x16UpdateInventoryRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x17\n\x07item_id\x18\x02 \x01(\tB\x06\x8a\xb2\x19\x02\x08\x01\x12\x1f\n\x0fquantity_change\x18\x03 \x01(\x05\x42\x06\x8a\xb2\x19\x02\x08\x01\x12\x17\n\x06reason\x18\x04 \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\x12\x1b\n\nupdated_by\x18\x05 \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\x12 \n\x0fidempotency_key\x18\x06 \x01(\tB\x07\x8a\xb2\x19\x03\x38\x80\x01\"O\n\x17UpdateInventoryResponse\x12\x1c\n\x04item\x18\x01 \x01(\x0b\x32\x0e.store.v1.Item\x12\x16\n\x0eprevious_count\x18\x02 \x01(\x05\"c\n\x18ListLowStockItemsRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x11\n\tpage_size\x18\x02 \x01(\x05\x12\x12\n\npage_token\x18\x03 \x01(\t\"S\n\x19ListLowStockItemsResponse\x12\x1d\n\x05items\x18\x01 \x03(\x0b\x32\x0e.store.v1.Item\x12\x17\n\x0fnext_page_token\x18\x02 \x01(\t\":\n\x16GetTenantConfigRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\"A\n\x17GetTenantConfigResponse\x12&\n\x06\x63onfig\x18\x01 \x01(\x0b\x32\x16.store.v1.TenantConfig\"\x9c\x01\n\x19UpdateTenantConfigRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x32\n\x1b\x64\x65\x66\x61ult_low_stock_threshold\x18\x02 \x01(\x05\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12\x1b\n\nupdated_by\x18\x03 \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01J\x04\x08\x04\x10\x05R\x06quotas\"D\n\x1aUpdateTenantConfigResponse\x12&\n\x06\x63onfig\x18\x01 \x01(\x0b\x32\x16.store.v1.TenantConfig\"\x7f\n\x16SetTenantQuotasRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12&\n\x06quotas\x18\x02 \x01(\x0b\x32\x16.store.v1.TenantQuotas\x12\x1b\n\nupdated_by\x18\x03 \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\"A\n\x17SetTenantQuotasResponse\x12&\n\x06\x63onfig\x18\x01 \x01(\x0b\x32\x16.store.v1.TenantConfig\"9\n\x15GetTenantUsageRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\">\n\x16GetTenantUsageResponse\x12$\n\x05usage\x18\x01 \x01(\x0b\x32\x15.store.v1.TenantUsage\"7\n\x13RecountItemsRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\"G\n\x14RecountItemsResponse\x12\x1b\n\x13previous_item_count\x18\x01 \x01(\x03\x12\x12\n\nitem_count\x18\x02 \x01(\x03\";\n\x0b\x46ieldChange\x12\r\n\x05\x66ield\x18\x01 \x01(\t\x12\x0e\n\x06\x62\x65\x66ore\x18\x02 \x01(\t\x12\r\n\x05\x61\x66ter\x18\x03 \x01(\t\"\xec\x01\n\nAuditEvent\x12\n\n\x02id\x18\x01 \x01(\t\x12\x11\n\ttenant_id\x18\x02 \x01(\x03\x12\x0f\n\x07item_id\x18\x03 \x01(\t\x12\x0e\n\x06method\x18\x04 \x01(\t\x12\r\n\x05\x61\x63tor\x18\x05 \x01(\t\x12&\n\x07\x63hanges\x18\x06 \x03(\x0b\x32\x15.store.v1.FieldChange\x12\x0e\n\x06\x63\x61nary\x18\x07 \x01(\t\x12\x10\n\x08trace_id\x18\x08 \x01(\t\x12\x16\n\x0e\x63lient_address\x18\t \x01(\t\x12-\n\ttimestamp\x18\n \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"\x80\x02\n\x16ListAuditEventsRequest\x12 \n\ttenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x11\x00\x00\x00\x00\x00\x00\x00\x00\x12\x0f\n\x07item_id\x18\x02 \x01(\t\x12\x16\n\x05\x61\x63tor\x18\x03 \x01(\tB\x07\x8a\xb2\x19\x03\x38\xc8\x01\x12\x16\n\x06method\x18\x04 \x01(\tB\x06\x8a\xb2\x19\x02\x38\x64\x12.\n\nstart_time\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12,\n\x08\x65nd_time\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x11\n\tpage_size\x18\x07 \x01(\x05\x12\x12\n\npage_token\x18\x08 \x01(\t\"X\n\x17ListAuditEventsResponse\x12$\n\x06\x65vents\x18\x01 \x03(\x0b\x32\x14.store.v1.AuditEvent\x12\x17\n\x0fnext_page_token\x18\x02 \x01(\t\"\x87\x01\n\x10\x44\x65\x62ugLoggingRule\x12\x18\n\x10target_tenant_id\x18\x01 \x01(\x03\x12\x13\n\x0bheader_name\x18\x02 \x01(\t\x12\x14\n\x0cheader_value\x18\x03 \x01(\t\x12.\n\nexpires_at\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"\x13\n\x11GetLoggingRequest\"T\n\x12GetLoggingResponse\x12\r\n\x05level\x18\x01 \x01(\t\x12/\n\x0b\x64\x65\x62ug_rules\x18\x02 \x03(\x0b\x32\x1a.store.v1.DebugLoggingRule\"+\n\x12SetLogLevelRequest\x12\x15\n\x05level\x18\x01 \x01(\tB\x06\x8a\xb2\x19\x02\x08\x01\"<\n\x13SetLogLevelResponse\x12\r\n\x05level\x18\x01 \x01(\t\x12\x16\n\x0eprevious_level\x18\x02 \x01(\t\"\x9c\x01\n\x19\x45nableDebugLoggingRequest\x12\'\n\x10target_tenant_id\x18\x01 \x01(\x03\x42\r\x8a\xb2\x19\t\x19\x00\x00\x00\x00\x00\x00\x00\x00\x12\x13\n\x0bheader_name\x18\x02 \x01(\t\x12\x14\n\x0cheader_value\x18\x03 \x01(\t\x12+\n\x08\x64uration\x18\x04 \x01(\x0b\x32\x19.google.protobuf.Duration\"F\n\x1a\x45nableDebugLoggingResponse\x12(\n\x04rule\x18\x01 \x01(\x0b\x32\x1a.store.v1.DebugLoggingRule\"\x1a\n\x18\x43learDebugLoggingRequest\",\n\x19\x43learDebugLoggingResponse\x12\x0f\n\x07removed\x18\x01 \x01(\x05*\xb3\x01\n\x0cItemCategory\x12\x1d\n\x19ITEM_CATEGORY_UNSPECIFIED\x10\x00\x12\x1d\n\x19ITEM_CATEGORY_ELECTRONICS\x10\x01\x12\x1a\n\x16ITEM_CATEGORY_CLOTHING\x10\x02\x12\x17\n\x13ITEM_CATEGORY_BOOKS\x10\x03\x12\x16\n\x12ITEM_CATEGORY_HOME\x10\x04\x12\x18\n\x14ITEM_CATEGORY_SPORTS\x10\x05*\x97\x01\n\nItemStatus\x12\x1b\n\x17ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x16\n\x12ITEM_STATUS_ACTIVE\x10\x01\x12\x18\n\x14ITEM_STATUS_INACTIVE\x10\x02\x12\x1c\n\x18ITEM_STATUS_OUT_OF_STOCK\x10\x03\x12\x1c\n\x18ITEM_STATUS_DISCONTINUED\x10\x04\x32\x83\r\n\x0cStoreService\x12q\n\nCreateItem\x12\x1b.store.v1.CreateItemRequest\x1a\x1c.store.v1.CreateItemResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/tenants/{tenant_id}/items\x12j\n\x07GetItem\x12\x18.store.v1.GetItemRequest\x1a\x19.store.v1.GetItemResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/tenants/{tenant_id}/items/{id}\x12v\n\nUpdateItem\x12\x1b.store.v1.UpdateItemRequest\x1a\x1c.store.v1.UpdateItemResponse\"-\x82\xd3\xe4\x93\x02\':\x01*\x1a\"/v1/tenants/{tenant_id}/items/{id}\x12s\n\nDeleteItem\x12\x1b.store.v1.DeleteItemRequest\x1a\x1c.store.v1.DeleteItemResponse\"*\x82\xd3\xe4\x93\x02$*\"/v1/tenants/{tenant_id}/items/{id}\x12k\n\tListItems\x12\x1a.store.v1.ListItemsRequest\x1a\x1b.store.v1.ListItemsResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/tenants/{tenant_id}/items\x12\x94\x01\n\x0fUpdateInventory\x12 .store.v1.UpdateInventoryRequest\x1a!.store.v1.UpdateInventoryResponse\"<\x82\xd3\xe4\x93\x02\x36:\x01*\"1/v1/tenants/{tenant_id}/items/{item_id}/inventory\x12\x8d\x01\n\x11ListLowStockItems\x12\".store.v1.ListLowStockItemsRequest\x1a#.store.v1.ListLowStockItemsResponse\"/\x82\xd3\xe4\x93\x02)\x12\'/v1/tenants/{tenant_id}/low-stock-items\x12~\n\x0fGetTenantConfig\x12 .store.v1.GetTenantConfigRequest\x1a!.store.v1.GetTenantConfigResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/v1/tenants/{tenant_id}/config\x12\x8a\x01\n\x12UpdateTenantConfig\x12#.store.v1.UpdateTenantConfigRequest\x1a$.store.v1.UpdateTenantConfigResponse\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/v1/tenants/{tenant_id}/config\x12\x81\x01\n\x0fSetTenantQuotas\x12 .store.v1.SetTenantQuotasRequest\x1a!.store.v1.SetTenantQuotasResponse\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/v1/tenants/{tenant_id}/quotas\x12z\n\x0eGetTenantUsage\x12\x1f.store.v1.GetTenantUsageRequest\x1a .store.v1.GetTenantUsageResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/tenants/{tenant_id}/usage\x12\x7f\n\x0cRecountItems\x12\x1d.store.v1.RecountItemsRequest\x1a\x1e.store.v1.RecountItemsResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/tenants/{tenant_id}/usage/recount\x12\x84\x01\n\x0fListAuditEvents\x12 .store.v1.ListAuditEventsRequest\x1a!.store.v1.ListAuditEventsResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/tenants/{tenant_id}/audit-events2\xe2\x02\n\x0c\x41\x64minService\x12G\n\nGetLogging\x12\x1b.store.v1.GetLoggingRequest\x1a\x1c.store.v1.GetLoggingResponse\x12J\n\x0bSetLogLevel\x12\x1c.store.v1.SetLogLevelRequest\x1a\x1d.store.v1.SetLogLevelResponse\x12_\n\x12\x45nableDebugLogging\x12#.store.v1.EnableDebugLoggingRequest\x1a$.store.v1.EnableDebugLoggingResponse\x12\\\n\x11\x43learDebugLogging\x12\".store.v1.ClearDebugLoggingRequest\x1a#.store.v1.ClearDebugLoggingResponseB7Z5github.com/rinsecrm/store-service/proto/go;storeprotob\x06proto3"
 
 pool = ::Google::Protobuf::DescriptorPool.generated_pool
 pool.add_serialized_file(descriptor_data)
@@ -15,6 +18,9 @@ pool.add_serialized_file(descriptor_data)
 module Store
   module V1
     Item = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.Item").msgclass
+    TenantQuotas = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.TenantQuotas").msgclass
+    TenantConfig = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.TenantConfig").msgclass
+    TenantUsage = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.TenantUsage").msgclass
     CreateItemRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.CreateItemRequest").msgclass
     CreateItemResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.CreateItemResponse").msgclass
     GetItemRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.GetItemRequest").msgclass
@@ -27,6 +33,31 @@ module Store
     ListItemsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ListItemsResponse").msgclass
     UpdateInventoryRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.UpdateInventoryRequest").msgclass
     UpdateInventoryResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.UpdateInventoryResponse").msgclass
+    ListLowStockItemsRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ListLowStockItemsRequest").msgclass
+    ListLowStockItemsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ListLowStockItemsResponse").msgclass
+    GetTenantConfigRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.GetTenantConfigRequest").msgclass
+    GetTenantConfigResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.GetTenantConfigResponse").msgclass
+    UpdateTenantConfigRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.UpdateTenantConfigRequest").msgclass
+    UpdateTenantConfigResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.UpdateTenantConfigResponse").msgclass
+    SetTenantQuotasRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.SetTenantQuotasRequest").msgclass
+    SetTenantQuotasResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.SetTenantQuotasResponse").msgclass
+    GetTenantUsageRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.GetTenantUsageRequest").msgclass
+    GetTenantUsageResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.GetTenantUsageResponse").msgclass
+    RecountItemsRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.RecountItemsRequest").msgclass
+    RecountItemsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.RecountItemsResponse").msgclass
+    FieldChange = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.FieldChange").msgclass
+    AuditEvent = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.AuditEvent").msgclass
+    ListAuditEventsRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ListAuditEventsRequest").msgclass
+    ListAuditEventsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ListAuditEventsResponse").msgclass
+    DebugLoggingRule = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.DebugLoggingRule").msgclass
+    GetLoggingRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.GetLoggingRequest").msgclass
+    GetLoggingResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.GetLoggingResponse").msgclass
+    SetLogLevelRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.SetLogLevelRequest").msgclass
+    SetLogLevelResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.SetLogLevelResponse").msgclass
+    EnableDebugLoggingRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.EnableDebugLoggingRequest").msgclass
+    EnableDebugLoggingResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.EnableDebugLoggingResponse").msgclass
+    ClearDebugLoggingRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ClearDebugLoggingRequest").msgclass
+    ClearDebugLoggingResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ClearDebugLoggingResponse").msgclass
     ItemCategory = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ItemCategory").enummodule
     ItemStatus = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.ItemStatus").enummodule
   end
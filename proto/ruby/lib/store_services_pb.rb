@@ -28,6 +28,43 @@ module Store
         rpc :ListItems, ::Store::V1::ListItemsRequest, ::Store::V1::ListItemsResponse
         # UpdateInventory updates the inventory count for an item
         rpc :UpdateInventory, ::Store::V1::UpdateInventoryRequest, ::Store::V1::UpdateInventoryResponse
+        # ListLowStockItems lists items whose inventory is at or below their low stock threshold
+        rpc :ListLowStockItems, ::Store::V1::ListLowStockItemsRequest, ::Store::V1::ListLowStockItemsResponse
+        # GetTenantConfig retrieves the settings for a tenant
+        rpc :GetTenantConfig, ::Store::V1::GetTenantConfigRequest, ::Store::V1::GetTenantConfigResponse
+        # UpdateTenantConfig updates the settings for a tenant
+        rpc :UpdateTenantConfig, ::Store::V1::UpdateTenantConfigRequest, ::Store::V1::UpdateTenantConfigResponse
+        # SetTenantQuotas replaces the plan limits of a tenant; only operators may call it
+        rpc :SetTenantQuotas, ::Store::V1::SetTenantQuotasRequest, ::Store::V1::SetTenantQuotasResponse
+        # GetTenantUsage reports a tenant's current usage against each of its quotas
+        rpc :GetTenantUsage, ::Store::V1::GetTenantUsageRequest, ::Store::V1::GetTenantUsageResponse
+        # RecountItems resets a tenant's item count to its items that are not
+        # discontinued, backfilling counts that predate quotas; only operators may call it
+        rpc :RecountItems, ::Store::V1::RecountItemsRequest, ::Store::V1::RecountItemsResponse
+        # ListAuditEvents lists the audit trail of catalog mutations for a tenant
+        rpc :ListAuditEvents, ::Store::V1::ListAuditEventsRequest, ::Store::V1::ListAuditEventsResponse
+      end
+
+      Stub = Service.rpc_stub_class
+    end
+    module AdminService
+      # AdminService controls operational settings of a running instance
+      class Service
+
+        include ::GRPC::GenericService
+
+        self.marshal_class_method = :encode
+        self.unmarshal_class_method = :decode
+        self.service_name = 'store.v1.AdminService'
+
+        # GetLogging reports the log level and active debug logging rules
+        rpc :GetLogging, ::Store::V1::GetLoggingRequest, ::Store::V1::GetLoggingResponse
+        # SetLogLevel changes the log level until the next restart
+        rpc :SetLogLevel, ::Store::V1::SetLogLevelRequest, ::Store::V1::SetLogLevelResponse
+        # EnableDebugLogging logs at debug level for matching requests for a limited time
+        rpc :EnableDebugLogging, ::Store::V1::EnableDebugLoggingRequest, ::Store::V1::EnableDebugLoggingResponse
+        # ClearDebugLogging removes every debug logging rule
+        rpc :ClearDebugLogging, ::Store::V1::ClearDebugLoggingRequest, ::Store::V1::ClearDebugLoggingResponse
       end
 
       Stub = Service.rpc_stub_class
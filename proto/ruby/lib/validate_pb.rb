@@ -0,0 +1,19 @@
+# frozen_string_literal: true
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# source: validate.proto
+
+require 'google/protobuf'
+
+require 'google/protobuf/descriptor_pb'
+
+
+descriptor_data = "\n\x0evalidate.proto\x12\x08store.v1\x1a google/protobuf/descriptor.proto\"\x83\x02\n\nFieldRules\x12\x10\n\x08required\x18\x01 \x01(\x08\x12\x0f\n\x02gt\x18\x02 \x01(\x01H\x00\x88\x01\x01\x12\x10\n\x03gte\x18\x03 \x01(\x01H\x01\x88\x01\x01\x12\x0f\n\x02lt\x18\x04 \x01(\x01H\x02\x88\x01\x01\x12\x10\n\x03lte\x18\x05 \x01(\x01H\x03\x88\x01\x01\x12\x0f\n\x07min_len\x18\x06 \x01(\r\x12\x0f\n\x07max_len\x18\x07 \x01(\r\x12\x0f\n\x07pattern\x18\x08 \x01(\t\x12\x14\n\x0c\x64\x65\x66ined_only\x18\t \x01(\x08\x12\x11\n\tmax_items\x18\n \x01(\r\x12#\n\x05items\x18\x0b \x01(\x0b\x32\x14.store.v1.FieldRulesB\x05\n\x03_gtB\x06\n\x04_gteB\x05\n\x03_ltB\x06\n\x04_lte:K\n\x05rules\x12\x1d.google.protobuf.FieldOptions\x18\xa1\x96\x03 \x01(\x0b\x32\x14.store.v1.FieldRulesR\x05rulesB7Z5github.com/rinsecrm/store-service/proto/go;storeprotob\x06proto3"
+
+pool = ::Google::Protobuf::DescriptorPool.generated_pool
+pool.add_serialized_file(descriptor_data)
+
+module Store
+  module V1
+    FieldRules = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("store.v1.FieldRules").msgclass
+  end
+end